@@ -97,4 +97,94 @@ Chart label
 */}}
 {{- define "kube-mind-observer.chart" -}}
 {{- printf "%s-%s" .Chart.Name .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" -}}
-{{- end -}}
\ No newline at end of file
+{{- end -}}
+{{/*
+Rules on the namespaced resources the Observer reads and writes, shared by the ClusterRole
+and the per-namespace Roles.
+*/}}
+{{- define "kube-mind-observer.namespacedRules" -}}
+# Core Kubernetes resources: READ-ONLY.
+# The Observer never mutates pods or events.
+# All remediation is performed by the Brain via GitHub PRs only.
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+# Logs and previous logs collectors.
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+# Events collector: the events of a failing pod are listed on demand, never cached.
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - list
+# Workload owners: READ-ONLY. Used to resolve a failing pod's owner chain
+# (ReplicaSet → Deployment, Job → CronJob, ...) for the incident payload, and
+# to watch Deployment rollouts with rollout incidents and Jobs with job incidents.
+- apiGroups:
+  - apps
+  resources:
+  - replicasets
+  - deployments
+  - statefulsets
+  - daemonsets
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  - cronjobs
+  verbs:
+  - get
+  - list
+  - watch
+# Incident resources: the only objects the Observer creates. One Incident per
+# fingerprint records occurrences, delivery state and resolution of a failure.
+- apiGroups:
+  - observer.tutorial.kubebuilder.io
+  resources:
+  - incidents
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+- apiGroups:
+  - observer.tutorial.kubebuilder.io
+  resources:
+  - incidents/status
+  verbs:
+  - get
+  - update
+# Silences: read to suppress matching incidents, status updated with their state and
+# suppression count.
+- apiGroups:
+  - observer.tutorial.kubebuilder.io
+  resources:
+  - silences
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - observer.tutorial.kubebuilder.io
+  resources:
+  - silences/status
+  verbs:
+  - get
+  - update
+{{- end -}}
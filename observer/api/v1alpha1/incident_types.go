@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeliveryState is the outcome of delivering the latest occurrence of an incident to a sink.
+// +kubebuilder:validation:Enum=Pending;Delivered;Failed
+type DeliveryState string
+
+const (
+	DeliveryPending   DeliveryState = "Pending"
+	DeliveryDelivered DeliveryState = "Delivered"
+	DeliveryFailed    DeliveryState = "Failed"
+)
+
+// WorkloadReference identifies the top-level workload an incident belongs to.
+type WorkloadReference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// String returns the workload as Kind/name.
+func (w WorkloadReference) String() string {
+	return w.Kind + "/" + w.Name
+}
+
+// IncidentSpec is the redacted summary of an incident. It identifies what failed and is the
+// same for every occurrence with the same fingerprint.
+type IncidentSpec struct {
+	// Fingerprint groups occurrences of the same failure of the same workload.
+	Fingerprint string `json:"fingerprint"`
+	// ClusterID is the cluster the incident was detected in, if configured.
+	// +optional
+	ClusterID string            `json:"clusterID,omitempty"`
+	Workload  WorkloadReference `json:"workload"`
+	// +optional
+	Container string `json:"container,omitempty"`
+	// Reason is the failure reason, such as CrashLoopBackOff or OOMKilled.
+	Reason string `json:"reason"`
+	// Summary is a one-line, human-readable description of the incident.
+	Summary string `json:"summary"`
+	// LogExcerpt holds the last log lines of the latest occurrence, as sent to the Brain after
+	// the redaction rules of the ObserverPolicy.
+	// +optional
+	LogExcerpt string `json:"logExcerpt,omitempty"`
+}
+
+// IncidentDelivery records how the latest occurrence was delivered to one sink.
+type IncidentDelivery struct {
+	Sink  string        `json:"sink"`
+	State DeliveryState `json:"state"`
+	// IncidentID is the occurrence the state refers to.
+	IncidentID         string      `json:"incidentID"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IncidentStatus tracks the occurrences of an incident.
+type IncidentStatus struct {
+	// Count is the number of occurrences reported by the Observer.
+	Count int64 `json:"count"`
+	// +optional
+	FirstSeen *metav1.Time `json:"firstSeen,omitempty"`
+	// +optional
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+	// LastIncidentID is the ID sent to sinks for the latest occurrence.
+	// +optional
+	LastIncidentID string `json:"lastIncidentID,omitempty"`
+	// LastPod is the pod of the latest occurrence.
+	// +optional
+	LastPod string `json:"lastPod,omitempty"`
+	// Deliveries holds the delivery state of the latest occurrence per sink.
+	// +optional
+	// +listType=map
+	// +listMapKey=sink
+	Deliveries []IncidentDelivery `json:"deliveries,omitempty"`
+	// ResolvedAt is set once the workload's container is ready again, and cleared if the incident recurs.
+	// +optional
+	ResolvedAt *metav1.Time `json:"resolvedAt,omitempty"`
+	// SilencedBy is the Silence that suppressed the latest occurrence, if any.
+	// +optional
+	SilencedBy string `json:"silencedBy,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=inc
+// +kubebuilder:printcolumn:name="Workload",type=string,JSONPath=`.spec.workload.name`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.status.count`
+// +kubebuilder:printcolumn:name="Last Seen",type=date,JSONPath=`.status.lastSeen`
+// +kubebuilder:printcolumn:name="Resolved",type=date,JSONPath=`.status.resolvedAt`
+
+// Incident records a failure detected by the Observer, one per fingerprint, in the namespace
+// of the failing workload.
+type Incident struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the redacted summary of the incident
+	// +required
+	Spec IncidentSpec `json:"spec"`
+
+	// status tracks occurrences, delivery and resolution of the incident
+	// +optional
+	Status IncidentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IncidentList contains a list of Incident.
+type IncidentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Incident `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Incident{}, &IncidentList{})
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Collector is a source of context harvested for an incident.
+// +kubebuilder:validation:Enum=logs;previousLogs;events;manifests
+type Collector string
+
+const (
+	// CollectorLogs tails the logs of the failing containers.
+	CollectorLogs Collector = "logs"
+	// CollectorPreviousLogs tails the logs of the previous instance of restarted containers.
+	CollectorPreviousLogs Collector = "previousLogs"
+	// CollectorEvents gathers the pod's events.
+	CollectorEvents Collector = "events"
+	// CollectorManifests attaches the redacted pod and deployment manifests.
+	CollectorManifests Collector = "manifests"
+)
+
+// ObserverPolicyValid is the condition reporting whether a policy can be applied.
+const ObserverPolicyValid = "Valid"
+
+// RedactionRule removes sensitive data from harvested logs and manifests, in addition to the
+// built-in rules.
+type RedactionRule struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Pattern is a Go regular expression.
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+	// Replacement replaces every match; $1-style group references are expanded. Defaults to [REDACTED].
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// ObserverPolicySpec selects pods and sets how their incidents are detected, harvested and
+// delivered. Unset fields keep the Observer's global settings.
+//
+// When several policies match a pod, the one with the highest priority applies. Ties go to
+// the more specific policy, one with a pod selector before one restricted to namespaces before
+// one matching everything, and then to the policy whose name sorts first. Settings of
+// different policies are never merged.
+type ObserverPolicySpec struct {
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Namespaces restricts the policy to these namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector restricts the policy to namespaces with matching labels.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Selector restricts the policy to pods with matching labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Ignore stops the Observer from reporting incidents for the selected pods.
+	// +optional
+	Ignore bool `json:"ignore,omitempty"`
+	// Reasons are the container waiting or termination reasons, or pod reasons such as
+	// Unschedulable, that count as incidents.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+	// LogTailLines is the number of log lines harvested per container.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	// +optional
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+	// Collectors are the sources harvested for an incident. All of them run if unset.
+	// +optional
+	Collectors []Collector `json:"collectors,omitempty"`
+	// Redaction adds rules applied to harvested logs and manifests.
+	// +optional
+	Redaction []RedactionRule `json:"redaction,omitempty"`
+	// DebounceTTL is how long repeated incidents of the same container are suppressed.
+	// +optional
+	DebounceTTL *metav1.Duration `json:"debounceTTL,omitempty"`
+	// Sinks are the sinks that receive the incidents, instead of the namespace's routes.
+	// +optional
+	Sinks []string `json:"sinks,omitempty"`
+}
+
+// ObserverPolicyStatus reports what a policy applies to.
+type ObserverPolicyStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// CoveredPods is the number of pods this policy applies to.
+	// +optional
+	CoveredPods int32 `json:"coveredPods"`
+	// CoveredWorkloads is the number of workloads this policy applies to.
+	// +optional
+	CoveredWorkloads int32 `json:"coveredWorkloads"`
+	// Workloads lists covered workloads as namespace/Kind/name, truncated to the first 100.
+	// +optional
+	Workloads []string `json:"workloads,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=obspol
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+// +kubebuilder:printcolumn:name="Workloads",type=integer,JSONPath=`.status.coveredWorkloads`
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ObserverPolicy scopes what the Observer watches and harvests for a set of pods.
+type ObserverPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec selects pods and sets how their incidents are handled
+	// +required
+	Spec ObserverPolicySpec `json:"spec"`
+
+	// status reports the workloads the policy applies to
+	// +optional
+	Status ObserverPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObserverPolicyList contains a list of ObserverPolicy.
+type ObserverPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObserverPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObserverPolicy{}, &ObserverPolicyList{})
+}
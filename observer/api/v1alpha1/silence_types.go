@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MatcherName is an incident attribute a silence can match on.
+// +kubebuilder:validation:Enum=workload;workloadKind;pod;container;reason;cluster
+type MatcherName string
+
+const (
+	// MatchWorkload matches the top-level workload as Kind/name, such as Deployment/api, or the
+	// node of a node incident, such as Node/worker-1.
+	MatchWorkload     MatcherName = "workload"
+	MatchWorkloadKind MatcherName = "workloadKind"
+	MatchPod          MatcherName = "pod"
+	MatchContainer    MatcherName = "container"
+	MatchReason       MatcherName = "reason"
+	MatchCluster      MatcherName = "cluster"
+)
+
+// SilenceState is whether a silence currently suppresses incidents.
+type SilenceState string
+
+const (
+	SilencePending SilenceState = "Pending"
+	SilenceActive  SilenceState = "Active"
+	SilenceExpired SilenceState = "Expired"
+)
+
+// Matcher selects incidents by one attribute.
+type Matcher struct {
+	Name  MatcherName `json:"name"`
+	Value string      `json:"value"`
+	// Regex matches Value as an anchored Go regular expression.
+	// +optional
+	Regex bool `json:"regex,omitempty"`
+	// Negate selects incidents that do not match.
+	// +optional
+	Negate bool `json:"negate,omitempty"`
+}
+
+// Recurrence restricts a silence to a daily time window, such as a weekly maintenance slot.
+type Recurrence struct {
+	// Weekdays are the days the window opens on, such as Monday. Every day if unset.
+	// +optional
+	Weekdays []string `json:"weekdays,omitempty"`
+	// StartTime opens the window, as HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+	// EndTime closes the window, as HH:MM. A window ending before it starts closes the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+	// TimeZone is the IANA time zone of the window. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// SilenceSpec suppresses matching incidents in the silence's namespace, like an Alertmanager
+// silence. Suppressed incidents are still recorded as Incident resources but are not sent.
+// Silences in the Observer's own namespace also apply to node incidents, which have none.
+type SilenceSpec struct {
+	// Matchers must all match for an incident to be silenced. An empty list silences every
+	// incident in the namespace.
+	// +optional
+	Matchers []Matcher `json:"matchers,omitempty"`
+	// StartsAt is when the silence takes effect. Immediately if unset.
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// EndsAt is when the silence expires. Never if unset.
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+	// Recurrence limits the silence to recurring windows between StartsAt and EndsAt.
+	// +optional
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// SilenceStatus reports the state and effect of a silence.
+type SilenceStatus struct {
+	// +optional
+	State SilenceState `json:"state,omitempty"`
+	// Suppressed is the number of incidents the silence has suppressed.
+	// +optional
+	Suppressed int64 `json:"suppressed,omitempty"`
+	// +optional
+	LastSuppressed *metav1.Time `json:"lastSuppressed,omitempty"`
+	// Message explains why the silence cannot be applied, if it is invalid.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Suppressed",type=integer,JSONPath=`.status.suppressed`
+// +kubebuilder:printcolumn:name="Ends",type=date,JSONPath=`.spec.endsAt`
+// +kubebuilder:printcolumn:name="Comment",type=string,JSONPath=`.spec.comment`,priority=1
+
+// Silence suppresses incident reporting during planned work such as chaos tests and migrations.
+type Silence struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec selects the incidents to suppress and when
+	// +required
+	Spec SilenceSpec `json:"spec"`
+
+	// status reports whether the silence is active and what it suppressed
+	// +optional
+	Status SilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceList contains a list of Silence.
+type SilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Silence `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Silence{}, &SilenceList{})
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
@@ -28,17 +32,27 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
 	"kube-mind/observer/internal/comms"
 	observerconfig "kube-mind/observer/internal/config"
 	"kube-mind/observer/internal/controller"
 	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/incident"
+	"kube-mind/observer/internal/multicluster"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/shard"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -50,6 +64,8 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(observerv1alpha1.AddToScheme(scheme))
+
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -65,6 +81,24 @@ func main() {
 	var grpcServerAddress string
 	var grpcCaCertPath, grpcClientCertPath, grpcClientKeyPath string
 	var grpcInsecure bool
+	var grpcCompression string
+	var grpcQueueSize int
+	var grpcBalancing, grpcMirrorAddress string
+	var sinksConfigPath string
+	var dryRun bool
+	var dryRunOutput string
+	var dryRunBuffer int
+	var incidentResources bool
+	var observerPolicies bool
+	var silences bool
+	var namespaced bool
+	var sharding bool
+	var shardKey string
+	var nodeIncidents bool
+	var rolloutIncidents bool
+	var jobIncidents bool
+	var clusterSecretsNamespace string
+	var kubeconfigDir string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -83,11 +117,55 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	flag.StringVar(&grpcServerAddress, "grpc-server-address", "localhost:50051", "The address of the gRPC Brain server.")
+	flag.StringVar(&grpcServerAddress, "grpc-server-address", "localhost:50051",
+		"Comma-separated addresses of the gRPC Brain servers, in priority order. "+
+			"Use dns:///<headless-service>:<port> to discover every Brain behind a headless Service.")
+	flag.StringVar(&grpcBalancing, "grpc-balancing", string(comms.PolicyPriority),
+		"How incidents are spread over the gRPC Brain servers: priority (failover in order) or round-robin.")
+	flag.StringVar(&grpcMirrorAddress, "grpc-mirror-address", "",
+		"If set, every incident is also sent to this secondary gRPC Brain server on a best-effort basis.")
 	flag.StringVar(&grpcCaCertPath, "grpc-ca-cert", "", "Path to the gRPC CA certificate.")
 	flag.StringVar(&grpcClientCertPath, "grpc-client-cert", "", "Path to the gRPC client certificate.")
 	flag.StringVar(&grpcClientKeyPath, "grpc-client-key", "", "Path to the gRPC client key.")
 	flag.BoolVar(&grpcInsecure, "grpc-insecure", false, "If set, connect to the gRPC server without mTLS (for local development).")
+	flag.StringVar(&grpcCompression, "grpc-compression", strings.Join(comms.DefaultCompressors, ","),
+		"Comma-separated compressors offered to the gRPC server, preferred first, or \"none\" to disable compression.")
+	flag.StringVar(&sinksConfigPath, "sinks-config", "",
+		"Path to a YAML file configuring incident sinks and per-namespace routes. If empty, incidents go to the gRPC Brain only.")
+	flag.IntVar(&grpcQueueSize, "grpc-queue-size", comms.DefaultQueueCapacity,
+		"The number of incidents kept in memory while the gRPC server is unavailable.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, incidents are recorded locally instead of being sent to the gRPC Brain. "+
+			"Recent incidents are served on the metrics server at "+comms.RecorderPath+".")
+	flag.StringVar(&dryRunOutput, "dry-run-output", "",
+		"If set with --dry-run, recorded incidents are also appended to this JSON-lines file.")
+	flag.IntVar(&dryRunBuffer, "dry-run-buffer", comms.DefaultRecorderCapacity,
+		"The number of recent incidents served by the dry-run endpoint.")
+	flag.BoolVar(&incidentResources, "incident-resources", true,
+		"If set, every incident is recorded as an Incident resource in the namespace of the failing workload.")
+	flag.BoolVar(&observerPolicies, "observer-policies", true,
+		"If set, ObserverPolicy resources scope which pods are observed and how their incidents are harvested.")
+	flag.BoolVar(&silences, "silences", true,
+		"If set, incidents matched by an active Silence resource are recorded but not sent.")
+	flag.BoolVar(&namespaced, "namespaced", false,
+		"If set, the Observer runs with Roles in WATCH_NAMESPACES only: cluster-scoped resources are not read, "+
+			"so ObserverPolicies and namespace annotations are disabled.")
+	flag.BoolVar(&sharding, "sharding", false,
+		"If set, every replica observes a share of the pods, coordinated through Leases, instead of "+
+			"a single elected leader observing them all. Overrides --leader-elect.")
+	flag.StringVar(&shardKey, "shard-key", string(shard.KeyNamespace),
+		"How pods are split between replicas when sharding: namespace or workload.")
+	flag.BoolVar(&nodeIncidents, "node-incidents", true,
+		"If set, nodes turning NotReady or under pressure, rebooting or restarting their kubelet are reported as incidents.")
+	flag.BoolVar(&rolloutIncidents, "rollout-incidents", true,
+		"If set, Deployment rollouts exceeding their progress deadline or stalled with failing new pods are reported as incidents.")
+	flag.BoolVar(&jobIncidents, "job-incidents", true,
+		"If set, failed Jobs and CronJobs whose last runs failed are reported as incidents, with the logs of each failed attempt.")
+	flag.StringVar(&clusterSecretsNamespace, "cluster-secrets-namespace", "",
+		"If set, every Secret in this namespace labelled "+multicluster.ClusterLabel+" adds a remote cluster "+
+			"from its kubeconfig key; clusters follow the Secrets as they are created, updated and deleted.")
+	flag.StringVar(&kubeconfigDir, "kubeconfig-dir", "",
+		"If set, every kubeconfig file in this directory adds a remote cluster named after the file.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -158,8 +236,69 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	var recorder *comms.Recorder
+	if dryRun {
+		recorder, err = comms.NewRecorder(dryRunOutput, dryRunBuffer)
+		if err != nil {
+			setupLog.Error(err, "unable to create dry-run recorder")
+			os.Exit(1)
+		}
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{comms.RecorderPath: recorder}
+		setupLog.Info("Dry-run mode: incidents will be recorded instead of sent to the Brain",
+			"output", dryRunOutput, "endpoint", comms.RecorderPath)
+	}
+
+	if namespaced {
+		if len(cfg.ObservedNamespaces()) == 0 {
+			setupLog.Error(nil, "--namespaced requires WATCH_NAMESPACES to list the namespaces the Observer has Roles in")
+			os.Exit(1)
+		}
+		if observerPolicies {
+			setupLog.Info("ObserverPolicies are cluster-scoped and disabled in namespaced mode")
+			observerPolicies = false
+		}
+		if nodeIncidents {
+			setupLog.Info("Nodes are cluster-scoped and node incidents are disabled in namespaced mode")
+			nodeIncidents = false
+		}
+	}
+
+	if sharding && enableLeaderElection {
+		setupLog.Info("Sharding enabled: replicas share the pods instead of electing a leader")
+		enableLeaderElection = false
+	}
+
+	multiCluster := clusterSecretsNamespace != "" || kubeconfigDir != ""
+	if multiCluster && sharding {
+		setupLog.Error(nil, "remote clusters are observed by the leader and cannot be combined with --sharding")
+		os.Exit(1)
+	}
+
+	cacheOptions, err := cfg.CacheOptions()
+	if err != nil {
+		setupLog.Error(err, "invalid namespace or pod selection")
+		os.Exit(1)
+	}
+	// Remote clusters cache the same namespaces and pods, but no Secrets.
+	remoteCacheOptions, _ := cfg.CacheOptions()
+	if nodeIncidents {
+		controller.WatchKubeletStarts(&cacheOptions)
+		controller.WatchKubeletStarts(&remoteCacheOptions)
+	}
+	if clusterSecretsNamespace != "" {
+		multicluster.WatchSecrets(&cacheOptions, clusterSecretsNamespace)
+	}
+	if silences {
+		silence.WatchClusterNamespace(&cacheOptions, cfg.LeaderElectionNamespace)
+	}
+	if len(cfg.WatchNamespaces) > 0 || len(cfg.ExcludeNamespaces) > 0 || cfg.PodLabelSelector != "" {
+		setupLog.Info("Restricting the observed pods", "namespaces", cfg.ObservedNamespaces(),
+			"excludedNamespaces", cfg.ExcludeNamespaces, "podLabelSelector", cfg.PodLabelSelector)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                     scheme,
+		Cache:                      cacheOptions,
 		Metrics:                    metricsServerOptions,
 		WebhookServer:              webhookServer,
 		HealthProbeBindAddress:     probeAddr,
@@ -183,6 +322,7 @@ func main() {
 		os.Exit(1)
 	}
 	logAggregator := harvester.NewK8sLogAggregator(clientset)
+	eventCollector := harvester.NewK8sEventCollector(clientset)
 
 	manifestParser, err := harvester.NewManifestParser(mgr.GetClient())
 	if err != nil {
@@ -191,29 +331,300 @@ func main() {
 	}
 	incidentCache := harvester.NewGoCacheIntelligenceCache(cfg.DebounceTTLSeconds, cfg.DebounceTTLSeconds/2)
 
-	grpcClient, err := comms.NewBrainGrpcClient(ctrl.SetupSignalHandler(), grpcServerAddress, grpcInsecure, grpcCaCertPath, grpcClientCertPath, grpcClientKeyPath)
+	var sharder *shard.Sharder
+	if sharding {
+		identity, err := os.Hostname()
+		if err != nil {
+			setupLog.Error(err, "unable to determine the shard identity")
+			os.Exit(1)
+		}
+		sharder, err = shard.NewSharder(mgr.GetClient(), mgr.GetAPIReader(), cfg.LeaderElectionNamespace,
+			cfg.LeaderElectionID, identity, shard.Key(shardKey))
+		if err != nil {
+			setupLog.Error(err, "invalid sharding configuration")
+			os.Exit(1)
+		}
+		sharder.Debounce = incidentCache
+		if err := mgr.Add(sharder); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		setupLog.Info("Sharding pods between replicas", "identity", identity, "key", shardKey)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	var brain comms.GrpcClient = recorder
+	var grpcClient *comms.BrainPool
+	if !dryRun {
+		balancingPolicy, err := comms.ParseBalancingPolicy(grpcBalancing)
+		if err != nil {
+			setupLog.Error(err, "invalid --grpc-balancing")
+			os.Exit(1)
+		}
+		grpcClient, err = comms.NewBrainPool(ctx, comms.BrainPoolOptions{
+			Addresses:      splitList(grpcServerAddress),
+			MirrorAddress:  grpcMirrorAddress,
+			Policy:         balancingPolicy,
+			Insecure:       grpcInsecure,
+			CACertPath:     grpcCaCertPath,
+			ClientCertPath: grpcClientCertPath,
+			ClientKeyPath:  grpcClientKeyPath,
+			ClientOptions:  []comms.ClientOption{comms.WithCompressors(parseCompressors(grpcCompression)...)},
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create gRPC client")
+			os.Exit(1)
+		}
+		brain = grpcClient
+	}
+	incidentQueue := comms.NewIncidentQueue(brain, grpcQueueSize)
+	defer func() {
+		if err := incidentQueue.Close(); err != nil {
+			setupLog.Error(err, "failed to close gRPC client")
+		}
+	}()
+	if err := mgr.Add(incidentQueue); err != nil {
+		setupLog.Error(err, "unable to set up incident queue")
+		os.Exit(1)
+	}
+
+	if dryRun && sinksConfigPath != "" {
+		// Nothing may leave the cluster in a dry run, so other sinks are not set up either.
+		setupLog.Info("Dry-run mode: ignoring --sinks-config", "path", sinksConfigPath)
+		sinksConfigPath = ""
+	}
+	sinksConfig, err := sink.LoadConfig(sinksConfigPath)
 	if err != nil {
-		setupLog.Error(err, "unable to create gRPC client")
+		setupLog.Error(err, "unable to load sinks config")
+		os.Exit(1)
+	}
+	router, err := sinksConfig.Build(incidentQueue)
+	if err != nil {
+		setupLog.Error(err, "unable to set up incident sinks")
 		os.Exit(1)
 	}
 	defer func() {
-		if err := grpcClient.Close(); err != nil {
-			setupLog.Error(err, "failed to close gRPC client")
+		if err := router.Close(); err != nil {
+			setupLog.Error(err, "failed to close incident sinks")
 		}
 	}()
+	if err := mgr.Add(router); err != nil {
+		setupLog.Error(err, "unable to set up incident router")
+		os.Exit(1)
+	}
+
+	var incidentStore *incident.Store
+	if incidentResources {
+		incidentStore = incident.NewStore(mgr.GetClient(), mgr.GetAPIReader())
+		router.Reporter = incidentStore
+	}
+
+	var policyResolver *policy.Resolver
+	if observerPolicies {
+		policyResolver = policy.NewResolver(mgr.GetClient(), cfg)
+		if err = (&controller.ObserverPolicyReconciler{
+			Client: mgr.GetClient(),
+			Sinks:  router.Has,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ObserverPolicy")
+			os.Exit(1)
+		}
+	}
+
+	var silenceChecker *silence.Checker
+	if silences {
+		silenceChecker = silence.NewChecker(mgr.GetClient())
+		silenceChecker.ClusterNamespace = cfg.LeaderElectionNamespace
+		if err = (&controller.SilenceReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Silence")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&controller.PodReconciler{
 		Client:         mgr.GetClient(),
 		Scheme:         mgr.GetScheme(),
 		LogAggregator:  logAggregator,
+		EventCollector: eventCollector,
 		ManifestParser: manifestParser,
 		IncidentCache:  incidentCache,
-		GrpcClient:     grpcClient,
+		Sink:           router,
+		Incidents:      incidentStore,
+		Policies:       policyResolver,
+		Silences:       silenceChecker,
+		Namespaced:     namespaced,
+		Shards:         sharder,
+		ClusterID:      cfg.ClusterID,
+		APIReader:      mgr.GetAPIReader(),
+		JobIncidents:   jobIncidents,
 		Config:         cfg,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Pod")
 		os.Exit(1)
 	}
+	if nodeIncidents {
+		if err = (&controller.NodeReconciler{
+			Client:         mgr.GetClient(),
+			APIReader:      mgr.GetAPIReader(),
+			EventCollector: eventCollector,
+			IncidentCache:  incidentCache,
+			Sink:           router,
+			Silences:       silenceChecker,
+			Shards:         sharder,
+			ClusterID:      cfg.ClusterID,
+			Config:         cfg,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Node")
+			os.Exit(1)
+		}
+	}
+	if rolloutIncidents {
+		if err = (&controller.DeploymentReconciler{
+			Client:         mgr.GetClient(),
+			EventCollector: eventCollector,
+			ManifestParser: manifestParser,
+			IncidentCache:  incidentCache,
+			Sink:           router,
+			Incidents:      incidentStore,
+			Policies:       policyResolver,
+			Silences:       silenceChecker,
+			Namespaced:     namespaced,
+			Shards:         sharder,
+			ClusterID:      cfg.ClusterID,
+			Config:         cfg,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Deployment")
+			os.Exit(1)
+		}
+	}
+	if jobIncidents {
+		if err = (&controller.JobReconciler{
+			Client:         mgr.GetClient(),
+			LogAggregator:  logAggregator,
+			EventCollector: eventCollector,
+			IncidentCache:  incidentCache,
+			Sink:           router,
+			Incidents:      incidentStore,
+			Policies:       policyResolver,
+			Silences:       silenceChecker,
+			Namespaced:     namespaced,
+			Shards:         sharder,
+			ClusterID:      cfg.ClusterID,
+			Config:         cfg,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Job")
+			os.Exit(1)
+		}
+	}
+
+	if multiCluster {
+		// Remote clusters share the sinks and Silences of the local cluster. Incident resources and
+		// ObserverPolicies live in the local cluster only and do not apply to them.
+		clusters := multicluster.NewClusters(func(ctx context.Context, id string, restConfig *rest.Config) error {
+			remote, err := ctrl.NewManager(restConfig, ctrl.Options{
+				Scheme:  scheme,
+				Cache:   remoteCacheOptions,
+				Metrics: metricsserver.Options{BindAddress: "0"},
+				// Every cluster runs a "pod" controller; their names only need to be unique per manager.
+				Controller: ctrlconfig.Controller{SkipNameValidation: ptr.To(true)},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create manager: %w", err)
+			}
+			remoteClientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create clientset: %w", err)
+			}
+			remoteParser, err := harvester.NewManifestParser(remote.GetClient())
+			if err != nil {
+				return fmt.Errorf("failed to create manifest parser: %w", err)
+			}
+			remoteEvents := harvester.NewK8sEventCollector(remoteClientset)
+			remoteCache := harvester.NewGoCacheIntelligenceCache(cfg.DebounceTTLSeconds, cfg.DebounceTTLSeconds/2)
+			if err := (&controller.PodReconciler{
+				Client:         remote.GetClient(),
+				Scheme:         remote.GetScheme(),
+				LogAggregator:  harvester.NewK8sLogAggregator(remoteClientset),
+				EventCollector: remoteEvents,
+				ManifestParser: remoteParser,
+				IncidentCache:  remoteCache,
+				Sink:           router,
+				Silences:       silenceChecker,
+				ClusterID:      id,
+				APIReader:      remote.GetAPIReader(),
+				JobIncidents:   jobIncidents,
+				Config:         cfg,
+			}).SetupWithManager(remote); err != nil {
+				return fmt.Errorf("failed to create pod controller: %w", err)
+			}
+			if nodeIncidents {
+				if err := (&controller.NodeReconciler{
+					Client:         remote.GetClient(),
+					APIReader:      remote.GetAPIReader(),
+					EventCollector: remoteEvents,
+					IncidentCache:  remoteCache,
+					Sink:           router,
+					Silences:       silenceChecker,
+					ClusterID:      id,
+					Config:         cfg,
+				}).SetupWithManager(remote); err != nil {
+					return fmt.Errorf("failed to create node controller: %w", err)
+				}
+			}
+			if rolloutIncidents {
+				if err := (&controller.DeploymentReconciler{
+					Client:         remote.GetClient(),
+					EventCollector: remoteEvents,
+					ManifestParser: remoteParser,
+					IncidentCache:  remoteCache,
+					Sink:           router,
+					Silences:       silenceChecker,
+					ClusterID:      id,
+					Config:         cfg,
+				}).SetupWithManager(remote); err != nil {
+					return fmt.Errorf("failed to create deployment controller: %w", err)
+				}
+			}
+			if jobIncidents {
+				if err := (&controller.JobReconciler{
+					Client:         remote.GetClient(),
+					LogAggregator:  harvester.NewK8sLogAggregator(remoteClientset),
+					EventCollector: remoteEvents,
+					IncidentCache:  remoteCache,
+					Sink:           router,
+					Silences:       silenceChecker,
+					ClusterID:      id,
+					Config:         cfg,
+				}).SetupWithManager(remote); err != nil {
+					return fmt.Errorf("failed to create job controller: %w", err)
+				}
+			}
+			return remote.Start(ctx)
+		})
+		if err := mgr.Add(clusters); err != nil {
+			setupLog.Error(err, "unable to set up remote clusters")
+			os.Exit(1)
+		}
+		if clusterSecretsNamespace != "" {
+			if err = (&controller.ClusterSecretReconciler{
+				Client:    mgr.GetClient(),
+				Clusters:  clusters,
+				Namespace: clusterSecretsNamespace,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ClusterSecret")
+				os.Exit(1)
+			}
+		}
+		if kubeconfigDir != "" {
+			if err := mgr.Add(multicluster.NewDirectory(kubeconfigDir, clusters)); err != nil {
+				setupLog.Error(err, "unable to set up remote clusters from directory")
+				os.Exit(1)
+			}
+		}
+		setupLog.Info("Observing remote clusters", "secretsNamespace", clusterSecretsNamespace, "kubeconfigDir", kubeconfigDir)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -224,10 +635,48 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if grpcClient != nil {
+		if err := mgr.AddReadyzCheck("brain", grpcClient.ReadyCheck); err != nil {
+			setupLog.Error(err, "unable to set up gRPC ready check")
+			os.Exit(1)
+		}
+	}
+	if grpcClient != nil && grpcClient.Certs != nil {
+		if err := mgr.Add(grpcClient.Certs); err != nil {
+			setupLog.Error(err, "unable to set up gRPC certificate reloader")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("brain-certificates", grpcClient.Certs.ReadyCheck); err != nil {
+			setupLog.Error(err, "unable to set up gRPC certificate ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// parseCompressors splits the --grpc-compression flag; "none" or an empty value disables compression.
+func parseCompressors(value string) []string {
+	var names []string
+	for _, name := range splitList(value) {
+		if name != "none" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// splitList splits a comma-separated flag value, dropping blanks.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,271 @@
+// Package cloudevents encodes incidents as CloudEvents v1.0 for event buses, in structured or
+// binary HTTP mode with JSON or protobuf data.
+package cloudevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+
+	pb "kube-mind/observer/proto"
+)
+
+// SpecVersion is the CloudEvents specification version produced by this package.
+const SpecVersion = "1.0"
+
+// Event types of incidents. A fingerprint is reported as detected the first time it is seen
+// and as updated for every later occurrence.
+const (
+	TypeIncidentDetected = "io.kubemind.observer.incident.detected.v1"
+	TypeIncidentUpdated  = "io.kubemind.observer.incident.updated.v1"
+)
+
+// Extension attributes carried by every incident event.
+const (
+	ExtensionCluster       = "kubemindcluster"
+	ExtensionFingerprint   = "kubemindfingerprint"
+	ExtensionSchemaVersion = "kubemindschema"
+)
+
+// Mode is the HTTP content mode of an event.
+type Mode string
+
+const (
+	// ModeStructured sends the whole event, attributes and data, as one JSON document.
+	ModeStructured Mode = "structured"
+	// ModeBinary sends attributes as ce- headers and the data as the raw body.
+	ModeBinary Mode = "binary"
+)
+
+// DataFormat is the encoding of the incident inside an event.
+type DataFormat string
+
+const (
+	DataJSON     DataFormat = "json"
+	DataProtobuf DataFormat = "protobuf"
+)
+
+const (
+	contentTypeJSON       = "application/json"
+	contentTypeProtobuf   = "application/protobuf"
+	contentTypeStructured = "application/cloudevents+json"
+
+	defaultCluster = "default"
+)
+
+// ErrInvalidEvent is returned when a request cannot be decoded as a CloudEvent.
+var ErrInvalidEvent = errors.New("invalid CloudEvent")
+
+// Event is a CloudEvent. Data holds the encoded payload as described by DataContentType.
+type Event struct {
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	Extensions      map[string]string
+	Data            []byte
+}
+
+// Source returns the stable event source of a cluster's Observer.
+func Source(clusterID string) string {
+	if clusterID == "" {
+		clusterID = defaultCluster
+	}
+	return "/kube-mind/observer/clusters/" + clusterID
+}
+
+// Subject returns the stable subject of an incident: its namespace and top-level workload.
+func Subject(incident *pb.IncidentContext) string {
+	return incident.GetPodNamespace() + "/" + pb.Workload(incident)
+}
+
+// NewIncidentEvent wraps an incident in an event of the given type. Updates of the same
+// incident get distinct IDs so that consumers do not discard them as duplicates.
+func NewIncidentEvent(incident *pb.IncidentContext, eventType string, format DataFormat, now time.Time) (*Event, error) {
+	event := &Event{
+		ID:      incident.GetIncidentId(),
+		Source:  Source(incident.GetClusterId()),
+		Type:    eventType,
+		Subject: Subject(incident),
+		Time:    now.UTC(),
+		Extensions: map[string]string{
+			ExtensionFingerprint:   pb.Fingerprint(incident),
+			ExtensionSchemaVersion: strconv.FormatUint(uint64(pb.SchemaVersion), 10),
+		},
+	}
+	if eventType == TypeIncidentUpdated {
+		event.ID = fmt.Sprintf("%s-%d", incident.GetIncidentId(), now.UnixNano())
+	}
+	if incident.GetClusterId() != "" {
+		event.Extensions[ExtensionCluster] = incident.GetClusterId()
+	}
+
+	var err error
+	switch format {
+	case DataJSON:
+		event.DataContentType = contentTypeJSON
+		event.Data, err = protojson.Marshal(incident)
+	case DataProtobuf:
+		event.DataContentType = contentTypeProtobuf
+		event.Data, err = gproto.Marshal(incident)
+	default:
+		return nil, fmt.Errorf("unknown data format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode incident %s: %w", incident.GetIncidentId(), err)
+	}
+	return event, nil
+}
+
+// Incident decodes the incident carried in the event's data.
+func (e *Event) Incident() (*pb.IncidentContext, error) {
+	incident := &pb.IncidentContext{}
+	var err error
+	switch mediaType(e.DataContentType) {
+	case contentTypeJSON:
+		err = protojson.Unmarshal(e.Data, incident)
+	case contentTypeProtobuf:
+		err = gproto.Unmarshal(e.Data, incident)
+	default:
+		return nil, fmt.Errorf("%w: unsupported datacontenttype %q", ErrInvalidEvent, e.DataContentType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode incident: %w", err)
+	}
+	return incident, nil
+}
+
+// Encode returns the HTTP headers and body of the event in the given mode.
+func (e *Event) Encode(mode Mode) (http.Header, []byte, error) {
+	switch mode {
+	case ModeBinary:
+		header := http.Header{}
+		header.Set("Content-Type", e.DataContentType)
+		for name, value := range e.attributes() {
+			header.Set("ce-"+name, value)
+		}
+		return header, e.Data, nil
+	case ModeStructured:
+		doc := map[string]any{}
+		for name, value := range e.attributes() {
+			doc[name] = value
+		}
+		doc["datacontenttype"] = e.DataContentType
+		if mediaType(e.DataContentType) == contentTypeJSON {
+			doc["data"] = json.RawMessage(e.Data)
+		} else {
+			doc["data_base64"] = base64.StdEncoding.EncodeToString(e.Data)
+		}
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode CloudEvent %s: %w", e.ID, err)
+		}
+		header := http.Header{}
+		header.Set("Content-Type", contentTypeStructured+"; charset=utf-8")
+		return header, body, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown CloudEvents mode %q", mode)
+	}
+}
+
+// attributes returns every context attribute except datacontenttype, which each mode carries differently.
+func (e *Event) attributes() map[string]string {
+	attrs := map[string]string{
+		"specversion": SpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+		"time":        e.Time.Format(time.RFC3339Nano),
+	}
+	if e.Subject != "" {
+		attrs["subject"] = e.Subject
+	}
+	for name, value := range e.Extensions {
+		attrs[name] = value
+	}
+	return attrs
+}
+
+// Decode reads an event from an HTTP request in either mode.
+func Decode(header http.Header, body []byte) (*Event, error) {
+	attrs := map[string]string{}
+	var event Event
+
+	if mediaType(header.Get("Content-Type")) == contentTypeStructured {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+		for name, raw := range doc {
+			switch name {
+			case "data":
+				event.Data = raw
+			case "data_base64":
+				var encoded string
+				if err := json.Unmarshal(raw, &encoded); err != nil {
+					return nil, fmt.Errorf("%w: data_base64: %v", ErrInvalidEvent, err)
+				}
+				data, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return nil, fmt.Errorf("%w: data_base64: %v", ErrInvalidEvent, err)
+				}
+				event.Data = data
+			default:
+				var value string
+				if err := json.Unmarshal(raw, &value); err != nil {
+					return nil, fmt.Errorf("%w: attribute %s: %v", ErrInvalidEvent, name, err)
+				}
+				attrs[name] = value
+			}
+		}
+	} else {
+		for name, values := range header {
+			if lower := strings.ToLower(name); strings.HasPrefix(lower, "ce-") && len(values) > 0 {
+				attrs[strings.TrimPrefix(lower, "ce-")] = values[0]
+			}
+		}
+		attrs["datacontenttype"] = header.Get("Content-Type")
+		event.Data = body
+	}
+
+	if attrs["specversion"] != SpecVersion {
+		return nil, fmt.Errorf("%w: unsupported specversion %q", ErrInvalidEvent, attrs["specversion"])
+	}
+	event.ID, event.Source, event.Type = attrs["id"], attrs["source"], attrs["type"]
+	if event.ID == "" || event.Source == "" || event.Type == "" {
+		return nil, fmt.Errorf("%w: id, source and type are required", ErrInvalidEvent)
+	}
+	event.Subject = attrs["subject"]
+	event.DataContentType = attrs["datacontenttype"]
+	if ts := attrs["time"]; ts != "" {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return nil, fmt.Errorf("%w: time: %v", ErrInvalidEvent, err)
+		}
+		event.Time = t
+	}
+	for _, name := range []string{"specversion", "id", "source", "type", "subject", "time", "datacontenttype"} {
+		delete(attrs, name)
+	}
+	event.Extensions = attrs
+	return &event, nil
+}
+
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return parsed
+}
@@ -0,0 +1,115 @@
+package cloudevents_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gproto "google.golang.org/protobuf/proto"
+
+	"kube-mind/observer/internal/cloudevents"
+	pb "kube-mind/observer/proto"
+)
+
+func newIncident() *pb.IncidentContext {
+	return &pb.IncidentContext{
+		IncidentId:    "test-incident",
+		PodName:       "api-7d9f-abcde",
+		PodNamespace:  "payments",
+		FailureReason: "OOMKilled",
+		ClusterId:     "prod-eu",
+		Details: &pb.IncidentDetails{
+			ContainerName: "app",
+			OwnerChain:    []*pb.OwnerReference{{Kind: "ReplicaSet", Name: "api-7d9f"}, {Kind: "Deployment", Name: "api"}},
+		},
+	}
+}
+
+func TestNewIncidentEvent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	incident := newIncident()
+
+	detected, err := cloudevents.NewIncidentEvent(incident, cloudevents.TypeIncidentDetected, cloudevents.DataJSON, now)
+	require.NoError(t, err)
+	assert.Equal(t, "test-incident", detected.ID)
+	assert.Equal(t, "/kube-mind/observer/clusters/prod-eu", detected.Source)
+	assert.Equal(t, "payments/Deployment/api", detected.Subject)
+	assert.Equal(t, now, detected.Time)
+	assert.Equal(t, "prod-eu", detected.Extensions[cloudevents.ExtensionCluster])
+	assert.Equal(t, pb.Fingerprint(incident), detected.Extensions[cloudevents.ExtensionFingerprint])
+
+	updated, err := cloudevents.NewIncidentEvent(incident, cloudevents.TypeIncidentUpdated, cloudevents.DataJSON, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, detected.ID, updated.ID, "updates need their own event IDs")
+	assert.Equal(t, detected.Source, updated.Source)
+	assert.Equal(t, detected.Subject, updated.Subject)
+
+	assert.Equal(t, "/kube-mind/observer/clusters/default", cloudevents.Source(""))
+
+	_, err = cloudevents.NewIncidentEvent(incident, cloudevents.TypeIncidentDetected, "xml", now)
+	assert.Error(t, err)
+}
+
+func TestEvent_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		mode                cloudevents.Mode
+		format              cloudevents.DataFormat
+		expectedContentType string
+	}{
+		{name: "Structured JSON", mode: cloudevents.ModeStructured, format: cloudevents.DataJSON, expectedContentType: "application/cloudevents+json; charset=utf-8"},
+		{name: "Structured protobuf", mode: cloudevents.ModeStructured, format: cloudevents.DataProtobuf, expectedContentType: "application/cloudevents+json; charset=utf-8"},
+		{name: "Binary JSON", mode: cloudevents.ModeBinary, format: cloudevents.DataJSON, expectedContentType: "application/json"},
+		{name: "Binary protobuf", mode: cloudevents.ModeBinary, format: cloudevents.DataProtobuf, expectedContentType: "application/protobuf"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			incident := newIncident()
+			event, err := cloudevents.NewIncidentEvent(incident, cloudevents.TypeIncidentDetected, tc.format, time.Now())
+			require.NoError(t, err)
+
+			header, body, err := event.Encode(tc.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContentType, header.Get("Content-Type"))
+			if tc.mode == cloudevents.ModeBinary {
+				assert.Equal(t, "1.0", header.Get("ce-specversion"))
+				assert.Equal(t, "payments/Deployment/api", header.Get("ce-subject"))
+			}
+
+			decoded, err := cloudevents.Decode(header, body)
+			require.NoError(t, err)
+			assert.Equal(t, event.ID, decoded.ID)
+			assert.Equal(t, event.Source, decoded.Source)
+			assert.Equal(t, event.Type, decoded.Type)
+			assert.Equal(t, event.Subject, decoded.Subject)
+			assert.True(t, event.Time.Equal(decoded.Time))
+			assert.Equal(t, event.Extensions, decoded.Extensions)
+
+			decodedIncident, err := decoded.Incident()
+			require.NoError(t, err)
+			assert.True(t, gproto.Equal(incident, decodedIncident))
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("ce-specversion", "0.3")
+
+	_, err := cloudevents.Decode(header, []byte(`{}`))
+
+	assert.ErrorIs(t, err, cloudevents.ErrInvalidEvent)
+}
@@ -0,0 +1,197 @@
+package comms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const defaultCertReloadInterval = 10 * time.Second
+
+// CertReloader serves the Brain client certificate and CA bundle from disk and reloads both when
+// the files change, so cert-manager rotations take effect on the next handshake without a restart.
+// It is a manager.Runnable and must be added to the manager to start watching.
+type CertReloader struct {
+	caPath      string
+	certWatcher *certwatcher.CertWatcher
+	interval    time.Duration
+	now         func() time.Time
+
+	mu             sync.RWMutex
+	caPEM          []byte
+	caPool         *x509.CertPool
+	caNotAfter     time.Time
+	clientNotAfter time.Time
+}
+
+// NewCertReloader loads the CA bundle and client key pair, failing if either cannot be read.
+func NewCertReloader(caPath, certPath, keyPath string) (*CertReloader, error) {
+	certWatcher, err := certwatcher.New(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client key pair: %w", err)
+	}
+
+	r := &CertReloader{
+		caPath:      caPath,
+		certWatcher: certWatcher,
+		interval:    defaultCertReloadInterval,
+		now:         time.Now,
+	}
+	if err := r.ReadCA(); err != nil {
+		return nil, err
+	}
+	certWatcher.RegisterCallback(r.onClientCertificate)
+	return r, nil
+}
+
+// TLSConfig returns a client TLS configuration that always presents the current client
+// certificate and verifies the Brain against the current CA bundle.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.certWatcher.GetCertificate(nil)
+		},
+		// The static RootCAs check is replaced by VerifyConnection so a rotated CA is honoured.
+		InsecureSkipVerify: true, //nolint:gosec // verification happens in VerifyConnection.
+		VerifyConnection:   r.verifyConnection,
+	}
+}
+
+func (r *CertReloader) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("brain presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	r.mu.RLock()
+	roots := r.caPool
+	r.mu.RUnlock()
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       state.ServerName,
+		CurrentTime:   r.now(),
+	})
+	return err
+}
+
+// ReadCA reloads the CA bundle if its contents changed.
+func (r *CertReloader) ReadCA() error {
+	caPEM, err := os.ReadFile(r.caPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	r.mu.RLock()
+	unchanged := string(caPEM) == string(r.caPEM)
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("failed to add CA certificate to pool")
+	}
+	caNotAfter, err := earliestExpiry(caPEM)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.caPEM, r.caPool, r.caNotAfter = caPEM, caPool, caNotAfter
+	r.mu.Unlock()
+	brainCertificateExpiry.WithLabelValues(certificateCA).Set(float64(caNotAfter.Unix()))
+	return nil
+}
+
+func (r *CertReloader) onClientCertificate(cert tls.Certificate) {
+	notAfter := time.Time{}
+	if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil {
+		notAfter = leaf.NotAfter
+	}
+
+	r.mu.Lock()
+	r.clientNotAfter = notAfter
+	r.mu.Unlock()
+	brainCertificateExpiry.WithLabelValues(certificateClient).Set(float64(notAfter.Unix()))
+}
+
+// Start watches the client key pair and polls the CA bundle until ctx is cancelled.
+func (r *CertReloader) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithValues("caPath", r.caPath)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.certWatcher.Start(ctx)
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return <-errCh
+		case err := <-errCh:
+			return fmt.Errorf("client certificate watcher stopped: %w", err)
+		case <-ticker.C:
+			if err := r.ReadCA(); err != nil {
+				log.Error(err, "failed to reload Brain CA certificate")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection reports that every replica must reload its own credentials.
+func (r *CertReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// ReadyCheck is a healthz.Checker that fails once the client certificate or CA has expired.
+func (r *CertReloader) ReadyCheck(_ *http.Request) error {
+	r.mu.RLock()
+	clientNotAfter, caNotAfter := r.clientNotAfter, r.caNotAfter
+	r.mu.RUnlock()
+
+	now := r.now()
+	if !clientNotAfter.IsZero() && now.After(clientNotAfter) {
+		return fmt.Errorf("brain client certificate expired at %s", clientNotAfter.Format(time.RFC3339))
+	}
+	if now.After(caNotAfter) {
+		return fmt.Errorf("brain CA certificate expired at %s", caNotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// earliestExpiry returns the soonest NotAfter among the certificates in a PEM bundle.
+func earliestExpiry(pemData []byte) (time.Time, error) {
+	var earliest time.Time
+	for block, rest := pem.Decode(pemData); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse CA certificate: %w", err)
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+	return earliest, nil
+}
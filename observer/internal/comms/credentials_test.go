@@ -0,0 +1,156 @@
+package comms_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kube-mind/observer/internal/comms"
+)
+
+// testCert is a generated certificate together with its PEM encodings.
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert creates a certificate signed by parent, or a self-signed CA when parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert, notAfter time.Time) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-2 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeCerts writes the CA bundle and client key pair and returns their paths.
+func writeCerts(t *testing.T, dir string, ca, client *testCert) (string, string, string) {
+	t.Helper()
+
+	caPath := filepath.Join(dir, "ca.crt")
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(caPath, ca.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(certPath, client.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyPath, client.keyPEM, 0o600))
+	return caPath, certPath, keyPath
+}
+
+func TestCertReloader_ReadCA(t *testing.T) {
+	t.Parallel()
+
+	validUntil := time.Now().Add(24 * time.Hour)
+	oldCA := newTestCert(t, "old-ca", nil, validUntil)
+	newCA := newTestCert(t, "new-ca", nil, validUntil)
+	client := newTestCert(t, "observer", oldCA, validUntil)
+	brain := newTestCert(t, "brain", newCA, validUntil)
+
+	caPath, certPath, keyPath := writeCerts(t, t.TempDir(), oldCA, client)
+	reloader, err := comms.NewCertReloader(caPath, certPath, keyPath)
+	require.NoError(t, err)
+
+	state := tls.ConnectionState{ServerName: "brain", PeerCertificates: []*x509.Certificate{brain.cert}}
+	tlsConfig := reloader.TLSConfig()
+	require.Error(t, tlsConfig.VerifyConnection(state), "a Brain signed by an unknown CA must be rejected")
+
+	require.NoError(t, os.WriteFile(caPath, newCA.certPEM, 0o600))
+	require.NoError(t, reloader.ReadCA())
+	assert.NoError(t, tlsConfig.VerifyConnection(state), "the rotated CA must be used without rebuilding the config")
+
+	presented, err := tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, client.cert.Raw, presented.Certificate[0])
+}
+
+func TestCertReloader_ReadyCheck(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		caNotAfter     time.Duration
+		clientNotAfter time.Duration
+		expectErr      string
+	}{
+		{
+			name:           "Valid certificates",
+			caNotAfter:     24 * time.Hour,
+			clientNotAfter: time.Hour,
+		},
+		{
+			name:           "Expired client certificate",
+			caNotAfter:     24 * time.Hour,
+			clientNotAfter: -time.Hour,
+			expectErr:      "client certificate expired",
+		},
+		{
+			name:           "Expired CA",
+			caNotAfter:     -time.Hour,
+			clientNotAfter: time.Hour,
+			expectErr:      "CA certificate expired",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ca := newTestCert(t, "ca", nil, time.Now().Add(tc.caNotAfter))
+			client := newTestCert(t, "observer", ca, time.Now().Add(tc.clientNotAfter))
+			reloader, err := comms.NewCertReloader(writeCerts(t, t.TempDir(), ca, client))
+			require.NoError(t, err)
+
+			err = reloader.ReadyCheck(nil)
+
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,16 +2,29 @@ package comms
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
+	"errors"
 	"fmt"
-	"os"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	_ "google.golang.org/grpc/health" // enables client-side health checking
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+	gproto "google.golang.org/protobuf/proto"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	pb "kube-mind/observer/proto"
 )
@@ -22,67 +35,255 @@ type GrpcClient interface {
 	Close() error
 }
 
+const (
+	// healthCheckTimeout bounds the gRPC health check made for every readiness probe.
+	healthCheckTimeout = 2 * time.Second
+	// DefaultChunkSize is the amount of serialized incident data sent per chunk.
+	DefaultChunkSize = 1 << 20
+	// defaultMaxMessageBytes is gRPC's default receive limit, assumed when the Brain does not report one.
+	defaultMaxMessageBytes = 4 << 20
+)
+
+// brainServiceConfig balances over every address a Brain target resolves to and enables
+// client-side gRPC health checking; Brains without the health service count as healthy.
+const brainServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}],"healthCheckConfig":{"serviceName":""}}`
+
+// DefaultCompressors lists the compressors offered to the Brain, preferred first.
+var DefaultCompressors = []string{ZstdCompressorName, gzip.Name}
+
 // BrainGrpcClient implements GrpcClient to communicate with the .NET Brain service.
 type BrainGrpcClient struct {
-	Conn   *grpc.ClientConn
-	Client pb.IncidentServiceClient
+	Address string
+	Conn    *grpc.ClientConn
+	Client  pb.IncidentServiceClient
+	// Compressors are offered to the Brain during negotiation, preferred first.
+	// An empty list disables compression.
+	Compressors []string
+	// ChunkSize is the amount of data per chunk when an incident exceeds the Brain's message limit.
+	// Zero means DefaultChunkSize.
+	ChunkSize int
+	// Certs reloads the mTLS credentials when they rotate; nil for insecure transport.
+	Certs *CertReloader
+
+	negotiated atomic.Pointer[transportSettings]
+}
+
+// transportSettings is the outcome of schema negotiation with the Brain.
+type transportSettings struct {
+	schemaVersion   uint32
+	compressor      string
+	chunkedTransfer bool
+	maxMessageBytes int
+}
+
+// ClientOption configures optional behaviour of a BrainGrpcClient.
+type ClientOption func(*BrainGrpcClient)
+
+// WithCompressors sets the compressors offered to the Brain, preferred first.
+// Passing no names disables compression.
+func WithCompressors(names ...string) ClientOption {
+	return func(c *BrainGrpcClient) {
+		c.Compressors = names
+	}
+}
+
+// WithChunkSize sets the amount of data per chunk for oversized incidents.
+func WithChunkSize(bytes int) ClientOption {
+	return func(c *BrainGrpcClient) {
+		c.ChunkSize = bytes
+	}
 }
 
-// NewBrainGrpcClient creates and connects a new BrainGrpcClient.
+// NewBrainGrpcClient creates a BrainGrpcClient without waiting for the Brain to be reachable;
+// the connection is established in the background and the schema is negotiated on first use.
 // If `insecure` is true, it will connect without mTLS. Otherwise, mTLS is used.
-func NewBrainGrpcClient(ctx context.Context, addr string, useInsecureTransport bool, caCertPath, clientCertPath, clientKeyPath string) (*BrainGrpcClient, error) {
+func NewBrainGrpcClient(ctx context.Context, addr string, useInsecureTransport bool, caCertPath, clientCertPath, clientKeyPath string, clientOpts ...ClientOption) (*BrainGrpcClient, error) {
+	certs, err := loadCredentials(useInsecureTransport, caCertPath, clientCertPath, clientKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return dialBrain(addr, certs, clientOpts...)
+}
+
+// loadCredentials returns the mTLS credentials reloader, or nil for insecure transport.
+func loadCredentials(useInsecureTransport bool, caCertPath, clientCertPath, clientKeyPath string) (*CertReloader, error) {
+	if useInsecureTransport {
+		return nil, nil
+	}
+	if caCertPath == "" || clientCertPath == "" || clientKeyPath == "" {
+		return nil, fmt.Errorf("mTLS is enabled but one or more certificate paths are missing; please provide --grpc-ca-cert, --grpc-client-cert, and --grpc-client-key, or use --grpc-insecure for local development")
+	}
+	return NewCertReloader(caCertPath, clientCertPath, clientKeyPath)
+}
+
+// dialBrain creates the connection to one Brain endpoint and starts connecting in the
+// background; a nil certs means insecure transport. A dns:/// address spreads calls over every
+// resolved Brain, skipping those that fail the gRPC health check.
+func dialBrain(addr string, certs *CertReloader, clientOpts ...ClientOption) (*BrainGrpcClient, error) {
 	opts := []grpc.DialOption{
-		grpc.WithBlock(),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                10 * time.Second,
 			Timeout:             time.Second,
 			PermitWithoutStream: true,
 		}),
+		grpc.WithDefaultServiceConfig(brainServiceConfig),
 	}
-
-	if useInsecureTransport {
+	if certs == nil {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		if caCertPath == "" || clientCertPath == "" || clientKeyPath == "" {
-			return nil, fmt.Errorf("mTLS is enabled but one or more certificate paths are missing; please provide --grpc-ca-cert, --grpc-client-cert, and --grpc-client-key, or use --grpc-insecure for local development")
-		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(certs.TLSConfig())))
+	}
 
-		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load client key pair: %w", err)
-		}
+	conn, err := grpc.NewClient(brainTarget(addr), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", addr, err)
+	}
+	// NewClient stays idle until the first call; connecting now lets readiness reflect the Brain
+	// before any incident is sent.
+	conn.Connect()
 
-		caCert, err := os.ReadFile(caCertPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	client := &BrainGrpcClient{
+		Address:     addr,
+		Conn:        conn,
+		Client:      pb.NewIncidentServiceClient(conn),
+		Compressors: DefaultCompressors,
+		Certs:       certs,
+	}
+	for _, opt := range clientOpts {
+		opt(client)
+	}
+	return client, nil
+}
+
+// brainTarget passes a plain host:port address through to the dialer as grpc.DialContext did,
+// rather than resolving it with DNS, the default of grpc.NewClient. Addresses with a registered
+// scheme, such as dns:/// or unix:, are kept.
+func brainTarget(addr string) string {
+	if u, err := url.Parse(addr); err == nil && resolver.Get(u.Scheme) != nil {
+		return addr
+	}
+	return "passthrough:///" + addr
+}
+
+// Negotiate agrees on a schema version, a compressor and the transfer mode with the Brain
+// and uses them for every later call. A Brain that does not implement negotiation is treated
+// as speaking MinSchemaVersion without compression or chunking; a Brain that shares no
+// version with the Observer yields pb.ErrIncompatibleSchema.
+func (c *BrainGrpcClient) Negotiate(ctx context.Context) (uint32, error) {
+	log := logf.FromContext(ctx)
+
+	resp, err := c.Client.Negotiate(ctx, &pb.NegotiateRequest{
+		SchemaVersion:    pb.SchemaVersion,
+		MinSchemaVersion: pb.MinSchemaVersion,
+		ObserverVersion:  observerVersion(),
+		Compressions:     c.Compressors,
+		ChunkedTransfer:  true,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		log.Info("Brain does not support schema negotiation, downgrading", "schemaVersion", pb.MinSchemaVersion)
+		c.negotiated.Store(&transportSettings{schemaVersion: pb.MinSchemaVersion, maxMessageBytes: defaultMaxMessageBytes})
+		return pb.MinSchemaVersion, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to negotiate schema version: %w", err)
+	}
+
+	version, err := pb.NegotiateVersion(resp.GetMinSchemaVersion(), resp.GetSchemaVersion())
+	if err != nil {
+		if errors.Is(err, pb.ErrIncompatibleSchema) {
+			log.Error(err, "refusing to talk to Brain", "brainVersion", resp.GetBrainVersion())
 		}
-		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM(caCert) {
-			return nil, fmt.Errorf("failed to add CA certificate to pool")
+		return 0, err
+	}
+	settings := &transportSettings{
+		schemaVersion:   version,
+		maxMessageBytes: defaultMaxMessageBytes,
+	}
+	if version >= 4 {
+		settings.compressor = pickCompressor(c.Compressors, resp.GetCompressions())
+		settings.chunkedTransfer = resp.GetChunkedTransfer()
+		if resp.GetMaxMessageBytes() > 0 {
+			settings.maxMessageBytes = int(resp.GetMaxMessageBytes())
 		}
+	}
+	log.Info("Negotiated transport with Brain", "schemaVersion", version, "compressor", settings.compressor,
+		"chunkedTransfer", settings.chunkedTransfer, "brainVersion", resp.GetBrainVersion())
+	c.negotiated.Store(settings)
+	return version, nil
+}
 
-		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{clientCert},
-			RootCAs:      caCertPool,
+// SchemaVersion returns the negotiated schema version, or pb.SchemaVersion if
+// negotiation has not happened yet.
+func (c *BrainGrpcClient) SchemaVersion() uint32 {
+	return c.settings().schemaVersion
+}
+
+// Compressor returns the negotiated compressor, or "" when messages are sent uncompressed.
+func (c *BrainGrpcClient) Compressor() string {
+	return c.settings().compressor
+}
+
+func (c *BrainGrpcClient) settings() *transportSettings {
+	if settings := c.negotiated.Load(); settings != nil {
+		return settings
+	}
+	return &transportSettings{schemaVersion: pb.SchemaVersion, maxMessageBytes: defaultMaxMessageBytes}
+}
+
+// pickCompressor returns the first compressor the Observer prefers that the Brain can decode.
+func pickCompressor(preferred, supported []string) string {
+	for _, name := range preferred {
+		for _, candidate := range supported {
+			if name == candidate {
+				return name
+			}
 		}
-		creds := credentials.NewTLS(tlsConfig)
-		opts = append(opts, grpc.WithTransportCredentials(creds))
 	}
+	return ""
+}
 
-	conn, err := grpc.DialContext(ctx, addr, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial gRPC server: %w", err)
+// StreamIncident sends an incident to the Brain service. Incidents larger than the Brain's
+// message limit are split into chunks when the Brain supports chunked transfer.
+func (c *BrainGrpcClient) StreamIncident(ctx context.Context, incident *pb.IncidentContext) error {
+	settings := c.negotiated.Load()
+	if settings == nil {
+		if _, err := c.Negotiate(ctx); err != nil {
+			return err
+		}
+		settings = c.negotiated.Load()
 	}
 
-	return &BrainGrpcClient{
-		Conn:   conn,
-		Client: pb.NewIncidentServiceClient(conn),
-	}, nil
+	err := c.sendIncident(ctx, incident, settings)
+	if status.Code(err) == codes.Unavailable {
+		// The Brain may come back on another version, so negotiate again on the next send.
+		c.negotiated.CompareAndSwap(settings, nil)
+	}
+	return err
 }
 
-// StreamIncident sends an incident to the Brain service.
-func (c *BrainGrpcClient) StreamIncident(ctx context.Context, incident *pb.IncidentContext) error {
-	stream, err := c.Client.StreamIncident(ctx)
+// ErrIncidentTooLarge is returned for an incident larger than the Brain accepts when the Brain
+// cannot receive it in chunks. Retrying does not help; a smaller incident budget does.
+var ErrIncidentTooLarge = errors.New("incident exceeds the Brain's message size limit")
+
+func (c *BrainGrpcClient) sendIncident(ctx context.Context, incident *pb.IncidentContext, settings *transportSettings) error {
+	if settings.schemaVersion < pb.SchemaVersion {
+		incident = pb.Downgrade(incident, settings.schemaVersion)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, pb.SchemaVersionMetadataKey, strconv.FormatUint(uint64(settings.schemaVersion), 10))
+
+	var callOpts []grpc.CallOption
+	if settings.compressor != "" {
+		callOpts = append(callOpts, grpc.UseCompressor(settings.compressor))
+	}
+
+	if size := gproto.Size(incident); size > settings.maxMessageBytes {
+		if settings.chunkedTransfer {
+			return c.streamIncidentChunks(ctx, incident, settings, callOpts)
+		}
+		return fmt.Errorf("%w: incident %s is %d bytes, the Brain accepts %d", ErrIncidentTooLarge, incident.IncidentId, size, settings.maxMessageBytes)
+	}
+
+	stream, err := c.Client.StreamIncident(ctx, callOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create incident stream: %w", err)
 	}
@@ -95,6 +296,69 @@ func (c *BrainGrpcClient) StreamIncident(ctx context.Context, incident *pb.Incid
 	return nil
 }
 
+// streamIncidentChunks sends an oversized incident as ordered chunks that the Brain reassembles.
+func (c *BrainGrpcClient) streamIncidentChunks(ctx context.Context, incident *pb.IncidentContext, settings *transportSettings, callOpts []grpc.CallOption) error {
+	chunkSize := c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunks, err := pb.SplitIncident(incident, min(chunkSize, settings.maxMessageBytes/2))
+	if err != nil {
+		return fmt.Errorf("failed to split incident into chunks: %w", err)
+	}
+
+	stream, err := c.Client.StreamIncidentChunks(ctx, callOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create incident chunk stream: %w", err)
+	}
+	for _, chunk := range chunks {
+		if err := stream.Send(chunk); err != nil {
+			return fmt.Errorf("failed to send incident chunk %d/%d: %w", chunk.Index+1, chunk.Total, err)
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("failed to close and receive from chunk stream: %w", err)
+	}
+	return nil
+}
+
+// Healthy reports whether the connection is usable or may become so, without waiting on the
+// network; idle connections are asked to connect.
+func (c *BrainGrpcClient) Healthy() bool {
+	switch state := c.Conn.GetState(); state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	case connectivity.Idle:
+		c.Conn.Connect()
+	}
+	return true
+}
+
+// ReadyCheck is a healthz.Checker that reports whether the Brain can currently take incidents.
+// It uses the standard gRPC health protocol and falls back to the connection state for Brains
+// that do not serve it.
+func (c *BrainGrpcClient) ReadyCheck(req *http.Request) error {
+	ctx, cancel := context.WithTimeout(req.Context(), healthCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(c.Conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("brain reports %s", resp.GetStatus())
+		}
+		return nil
+	case codes.Unimplemented:
+		if state := c.Conn.GetState(); state != connectivity.Ready {
+			c.Conn.Connect()
+			return fmt.Errorf("brain connection is %s", state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("brain health check failed: %w", err)
+	}
+}
+
 // Close closes the gRPC connection.
 func (c *BrainGrpcClient) Close() error {
 	if c.Conn != nil {
@@ -102,3 +366,11 @@ func (c *BrainGrpcClient) Close() error {
 	}
 	return nil
 }
+
+// observerVersion reports the module version the Observer binary was built from.
+func observerVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return "unknown"
+}
@@ -2,13 +2,22 @@ package comms_test
 
 import (
 	"context"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -19,22 +28,88 @@ import (
 // mockIncidentService is a mock implementation of the pb.IncidentServiceServer.
 type mockIncidentService struct {
 	pb.UnimplementedIncidentServiceServer
-	lastIncident *pb.IncidentContext
-	streamErr    error // To simulate an error during streaming
+	lastIncident      *pb.IncidentContext
+	lastSchemaVersion []string
+	streamErr         error                 // To simulate an error during streaming
+	negotiateResp     *pb.NegotiateResponse // nil simulates a Brain without negotiation support
+	chunksReceived    int
+	received          int
 }
 
 func (s *mockIncidentService) StreamIncident(stream pb.IncidentService_StreamIncidentServer) error {
 	if s.streamErr != nil {
 		return s.streamErr
 	}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		s.lastSchemaVersion = md.Get(pb.SchemaVersionMetadataKey)
+	}
 	inc, err := stream.Recv()
 	if err != nil {
 		return err
 	}
 	s.lastIncident = inc
+	s.received++
 	return stream.SendAndClose(&pb.StreamIncidentResponse{Status: "Received"})
 }
 
+func (s *mockIncidentService) Negotiate(ctx context.Context, req *pb.NegotiateRequest) (*pb.NegotiateResponse, error) {
+	if s.negotiateResp == nil {
+		return s.UnimplementedIncidentServiceServer.Negotiate(ctx, req)
+	}
+	return s.negotiateResp, nil
+}
+
+func (s *mockIncidentService) StreamIncidentChunks(stream pb.IncidentService_StreamIncidentChunksServer) error {
+	var chunks []*pb.IncidentChunk
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+	}
+	inc, err := pb.AssembleIncident(chunks)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.chunksReceived = len(chunks)
+	s.lastIncident = inc
+	return stream.SendAndClose(&pb.StreamIncidentResponse{Status: "Received"})
+}
+
+// newBufconnClient serves the mock on an in-memory listener and returns a client connected to it.
+func newBufconnClient(t *testing.T, service *mockIncidentService, register ...func(*grpc.Server)) *comms.BrainGrpcClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pb.RegisterIncidentServiceServer(s, service)
+	for _, r := range register {
+		if r != nil {
+			r(s)
+		}
+	}
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &comms.BrainGrpcClient{
+		Conn:   conn,
+		Client: pb.NewIncidentServiceClient(conn),
+	}
+}
+
 func TestBrainGrpcClient_StreamIncident(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -109,3 +184,214 @@ func TestBrainGrpcClient_StreamIncident(t *testing.T) {
 		})
 	}
 }
+
+func TestBrainGrpcClient_Negotiate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	incident := &pb.IncidentContext{
+		IncidentId: "test-incident",
+		ClusterId:  "prod-eu",
+	}
+
+	testCases := []struct {
+		name              string
+		negotiateResp     *pb.NegotiateResponse
+		expectedVersion   uint32
+		expectedClusterID string
+		expectErr         bool
+	}{
+		{
+			name:              "Brain on the current schema",
+			negotiateResp:     &pb.NegotiateResponse{SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion},
+			expectedVersion:   pb.SchemaVersion,
+			expectedClusterID: "prod-eu",
+		},
+		{
+			name:              "Brain on an older schema",
+			negotiateResp:     &pb.NegotiateResponse{SchemaVersion: 1, MinSchemaVersion: 1},
+			expectedVersion:   1,
+			expectedClusterID: "",
+		},
+		{
+			name:              "Brain without negotiation support",
+			negotiateResp:     nil,
+			expectedVersion:   pb.MinSchemaVersion,
+			expectedClusterID: "",
+		},
+		{
+			name:          "Brain with no common schema",
+			negotiateResp: &pb.NegotiateResponse{SchemaVersion: pb.SchemaVersion + 2, MinSchemaVersion: pb.SchemaVersion + 1},
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &mockIncidentService{negotiateResp: tc.negotiateResp}
+			client := newBufconnClient(t, service)
+
+			version, err := client.Negotiate(ctx)
+
+			if tc.expectErr {
+				require.ErrorIs(t, err, pb.ErrIncompatibleSchema)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+
+			require.NoError(t, client.StreamIncident(ctx, incident))
+			assert.Equal(t, tc.expectedClusterID, service.lastIncident.ClusterId)
+			assert.Equal(t, []string{strconv.FormatUint(uint64(tc.expectedVersion), 10)}, service.lastSchemaVersion)
+			assert.Equal(t, "prod-eu", incident.ClusterId)
+		})
+	}
+}
+
+func TestBrainGrpcClient_Transport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	largeIncident := &pb.IncidentContext{
+		IncidentId: "large-incident",
+		Logs:       strings.Repeat("log line\n", 20000),
+	}
+
+	testCases := []struct {
+		name               string
+		negotiateResp      *pb.NegotiateResponse
+		compressors        []string
+		expectedCompressor string
+		expectedChunks     int
+	}{
+		{
+			name: "Observer preference wins",
+			negotiateResp: &pb.NegotiateResponse{
+				SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion,
+				Compressions: []string{"gzip", comms.ZstdCompressorName},
+			},
+			compressors:        comms.DefaultCompressors,
+			expectedCompressor: comms.ZstdCompressorName,
+		},
+		{
+			name: "Brain supports gzip only",
+			negotiateResp: &pb.NegotiateResponse{
+				SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion,
+				Compressions: []string{"gzip"},
+			},
+			compressors:        comms.DefaultCompressors,
+			expectedCompressor: "gzip",
+		},
+		{
+			name: "Compression disabled by the Observer",
+			negotiateResp: &pb.NegotiateResponse{
+				SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion,
+				Compressions: []string{"gzip", comms.ZstdCompressorName},
+			},
+		},
+		{
+			name: "Oversized incident is chunked",
+			negotiateResp: &pb.NegotiateResponse{
+				SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion,
+				Compressions: []string{comms.ZstdCompressorName}, ChunkedTransfer: true, MaxMessageBytes: 64 * 1024,
+			},
+			compressors:        comms.DefaultCompressors,
+			expectedCompressor: comms.ZstdCompressorName,
+			expectedChunks:     6,
+		},
+		{
+			name: "Older Brain gets neither compression nor chunks",
+			negotiateResp: &pb.NegotiateResponse{
+				SchemaVersion: 3, MinSchemaVersion: pb.MinSchemaVersion,
+				Compressions: []string{comms.ZstdCompressorName}, ChunkedTransfer: true, MaxMessageBytes: 64 * 1024,
+			},
+			compressors: comms.DefaultCompressors,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &mockIncidentService{negotiateResp: tc.negotiateResp}
+			client := newBufconnClient(t, service)
+			comms.WithCompressors(tc.compressors...)(client)
+			comms.WithChunkSize(32 * 1024)(client)
+
+			_, err := client.Negotiate(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCompressor, client.Compressor())
+
+			require.NoError(t, client.StreamIncident(ctx, largeIncident))
+			require.NotNil(t, service.lastIncident)
+			assert.Equal(t, largeIncident.Logs, service.lastIncident.Logs)
+			assert.Equal(t, tc.expectedChunks, service.chunksReceived)
+		})
+	}
+
+	t.Run("Oversized incident without chunking", func(t *testing.T) {
+		t.Parallel()
+
+		service := &mockIncidentService{negotiateResp: &pb.NegotiateResponse{
+			SchemaVersion: pb.SchemaVersion, MinSchemaVersion: pb.MinSchemaVersion, MaxMessageBytes: 64 * 1024,
+		}}
+		client := newBufconnClient(t, service)
+		_, err := client.Negotiate(ctx)
+		require.NoError(t, err)
+
+		err = client.StreamIncident(ctx, largeIncident)
+		require.ErrorIs(t, err, comms.ErrIncidentTooLarge)
+		assert.Nil(t, service.lastIncident, "the incident is not sent")
+	})
+}
+
+func TestBrainGrpcClient_ReadyCheck(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		healthStatus *healthpb.HealthCheckResponse_ServingStatus // nil means the Brain does not serve gRPC health
+		expectErr    bool
+	}{
+		{
+			name:         "Brain serving",
+			healthStatus: healthpb.HealthCheckResponse_SERVING.Enum(),
+		},
+		{
+			name:         "Brain not serving",
+			healthStatus: healthpb.HealthCheckResponse_NOT_SERVING.Enum(),
+			expectErr:    true,
+		},
+		{
+			name: "Brain without gRPC health falls back to the connection state",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var register func(*grpc.Server)
+			if tc.healthStatus != nil {
+				register = func(s *grpc.Server) {
+					healthServer := health.NewServer()
+					healthServer.SetServingStatus("", *tc.healthStatus)
+					healthpb.RegisterHealthServer(s, healthServer)
+				}
+			}
+			client := newBufconnClient(t, &mockIncidentService{}, register)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			if tc.expectErr {
+				assert.Error(t, client.ReadyCheck(req))
+				return
+			}
+			require.Eventually(t, func() bool { return client.ReadyCheck(req) == nil }, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
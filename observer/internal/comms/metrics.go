@@ -0,0 +1,56 @@
+package comms
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	certificateClient = "client"
+	certificateCA     = "ca"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// brainCertificateExpiry exposes when the certificates used for the Brain connection expire.
+var brainCertificateExpiry = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "kubemind_observer_brain_certificate_expiry_timestamp_seconds",
+		Help: "Unix time at which the certificate used for the Brain mTLS connection expires.",
+	},
+	[]string{"certificate"},
+)
+
+var (
+	incidentQueueLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kubemind_observer_incident_queue_length",
+		Help: "Number of incidents queued while the Brain is unavailable.",
+	})
+	incidentsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kubemind_observer_incidents_dropped_total",
+		Help: "Number of queued incidents dropped because the queue was full or the Brain rejected them.",
+	})
+	brainFailovers = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kubemind_observer_brain_failovers_total",
+		Help: "Number of times an incident was retried on another Brain endpoint.",
+	})
+	mirroredIncidents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubemind_observer_mirrored_incidents_total",
+		Help: "Number of incidents mirrored to the secondary Brain, by result.",
+	}, []string{"result"})
+	dryRunIncidents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubemind_observer_dry_run_incidents_total",
+		Help: "Number of incidents recorded instead of sent to the Brain in dry-run mode.",
+	}, []string{"namespace", "reason"})
+	dryRunPayloadBytes = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kubemind_observer_dry_run_incident_bytes",
+		Help:    "Serialized size of the incidents recorded in dry-run mode.",
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 8),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(brainCertificateExpiry, incidentQueueLength, incidentsDropped, brainFailovers, mirroredIncidents,
+		dryRunIncidents, dryRunPayloadBytes)
+}
@@ -0,0 +1,183 @@
+package comms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gproto "google.golang.org/protobuf/proto"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "kube-mind/observer/proto"
+)
+
+// BalancingPolicy selects how a BrainPool spreads incidents over its endpoints.
+type BalancingPolicy string
+
+const (
+	// PolicyRoundRobin rotates incidents over every healthy endpoint.
+	PolicyRoundRobin BalancingPolicy = "round-robin"
+	// PolicyPriority sends every incident to the first healthy endpoint in the configured order.
+	PolicyPriority BalancingPolicy = "priority"
+
+	// mirrorTimeout bounds a mirrored send, which outlives the reconcile that triggered it.
+	mirrorTimeout = 30 * time.Second
+)
+
+// ParseBalancingPolicy validates a --grpc-balancing value.
+func ParseBalancingPolicy(value string) (BalancingPolicy, error) {
+	switch policy := BalancingPolicy(value); policy {
+	case PolicyRoundRobin, PolicyPriority:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown balancing policy %q, expected %q or %q", value, PolicyRoundRobin, PolicyPriority)
+	}
+}
+
+// BrainPoolOptions configures NewBrainPool.
+type BrainPoolOptions struct {
+	// Addresses are the Brain endpoints, in priority order. A dns:/// address discovers every
+	// Brain behind a headless Service and balances over them.
+	Addresses []string
+	// MirrorAddress, if set, receives a best-effort copy of every incident, e.g. to canary a new Brain.
+	MirrorAddress  string
+	Policy         BalancingPolicy
+	Insecure       bool
+	CACertPath     string
+	ClientCertPath string
+	ClientKeyPath  string
+	ClientOptions  []ClientOption
+}
+
+// BrainPool is a GrpcClient that fails over between several Brain endpoints, skipping
+// unhealthy ones, and optionally mirrors every incident to a secondary Brain.
+type BrainPool struct {
+	Endpoints []*BrainGrpcClient
+	Mirror    *BrainGrpcClient
+	Policy    BalancingPolicy
+	// Certs reloads the mTLS credentials shared by every endpoint; nil for insecure transport.
+	Certs *CertReloader
+
+	next    atomic.Uint32
+	mirrors sync.WaitGroup
+}
+
+// NewBrainPool dials every endpoint and the optional mirror without waiting for them to be reachable.
+func NewBrainPool(ctx context.Context, opts BrainPoolOptions) (*BrainPool, error) {
+	if len(opts.Addresses) == 0 {
+		return nil, errors.New("at least one Brain address is required")
+	}
+	certs, err := loadCredentials(opts.Insecure, opts.CACertPath, opts.ClientCertPath, opts.ClientKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &BrainPool{Policy: opts.Policy, Certs: certs}
+	for _, addr := range opts.Addresses {
+		client, err := dialBrain(addr, certs, opts.ClientOptions...)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+		pool.Endpoints = append(pool.Endpoints, client)
+	}
+	if opts.MirrorAddress != "" {
+		if pool.Mirror, err = dialBrain(opts.MirrorAddress, certs, opts.ClientOptions...); err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// StreamIncident sends the incident to one endpoint chosen by the balancing policy, failing
+// over to the next one while the Brain is unreachable. Once an endpoint accepts the incident, it
+// is mirrored if a mirror is configured, so that incidents retried by an IncidentQueue are
+// mirrored once.
+func (p *BrainPool) StreamIncident(ctx context.Context, incident *pb.IncidentContext) error {
+	var err error
+	for i, endpoint := range p.candidates() {
+		if i > 0 {
+			brainFailovers.Inc()
+			logf.FromContext(ctx).Info("Failing over to next Brain", "address", endpoint.Address, "reason", err.Error())
+		}
+		err = endpoint.StreamIncident(ctx, incident)
+		if !isRetryable(err) {
+			break
+		}
+	}
+	if err == nil && p.Mirror != nil {
+		p.mirror(ctx, incident)
+	}
+	return err
+}
+
+// candidates orders the endpoints by policy, healthy ones first.
+func (p *BrainPool) candidates() []*BrainGrpcClient {
+	ordered := p.Endpoints
+	if p.Policy == PolicyRoundRobin && len(ordered) > 1 {
+		start := int(p.next.Add(1)-1) % len(ordered)
+		ordered = append(append([]*BrainGrpcClient(nil), ordered[start:]...), ordered[:start]...)
+	}
+
+	healthy := make([]*BrainGrpcClient, 0, len(ordered))
+	var unhealthy []*BrainGrpcClient
+	for _, endpoint := range ordered {
+		if endpoint.Healthy() {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// mirror sends a copy of the incident to the mirror Brain without blocking the caller.
+func (p *BrainPool) mirror(ctx context.Context, incident *pb.IncidentContext) {
+	log := logf.FromContext(ctx).WithValues("address", p.Mirror.Address, "incidentID", incident.IncidentId)
+	incident = gproto.Clone(incident).(*pb.IncidentContext)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), mirrorTimeout)
+
+	p.mirrors.Add(1)
+	go func() {
+		defer p.mirrors.Done()
+		defer cancel()
+		if err := p.Mirror.StreamIncident(ctx, incident); err != nil {
+			mirroredIncidents.WithLabelValues(resultError).Inc()
+			log.Error(err, "failed to mirror incident")
+			return
+		}
+		mirroredIncidents.WithLabelValues(resultSuccess).Inc()
+	}()
+}
+
+// ReadyCheck is a healthz.Checker that passes while at least one endpoint is ready.
+// The mirror does not affect readiness.
+func (p *BrainPool) ReadyCheck(req *http.Request) error {
+	var errs []error
+	for _, endpoint := range p.Endpoints {
+		err := endpoint.ReadyCheck(req)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint.Address, err))
+	}
+	return errors.Join(errs...)
+}
+
+// Close waits for in-flight mirrored sends and closes every connection.
+func (p *BrainPool) Close() error {
+	p.mirrors.Wait()
+	var errs []error
+	for _, endpoint := range p.Endpoints {
+		errs = append(errs, endpoint.Close())
+	}
+	if p.Mirror != nil {
+		errs = append(errs, p.Mirror.Close())
+	}
+	return errors.Join(errs...)
+}
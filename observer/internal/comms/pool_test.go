@@ -0,0 +1,132 @@
+package comms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kube-mind/observer/internal/comms"
+	pb "kube-mind/observer/proto"
+)
+
+func TestBrainPool_StreamIncident(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testCases := []struct {
+		name              string
+		policy            comms.BalancingPolicy
+		primaryErr        error
+		incidents         int
+		expectErr         bool
+		expectedPrimary   int
+		expectedSecondary int
+	}{
+		{
+			name:            "Priority sends everything to the primary",
+			policy:          comms.PolicyPriority,
+			incidents:       3,
+			expectedPrimary: 3,
+		},
+		{
+			name:              "Priority fails over while the primary is unavailable",
+			policy:            comms.PolicyPriority,
+			primaryErr:        status.Error(codes.Unavailable, "maintenance"),
+			incidents:         3,
+			expectedSecondary: 3,
+		},
+		{
+			name:              "Round-robin spreads incidents",
+			policy:            comms.PolicyRoundRobin,
+			incidents:         4,
+			expectedPrimary:   2,
+			expectedSecondary: 2,
+		},
+		{
+			name:       "Rejected incidents are not retried elsewhere",
+			policy:     comms.PolicyPriority,
+			primaryErr: status.Error(codes.InvalidArgument, "bad incident"),
+			incidents:  1,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			primary := &mockIncidentService{streamErr: tc.primaryErr}
+			secondary := &mockIncidentService{}
+			pool := &comms.BrainPool{
+				Endpoints: []*comms.BrainGrpcClient{newBufconnClient(t, primary), newBufconnClient(t, secondary)},
+				Policy:    tc.policy,
+			}
+
+			for i := 0; i < tc.incidents; i++ {
+				err := pool.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "test-incident"})
+				if tc.expectErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+
+			assert.Equal(t, tc.expectedPrimary, primary.received)
+			assert.Equal(t, tc.expectedSecondary, secondary.received)
+		})
+	}
+}
+
+func TestBrainPool_Mirror(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	primary := &mockIncidentService{}
+	mirror := &mockIncidentService{streamErr: status.Error(codes.Internal, "canary crashed")}
+	canary := &mockIncidentService{}
+	pool := &comms.BrainPool{
+		Endpoints: []*comms.BrainGrpcClient{newBufconnClient(t, primary)},
+		Mirror:    newBufconnClient(t, canary),
+		Policy:    comms.PolicyPriority,
+	}
+	failingPool := &comms.BrainPool{
+		Endpoints: []*comms.BrainGrpcClient{newBufconnClient(t, primary)},
+		Mirror:    newBufconnClient(t, mirror),
+		Policy:    comms.PolicyPriority,
+	}
+
+	unavailable := &mockIncidentService{streamErr: status.Error(codes.Unavailable, "maintenance")}
+	downPool := &comms.BrainPool{
+		Endpoints: []*comms.BrainGrpcClient{newBufconnClient(t, unavailable)},
+		Mirror:    newBufconnClient(t, canary),
+		Policy:    comms.PolicyPriority,
+	}
+
+	require.NoError(t, pool.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "mirrored"}))
+	require.NoError(t, failingPool.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "mirror-fails"}), "mirror failures must not fail delivery")
+	require.Error(t, downPool.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "undelivered"}))
+	require.NoError(t, pool.Close())
+	require.NoError(t, failingPool.Close())
+	require.NoError(t, downPool.Close())
+
+	assert.Equal(t, 2, primary.received)
+	assert.Equal(t, 1, canary.received, "incidents no endpoint accepted are not mirrored")
+	require.NotNil(t, canary.lastIncident)
+	assert.Equal(t, "mirrored", canary.lastIncident.IncidentId)
+}
+
+func TestParseBalancingPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := comms.ParseBalancingPolicy("round-robin")
+	require.NoError(t, err)
+	assert.Equal(t, comms.PolicyRoundRobin, policy)
+
+	_, err = comms.ParseBalancingPolicy("random")
+	assert.Error(t, err)
+}
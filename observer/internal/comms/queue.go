@@ -0,0 +1,186 @@
+package comms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// DefaultQueueCapacity is the number of incidents held while the Brain is unavailable.
+	DefaultQueueCapacity = 1000
+	defaultRetryInterval = 5 * time.Second
+)
+
+// ErrQueued is returned for incidents queued until the Brain is available again.
+var ErrQueued = errors.New("incident queued until the Brain is available")
+
+// IncidentQueue is a GrpcClient that keeps the Observer working while the Brain is down.
+// Incidents that cannot be delivered because the Brain is unreachable are queued in memory
+// and redelivered in order once it is back; when the queue is full the oldest incident is
+// dropped. It is a manager.Runnable and must be added to the manager to drain the queue.
+type IncidentQueue struct {
+	Client        GrpcClient
+	Capacity      int
+	RetryInterval time.Duration
+
+	mu      sync.Mutex
+	pending []queuedIncident
+	wake    chan struct{}
+}
+
+// queuedIncident is an incident waiting for the Brain, with the function told its outcome.
+type queuedIncident struct {
+	incident *pb.IncidentContext
+	settled  func(ctx context.Context, err error)
+}
+
+// NewIncidentQueue wraps client with a queue holding up to capacity incidents.
+func NewIncidentQueue(client GrpcClient, capacity int) *IncidentQueue {
+	return &IncidentQueue{
+		Client:        client,
+		Capacity:      capacity,
+		RetryInterval: defaultRetryInterval,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// StreamIncident delivers the incident, or queues it and returns ErrQueued if the Brain is
+// unavailable or earlier incidents are still waiting. Only errors the Brain would return again on
+// retry are reported.
+func (q *IncidentQueue) StreamIncident(ctx context.Context, incident *pb.IncidentContext) error {
+	return q.StreamIncidentSettled(ctx, incident, nil)
+}
+
+// StreamIncidentSettled is StreamIncident for callers following queued incidents: settled, if
+// set, is called once a queued incident is delivered, with a nil error, or dropped.
+func (q *IncidentQueue) StreamIncidentSettled(ctx context.Context, incident *pb.IncidentContext, settled func(ctx context.Context, err error)) error {
+	if q.Len() == 0 {
+		err := q.Client.StreamIncident(ctx, incident)
+		if !isRetryable(err) {
+			return err
+		}
+		logf.FromContext(ctx).Info("Brain unavailable, queuing incident", "incidentID", incident.IncidentId, "reason", err.Error())
+	}
+	q.enqueue(ctx, queuedIncident{incident: incident, settled: settled})
+	return ErrQueued
+}
+
+// Len returns the number of incidents waiting for the Brain.
+func (q *IncidentQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+func (q *IncidentQueue) enqueue(ctx context.Context, queued queuedIncident) {
+	var dropped *queuedIncident
+	q.mu.Lock()
+	if q.Capacity > 0 && len(q.pending) >= q.Capacity {
+		oldest := q.pending[0]
+		dropped = &oldest
+		q.pending = q.pending[1:]
+		incidentsDropped.Inc()
+	}
+	q.pending = append(q.pending, queued)
+	incidentQueueLength.Set(float64(len(q.pending)))
+	q.mu.Unlock()
+
+	if dropped != nil {
+		logf.FromContext(ctx).Info("Incident queue full, dropping oldest incident", "incidentID", dropped.incident.IncidentId)
+		dropped.settle(ctx, fmt.Errorf("incident queue full, incident %s dropped", dropped.incident.IncidentId))
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start redelivers queued incidents until ctx is cancelled.
+func (q *IncidentQueue) Start(ctx context.Context) error {
+	ticker := time.NewTicker(q.RetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-q.wake:
+		case <-ticker.C:
+		}
+		q.drain(ctx)
+	}
+}
+
+// drain sends queued incidents oldest first and stops at the first retryable failure.
+func (q *IncidentQueue) drain(ctx context.Context) {
+	log := logf.FromContext(ctx)
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		queued := q.pending[0]
+		q.mu.Unlock()
+		incident := queued.incident
+
+		err := q.Client.StreamIncident(ctx, incident)
+		if isRetryable(err) {
+			return
+		}
+		if err != nil {
+			log.Error(err, "dropping queued incident rejected by Brain", "incidentID", incident.IncidentId)
+			incidentsDropped.Inc()
+		} else {
+			log.Info("Queued incident streamed to Brain", "incidentID", incident.IncidentId)
+		}
+
+		q.mu.Lock()
+		// The incident is no longer first if it was dropped for a full queue meanwhile.
+		removed := len(q.pending) > 0 && q.pending[0].incident == incident
+		if removed {
+			q.pending = q.pending[1:]
+		}
+		incidentQueueLength.Set(float64(len(q.pending)))
+		q.mu.Unlock()
+		if removed {
+			queued.settle(ctx, err)
+		}
+	}
+}
+
+func (q queuedIncident) settle(ctx context.Context, err error) {
+	if q.settled != nil {
+		q.settled(ctx, err)
+	}
+}
+
+// NeedLeaderElection reports that a replica drains its own queue even after losing leadership.
+func (q *IncidentQueue) NeedLeaderElection() bool {
+	return false
+}
+
+// Close closes the underlying client.
+func (q *IncidentQueue) Close() error {
+	return q.Client.Close()
+}
+
+// isRetryable reports whether err means the Brain could not be reached, as opposed to
+// the Brain rejecting the incident.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
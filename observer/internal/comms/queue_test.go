@@ -0,0 +1,154 @@
+package comms_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kube-mind/observer/internal/comms"
+	pb "kube-mind/observer/proto"
+)
+
+// mockGrpcClient is a mock implementation of comms.GrpcClient for testing.
+type mockGrpcClient struct {
+	mu         sync.Mutex
+	streamFunc func(ctx context.Context, incident *pb.IncidentContext) error
+	delivered  []string
+}
+
+func (m *mockGrpcClient) StreamIncident(ctx context.Context, incident *pb.IncidentContext) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.streamFunc != nil {
+		if err := m.streamFunc(ctx, incident); err != nil {
+			return err
+		}
+	}
+	m.delivered = append(m.delivered, incident.IncidentId)
+	return nil
+}
+
+func (m *mockGrpcClient) Close() error {
+	return nil
+}
+
+func (m *mockGrpcClient) setStreamFunc(streamFunc func(ctx context.Context, incident *pb.IncidentContext) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streamFunc = streamFunc
+}
+
+func (m *mockGrpcClient) deliveredIDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.delivered...)
+}
+
+func brainDown(context.Context, *pb.IncidentContext) error {
+	return status.Error(codes.Unavailable, "connection refused")
+}
+
+func TestIncidentQueue_StreamIncident(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testCases := []struct {
+		name              string
+		streamErr         error
+		expectErr         bool
+		expectedQueued    int
+		expectedDelivered []string
+	}{
+		{
+			name:              "Brain available",
+			expectedDelivered: []string{"incident-1"},
+		},
+		{
+			name:           "Brain unavailable",
+			streamErr:      status.Error(codes.Unavailable, "connection refused"),
+			expectErr:      true,
+			expectedQueued: 1,
+		},
+		{
+			name:      "Brain rejects the incident",
+			streamErr: status.Error(codes.InvalidArgument, "bad incident"),
+			expectErr: true,
+		},
+		{
+			name:      "Non-gRPC error",
+			streamErr: errors.New("failed to split incident"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &mockGrpcClient{streamFunc: func(context.Context, *pb.IncidentContext) error { return tc.streamErr }}
+			queue := comms.NewIncidentQueue(client, 10)
+
+			err := queue.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "incident-1"})
+
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedQueued > 0, errors.Is(err, comms.ErrQueued))
+			assert.Equal(t, tc.expectedQueued, queue.Len())
+			assert.Equal(t, tc.expectedDelivered, client.deliveredIDs())
+		})
+	}
+}
+
+func TestIncidentQueue_DrainsInOrderOnceBrainIsBack(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &mockGrpcClient{streamFunc: brainDown}
+	queue := comms.NewIncidentQueue(client, 2)
+	queue.RetryInterval = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	settled := map[string]error{}
+	for _, id := range []string{"incident-1", "incident-2", "incident-3"} {
+		err := queue.StreamIncidentSettled(ctx, &pb.IncidentContext{IncidentId: id}, func(_ context.Context, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			settled[id] = err
+		})
+		require.ErrorIs(t, err, comms.ErrQueued)
+	}
+	assert.Equal(t, 2, queue.Len(), "the oldest incident is dropped once the queue is full")
+
+	go func() {
+		_ = queue.Start(ctx)
+	}()
+	client.setStreamFunc(nil)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(settled) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, queue.Len())
+	assert.Equal(t, []string{"incident-2", "incident-3"}, client.deliveredIDs())
+	mu.Lock()
+	assert.Error(t, settled["incident-1"], "a dropped incident settles with an error")
+	assert.NoError(t, settled["incident-2"])
+	assert.NoError(t, settled["incident-3"])
+	mu.Unlock()
+
+	require.NoError(t, queue.StreamIncident(ctx, &pb.IncidentContext{IncidentId: "incident-4"}))
+	assert.Equal(t, []string{"incident-2", "incident-3", "incident-4"}, client.deliveredIDs())
+}
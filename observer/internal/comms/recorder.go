@@ -0,0 +1,121 @@
+package comms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// DefaultRecorderCapacity is the number of recent incidents served by the dry-run endpoint.
+	DefaultRecorderCapacity = 100
+	// RecorderPath is where the dry-run endpoint is served on the metrics server.
+	RecorderPath = "/debug/incidents"
+)
+
+// Recorder is a GrpcClient for dry runs. Detection, harvesting and redaction run as usual,
+// but instead of reaching the Brain every incident is counted, optionally appended to a
+// JSON-lines file and kept in memory for the debug endpoint. The dry-run metrics show the
+// incident rate and payload sizes the Brain would have to handle.
+type Recorder struct {
+	capacity int
+
+	mu     sync.Mutex
+	out    io.WriteCloser
+	recent []*pb.IncidentContext
+}
+
+// NewRecorder creates a Recorder keeping the last capacity incidents. If path is not empty,
+// every incident is also appended to that file.
+func NewRecorder(path string, capacity int) (*Recorder, error) {
+	if capacity <= 0 {
+		capacity = DefaultRecorderCapacity
+	}
+	r := &Recorder{capacity: capacity}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dry-run output %s: %w", path, err)
+		}
+		r.out = f
+	}
+	return r, nil
+}
+
+// StreamIncident records the incident instead of sending it.
+func (r *Recorder) StreamIncident(_ context.Context, incident *pb.IncidentContext) error {
+	dryRunIncidents.WithLabelValues(incident.PodNamespace, incident.FailureReason).Inc()
+	dryRunPayloadBytes.Observe(float64(gproto.Size(incident)))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.recent) >= r.capacity {
+		r.recent = r.recent[1:]
+	}
+	r.recent = append(r.recent, incident)
+
+	if r.out == nil {
+		return nil
+	}
+	line, err := protojson.Marshal(incident)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident %s: %w", incident.IncidentId, err)
+	}
+	if _, err := r.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record incident %s: %w", incident.IncidentId, err)
+	}
+	return nil
+}
+
+// Recent returns the recorded incidents still in memory, oldest first.
+func (r *Recorder) Recent() []*pb.IncidentContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*pb.IncidentContext(nil), r.recent...)
+}
+
+// ServeHTTP writes the recent incidents as JSON lines, oldest first. The optional limit query
+// parameter returns only the newest ones.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	incidents := r.Recent()
+	if value := req.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			http.Error(w, "limit must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		if limit < len(incidents) {
+			incidents = incidents[len(incidents)-limit:]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	for _, incident := range incidents {
+		line, err := protojson.Marshal(incident)
+		if err != nil {
+			continue
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// Close closes the output file, if any.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.out == nil {
+		return nil
+	}
+	return r.out.Close()
+}
@@ -0,0 +1,95 @@
+package comms_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"kube-mind/observer/internal/comms"
+	pb "kube-mind/observer/proto"
+)
+
+func TestRecorder_StreamIncident(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "incidents.jsonl")
+	recorder, err := comms.NewRecorder(path, 2)
+	require.NoError(t, err)
+
+	for _, id := range []string{"incident-1", "incident-2", "incident-3"} {
+		require.NoError(t, recorder.StreamIncident(ctx, &pb.IncidentContext{IncidentId: id, PodNamespace: "default", FailureReason: "OOMKilled"}))
+	}
+	require.NoError(t, recorder.Close())
+
+	var recent []string
+	for _, incident := range recorder.Recent() {
+		recent = append(recent, incident.IncidentId)
+	}
+	assert.Equal(t, []string{"incident-2", "incident-3"}, recent, "only the newest incidents are kept in memory")
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var recorded []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		incident := &pb.IncidentContext{}
+		require.NoError(t, protojson.Unmarshal(scanner.Bytes(), incident))
+		recorded = append(recorded, incident.IncidentId)
+	}
+	assert.Equal(t, []string{"incident-1", "incident-2", "incident-3"}, recorded, "every incident is written to the file")
+}
+
+func TestRecorder_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	recorder, err := comms.NewRecorder("", 0)
+	require.NoError(t, err)
+	for _, id := range []string{"incident-1", "incident-2", "incident-3"} {
+		require.NoError(t, recorder.StreamIncident(ctx, &pb.IncidentContext{IncidentId: id}))
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{name: "All incidents", expectedStatus: http.StatusOK, expectedIDs: []string{"incident-1", "incident-2", "incident-3"}},
+		{name: "Limit", query: "?limit=1", expectedStatus: http.StatusOK, expectedIDs: []string{"incident-3"}},
+		{name: "Invalid limit", query: "?limit=x", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, comms.RecorderPath+tc.query, nil))
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var ids []string
+			for _, line := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n") {
+				incident := &pb.IncidentContext{}
+				require.NoError(t, protojson.Unmarshal([]byte(line), incident))
+				ids = append(ids, incident.IncidentId)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package comms
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// ZstdCompressorName is the gRPC content-coding name of the zstd compressor.
+const ZstdCompressorName = "zstd"
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor implements encoding.Compressor with pooled zstd encoders and decoders,
+// since both are expensive to create.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+// Close flushes the frame and returns the encoder to the pool.
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w)
+	return err
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+// Read returns the decoder to the pool once the frame is fully consumed.
+func (r *zstdReader) Read(p []byte) (int, error) {
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		_ = r.Decoder.Reset(nil)
+		r.pool.Put(r)
+	}
+	return n, err
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if pooled, ok := c.encoders.Get().(*zstdWriter); ok {
+		pooled.Reset(w)
+		return pooled, nil
+	}
+	encoder, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdWriter{Encoder: encoder, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if pooled, ok := c.decoders.Get().(*zstdReader); ok {
+		if err := pooled.Reset(r); err != nil {
+			return nil, err
+		}
+		return pooled, nil
+	}
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+func (c *zstdCompressor) Name() string {
+	return ZstdCompressorName
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObservedNamespaces returns the namespaces the Observer is restricted to, without the excluded
+// ones, or nil if it observes every namespace.
+func (c *ControllerConfig) ObservedNamespaces() []string {
+	var namespaces []string
+	for _, ns := range c.WatchNamespaces {
+		if !slices.Contains(c.ExcludeNamespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// Observes reports whether pods in a namespace are observed.
+func (c *ControllerConfig) Observes(namespace string) bool {
+	if slices.Contains(c.ExcludeNamespaces, namespace) {
+		return false
+	}
+	return len(c.WatchNamespaces) == 0 || slices.Contains(c.WatchNamespaces, namespace)
+}
+
+// CacheOptions restricts the manager's cache to the observed namespaces and pods, so that
+// memory and API load scale with the observed set rather than with the whole cluster.
+// Namespaced objects are only cached in the watched namespaces; excluded namespaces and the
+// label selector are applied to pods, which dominate the cache on large clusters.
+func (c *ControllerConfig) CacheOptions() (cache.Options, error) {
+	var opts cache.Options
+
+	if len(c.WatchNamespaces) > 0 {
+		namespaces := c.ObservedNamespaces()
+		if len(namespaces) == 0 {
+			return opts, errors.New("every watched namespace is excluded")
+		}
+		opts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, ns := range namespaces {
+			opts.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+
+	var pods cache.ByObject
+	if c.PodLabelSelector != "" {
+		selector, err := labels.Parse(c.PodLabelSelector)
+		if err != nil {
+			return opts, fmt.Errorf("invalid pod label selector %q: %w", c.PodLabelSelector, err)
+		}
+		pods.Label = selector
+	}
+	if len(c.WatchNamespaces) == 0 && len(c.ExcludeNamespaces) > 0 {
+		excluded := make([]fields.Selector, 0, len(c.ExcludeNamespaces))
+		for _, ns := range c.ExcludeNamespaces {
+			excluded = append(excluded, fields.OneTermNotEqualSelector("metadata.namespace", ns))
+		}
+		pods.Field = fields.AndSelectors(excluded...)
+	}
+	if pods.Label != nil || pods.Field != nil {
+		opts.ByObject = map[client.Object]cache.ByObject{&corev1.Pod{}: pods}
+	}
+	return opts, nil
+}
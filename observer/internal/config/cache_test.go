@@ -0,0 +1,110 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"kube-mind/observer/internal/config"
+)
+
+func podOptions(t *testing.T, opts cache.Options) cache.ByObject {
+	t.Helper()
+	for obj, byObject := range opts.ByObject {
+		if _, ok := obj.(*corev1.Pod); ok {
+			return byObject
+		}
+	}
+	return cache.ByObject{}
+}
+
+func TestControllerConfig_CacheOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		cfg                config.ControllerConfig
+		expectedNamespaces []string
+		expectedLabel      string
+		expectedField      string
+		expectErr          string
+	}{
+		{
+			name: "everything",
+		},
+		{
+			name: "watch list without exclusions",
+			cfg: config.ControllerConfig{
+				WatchNamespaces:   []string{"payments", "kube-system", "shop"},
+				ExcludeNamespaces: []string{"kube-system"},
+			},
+			expectedNamespaces: []string{"payments", "shop"},
+		},
+		{
+			name:          "exclusions and label selector on pods",
+			cfg:           config.ControllerConfig{ExcludeNamespaces: []string{"kube-system", "monitoring"}, PodLabelSelector: "tier!=batch"},
+			expectedLabel: "tier!=batch",
+			expectedField: "metadata.namespace!=kube-system,metadata.namespace!=monitoring",
+		},
+		{
+			name:      "every watched namespace excluded",
+			cfg:       config.ControllerConfig{WatchNamespaces: []string{"a"}, ExcludeNamespaces: []string{"a"}},
+			expectErr: "every watched namespace is excluded",
+		},
+		{
+			name:      "invalid label selector",
+			cfg:       config.ControllerConfig{PodLabelSelector: "tier in"},
+			expectErr: "invalid pod label selector",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := tc.cfg.CacheOptions()
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			var namespaces []string
+			for ns := range opts.DefaultNamespaces {
+				namespaces = append(namespaces, ns)
+			}
+			assert.ElementsMatch(t, tc.expectedNamespaces, namespaces)
+			pods := podOptions(t, opts)
+			if tc.expectedLabel == "" {
+				assert.Nil(t, pods.Label)
+			} else {
+				assert.Equal(t, tc.expectedLabel, pods.Label.String())
+				assert.False(t, pods.Label.Matches(labels.Set{"tier": "batch"}))
+			}
+			if tc.expectedField == "" {
+				assert.Nil(t, pods.Field)
+			} else {
+				assert.Equal(t, tc.expectedField, pods.Field.String())
+				assert.False(t, pods.Field.Matches(fields.Set{"metadata.namespace": "monitoring"}))
+			}
+		})
+	}
+}
+
+func TestControllerConfig_Observes(t *testing.T) {
+	t.Parallel()
+
+	all := config.ControllerConfig{ExcludeNamespaces: []string{"kube-system"}}
+	assert.True(t, all.Observes("payments"))
+	assert.False(t, all.Observes("kube-system"))
+
+	listed := config.ControllerConfig{WatchNamespaces: []string{"payments"}}
+	assert.True(t, listed.Observes("payments"))
+	assert.False(t, listed.Observes("shop"))
+}
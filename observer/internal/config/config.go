@@ -2,7 +2,11 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"kube-mind/observer/internal/domain"
 )
 
 // ControllerConfig holds the configuration for the observer controller.
@@ -15,6 +19,22 @@ type ControllerConfig struct {
 	LeaderElectionLeaseDuration time.Duration
 	LeaderElectionRenewDeadline time.Duration
 	LeaderElectionRetryPeriod   time.Duration
+	// MaxIncidentBytes caps the serialized size of an incident; larger incidents are trimmed.
+	MaxIncidentBytes int
+	// WatchNamespaces restricts the Observer to these namespaces; all namespaces if empty.
+	WatchNamespaces []string
+	// ExcludeNamespaces are never observed, even if listed in WatchNamespaces.
+	ExcludeNamespaces []string
+	// PodLabelSelector restricts the observed pods to those matching it; all pods if empty.
+	PodLabelSelector string
+	// ClusterID identifies the local cluster in incidents; remote clusters have their own.
+	ClusterID string
+	// PendingThreshold is how long a pod may stay Pending before it is an incident.
+	PendingThreshold time.Duration
+	// NotReadyThreshold is how long a running container may stay not ready before it is an incident.
+	NotReadyThreshold time.Duration
+	// CronJobFailedRuns is how many runs of a CronJob must fail in a row before it is an incident.
+	CronJobFailedRuns int
 }
 
 const (
@@ -26,6 +46,10 @@ const (
 	defaultLeaderElectionLeaseDuration = 15 * time.Second
 	defaultLeaderElectionRenewDeadline = 10 * time.Second
 	defaultLeaderElectionRetryPeriod   = 2 * time.Second
+	defaultMaxIncidentBytes            = 3 << 20
+	defaultPendingThreshold            = 600 * time.Second
+	defaultNotReadyThreshold           = 300 * time.Second
+	defaultCronJobFailedRuns           = 1
 )
 
 // LoadConfig loads configuration from environment variables.
@@ -74,6 +98,26 @@ func LoadConfig() (*ControllerConfig, error) {
 		retryPeriod = defaultLeaderElectionRetryPeriod
 	}
 
+	maxIncidentBytes, err := strconv.Atoi(os.Getenv("MAX_INCIDENT_BYTES"))
+	if err != nil || maxIncidentBytes <= 0 {
+		maxIncidentBytes = defaultMaxIncidentBytes
+	}
+
+	pendingThreshold, err := time.ParseDuration(os.Getenv("PENDING_THRESHOLD_SECONDS") + "s")
+	if err != nil || pendingThreshold <= 0 {
+		pendingThreshold = defaultPendingThreshold
+	}
+
+	notReadyThreshold, err := time.ParseDuration(os.Getenv("NOT_READY_THRESHOLD_SECONDS") + "s")
+	if err != nil || notReadyThreshold <= 0 {
+		notReadyThreshold = defaultNotReadyThreshold
+	}
+
+	cronJobFailedRuns, err := strconv.Atoi(os.Getenv("CRONJOB_FAILED_RUNS"))
+	if err != nil || cronJobFailedRuns <= 0 || cronJobFailedRuns > domain.MaxCronJobRuns {
+		cronJobFailedRuns = defaultCronJobFailedRuns
+	}
+
 	return &ControllerConfig{
 		LogLevel:                    logLevel,
 		DebounceTTLSeconds:          debounceTTL,
@@ -83,5 +127,24 @@ func LoadConfig() (*ControllerConfig, error) {
 		LeaderElectionLeaseDuration: leaseDuration,
 		LeaderElectionRenewDeadline: renewDeadline,
 		LeaderElectionRetryPeriod:   retryPeriod,
+		MaxIncidentBytes:            maxIncidentBytes,
+		WatchNamespaces:             splitList(os.Getenv("WATCH_NAMESPACES")),
+		ExcludeNamespaces:           splitList(os.Getenv("EXCLUDE_NAMESPACES")),
+		PodLabelSelector:            strings.TrimSpace(os.Getenv("POD_LABEL_SELECTOR")),
+		ClusterID:                   strings.TrimSpace(os.Getenv("CLUSTER_ID")),
+		PendingThreshold:            pendingThreshold,
+		NotReadyThreshold:           notReadyThreshold,
+		CronJobFailedRuns:           cronJobFailedRuns,
 	}, nil
 }
+
+// splitList parses a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"kube-mind/observer/internal/multicluster"
+)
+
+// ClusterSecretReconciler adds, updates and removes remote clusters as the Secrets holding their
+// kubeconfigs change.
+type ClusterSecretReconciler struct {
+	client.Client
+	Clusters *multicluster.Clusters
+	// Namespace is the only namespace kubeconfig Secrets are read from.
+	Namespace string
+}
+
+// +kubebuilder:rbac:groups=core,namespace=system,resources=secrets,verbs=get;list;watch
+
+// Reconcile applies the kubeconfig of one Secret, or removes its cluster if it is gone.
+func (r *ClusterSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	key := multicluster.SecretKey(req.Namespace, req.Name)
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		r.Clusters.Remove(key)
+		return ctrl.Result{}, nil
+	}
+	if _, ok := secret.Labels[multicluster.ClusterLabel]; !ok || secret.DeletionTimestamp != nil {
+		r.Clusters.Remove(key)
+		return ctrl.Result{}, nil
+	}
+
+	// An invalid kubeconfig is reported once; the next change to the Secret is reconciled again.
+	id, kubeconfig, err := multicluster.FromSecret(secret)
+	if err == nil {
+		err = r.Clusters.Apply(key, id, kubeconfig)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to configure remote cluster", "secret", req.NamespacedName)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	inNamespace := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.Namespace
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(inNamespace, predicate.ResourceVersionChangedPredicate{})).
+		Named("clustersecret").
+		Complete(r)
+}
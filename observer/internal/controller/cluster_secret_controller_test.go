@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"kube-mind/observer/internal/multicluster"
+)
+
+const remoteKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://eu.example.com
+contexts:
+- name: remote
+  context:
+    cluster: remote
+current-context: remote
+`
+
+func TestClusterSecretReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eu-kubeconfig",
+			Namespace: "observer-system",
+			Labels:    map[string]string{multicluster.ClusterLabel: "prod-eu"},
+		},
+		Data: map[string][]byte{multicluster.KubeconfigKey: []byte(remoteKubeconfig)},
+	}
+	invalid := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "broken",
+			Namespace: "observer-system",
+			Labels:    map[string]string{multicluster.ClusterLabel: "prod-us"},
+		},
+		Data: map[string][]byte{multicluster.KubeconfigKey: []byte("{")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, invalid).Build()
+	clusters := multicluster.NewClusters(func(context.Context, string, *rest.Config) error { return nil })
+	r := &ClusterSecretReconciler{Client: c, Clusters: clusters, Namespace: "observer-system"}
+
+	reconcile := func(name string) {
+		t.Helper()
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "observer-system", Name: name}})
+		require.NoError(t, err)
+	}
+
+	reconcile("eu-kubeconfig")
+	reconcile("broken")
+	assert.Equal(t, []string{"prod-eu"}, clusters.IDs(), "an invalid kubeconfig adds no cluster")
+
+	// Removing the label removes the cluster, as does deleting the Secret.
+	secret.Labels = nil
+	require.NoError(t, c.Update(ctx, secret))
+	reconcile("eu-kubeconfig")
+	assert.Empty(t, clusters.IDs())
+
+	secret.Labels = map[string]string{multicluster.ClusterLabel: "prod-eu"}
+	require.NoError(t, c.Update(ctx, secret))
+	reconcile("eu-kubeconfig")
+	assert.Equal(t, []string{"prod-eu"}, clusters.IDs())
+	require.NoError(t, c.Delete(ctx, secret))
+	reconcile("eu-kubeconfig")
+	assert.Empty(t, clusters.IDs())
+}
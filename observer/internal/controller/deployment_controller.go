@@ -0,0 +1,481 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/incident"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/shard"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+// revisionAnnotation holds the rollout revision of Deployments and of their ReplicaSets.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// DeploymentReconciler reports Deployment rollouts that exceed their progress deadline, or that
+// stall with the pods of the new ReplicaSet failing while the old ReplicaSets still serve. The
+// incident carries the pod template changes between the old and new ReplicaSets, so that the
+// Brain can point at the change that broke the rollout.
+type DeploymentReconciler struct {
+	client.Client
+	EventCollector harvester.EventCollector
+	ManifestParser *harvester.ManifestParser
+	IncidentCache  harvester.IntelligenceCache
+	Sink           sink.Sink
+	// Incidents, if set, records every incident as an Incident resource.
+	Incidents *incident.Store
+	// Policies, if set, applies the ObserverPolicies selecting the pods of a Deployment.
+	Policies *policy.Resolver
+	// Silences, if set, keeps incidents matched by an active Silence from being sent.
+	Silences *silence.Checker
+	// Namespaced is set when the Observer only has Roles in the watched namespaces.
+	Namespaced bool
+	// Shards, if set, restricts this replica to the Deployments it owns in its shard group.
+	Shards *shard.Sharder
+	// ClusterID identifies the cluster the Deployments run in, for Observers watching several clusters.
+	ClusterID string
+	Config    *config.ControllerConfig
+}
+
+// rollout is the state of the latest rollout of a Deployment.
+type rollout struct {
+	// newReplicaSet is nil until the Deployment controller created it.
+	newReplicaSet *appsv1.ReplicaSet
+	// oldReplicaSets are the other ReplicaSets of the Deployment, newest revision first.
+	oldReplicaSets []*appsv1.ReplicaSet
+	failingPods    []failingPod
+}
+
+// failingPod is a pod of the new ReplicaSet with a container in an incident state.
+type failingPod struct {
+	pod    *corev1.Pod
+	reason string
+}
+
+// oldAvailableReplicas returns the replicas of the old ReplicaSets that still serve.
+func (r *rollout) oldAvailableReplicas() int32 {
+	var available int32
+	for _, rs := range r.oldReplicaSets {
+		available += rs.Status.AvailableReplicas
+	}
+	return available
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list
+
+// Reconcile reports the failed or stalled rollout of a Deployment once per revision.
+func (r *DeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, req.NamespacedName, deployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if r.Shards != nil && !r.Shards.Owns(shard.WorkloadKey(deployment.Namespace, "Deployment", deployment.Name, r.Shards.Key)) {
+		return ctrl.Result{}, nil
+	}
+
+	settings, err := r.settingsFor(ctx, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if settings.Ignore {
+		return ctrl.Result{}, nil
+	}
+
+	current, err := r.rolloutOf(ctx, deployment, settings)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	failureReason := rolloutReason(deployment, current, settings)
+	if failureReason == "" {
+		if r.Incidents != nil && isRolloutComplete(deployment) {
+			workload := func() string { return "Deployment/" + deployment.Name }
+			if err := r.Incidents.Resolve(ctx, deployment.Namespace, workload); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// A rollout is reported once, whether it stalls first or exceeds its deadline first.
+	incidentKey := fmt.Sprintf("%s/Deployment/%s/%d", deployment.Namespace, deployment.Name, revision(deployment))
+	err = r.reporter().report(ctx, incidentKey, settings, func() (*pb.IncidentContext, error) {
+		log.Info("Deployment rollout entered incident state", "deployment", deployment.Name, "namespace", deployment.Namespace, "revision", revision(deployment), "reason", failureReason)
+		return r.buildIncidentContext(ctx, deployment, current, failureReason, settings)
+	})
+	return ctrl.Result{}, err
+}
+
+func (r *DeploymentReconciler) reporter() incidentReporter {
+	return incidentReporter{
+		IncidentCache:    r.IncidentCache,
+		Sink:             r.Sink,
+		Incidents:        r.Incidents,
+		Silences:         r.Silences,
+		MaxIncidentBytes: r.Config.MaxIncidentBytes,
+	}
+}
+
+// settingsFor returns the settings of the ObserverPolicy selecting the pods of the Deployment,
+// overridden by the annotations of the Deployment and its namespace.
+func (r *DeploymentReconciler) settingsFor(ctx context.Context, deployment *appsv1.Deployment) (*policy.Effective, error) {
+	settings := policy.Defaults(r.Config)
+	if r.Policies != nil {
+		// Policies select pods, so the rollout gets the settings of the pods it creates.
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: deployment.Namespace, Labels: deployment.Spec.Template.Labels}}
+		var err error
+		if settings, err = r.Policies.For(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	objects := []policy.Annotated{{Ref: "Deployment/" + deployment.Name, Annotations: deployment.Annotations}}
+	if !r.Namespaced {
+		namespace := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: deployment.Namespace}, namespace); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to get namespace", "namespace", deployment.Namespace)
+		} else {
+			objects = append(objects, policy.Annotated{Ref: "Namespace/" + namespace.Name, Annotations: namespace.Annotations})
+		}
+	}
+	annotated, err := settings.Annotate(objects)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring invalid observer annotations", "deployment", deployment.Name, "namespace", deployment.Namespace)
+	}
+	return annotated, nil
+}
+
+// rolloutOf reads the ReplicaSets of the Deployment and the failing pods of its new ReplicaSet.
+func (r *DeploymentReconciler) rolloutOf(ctx context.Context, deployment *appsv1.Deployment, settings *policy.Effective) (*rollout, error) {
+	var replicaSets appsv1.ReplicaSetList
+	if err := r.List(ctx, &replicaSets, client.InNamespace(deployment.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list replica sets of deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+	}
+	current := &rollout{}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		if current.newReplicaSet == nil && revision(rs) == revision(deployment) {
+			current.newReplicaSet = rs
+		} else {
+			current.oldReplicaSets = append(current.oldReplicaSets, rs)
+		}
+	}
+	sort.SliceStable(current.oldReplicaSets, func(i, j int) bool {
+		return revision(current.oldReplicaSets[i]) > revision(current.oldReplicaSets[j])
+	})
+	if current.newReplicaSet == nil {
+		return current, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(current.newReplicaSet.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of replica set %s/%s: %w", current.newReplicaSet.Namespace, current.newReplicaSet.Name, err)
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of replica set %s/%s: %w", current.newReplicaSet.Namespace, current.newReplicaSet.Name, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !metav1.IsControlledBy(pod, current.newReplicaSet) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range containerStatuses(pod) {
+			if reason := incidentReason(status, settings); reason != "" {
+				current.failingPods = append(current.failingPods, failingPod{pod: pod, reason: reason})
+				break
+			}
+		}
+	}
+	sort.SliceStable(current.failingPods, func(i, j int) bool {
+		return current.failingPods[i].pod.Name < current.failingPods[j].pod.Name
+	})
+	return current, nil
+}
+
+// rolloutReason returns ReasonProgressDeadlineExceeded for a rollout past its progress deadline,
+// ReasonRolloutStalled for a rollout whose new pods fail while old pods still serve, or an empty
+// string.
+func rolloutReason(deployment *appsv1.Deployment, current *rollout, settings *policy.Effective) string {
+	if deployment.Spec.Paused {
+		return ""
+	}
+	if isProgressDeadlineExceeded(deployment) && settings.IsIncidentReason(domain.ReasonProgressDeadlineExceeded) {
+		return domain.ReasonProgressDeadlineExceeded
+	}
+	if len(current.failingPods) > 0 && current.oldAvailableReplicas() > 0 && settings.IsIncidentReason(domain.ReasonRolloutStalled) {
+		return domain.ReasonRolloutStalled
+	}
+	return ""
+}
+
+func isProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	condition := deploymentCondition(deployment, appsv1.DeploymentProgressing)
+	return condition != nil && condition.Status == corev1.ConditionFalse && condition.Reason == domain.ReasonProgressDeadlineExceeded
+}
+
+// isRolloutComplete reports whether every replica of the Deployment runs its latest template.
+func isRolloutComplete(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+func deploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deployment.Status.Conditions {
+		if deployment.Status.Conditions[i].Type == conditionType {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// revision returns the rollout revision of a Deployment or ReplicaSet, or 0 if it has none yet.
+func revision(obj metav1.Object) int64 {
+	value, err := strconv.ParseInt(obj.GetAnnotations()[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// buildIncidentContext harvests the rollout state, template changes, events and manifest of a
+// Deployment. The pod fields are left empty; the Deployment is the only entry of the owner chain.
+func (r *DeploymentReconciler) buildIncidentContext(ctx context.Context, deployment *appsv1.Deployment, current *rollout, failureReason string, settings *policy.Effective) (*pb.IncidentContext, error) {
+	log := logf.FromContext(ctx)
+
+	rolloutDetails, err := r.rolloutDetails(deployment, current, settings)
+	if err != nil {
+		return nil, err
+	}
+	details := &pb.IncidentDetails{
+		OwnerChain: []*pb.OwnerReference{{
+			ApiVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       deployment.Name,
+			Uid:        string(deployment.UID),
+		}},
+		Settings: newObservationSettings(settings),
+		Rollout:  rolloutDetails,
+	}
+
+	if r.EventCollector != nil && settings.Collects(observerv1alpha1.CollectorEvents) {
+		// ReplicaSets record the pods they fail to create, e.g. for an exceeded quota.
+		uids := []types.UID{deployment.UID}
+		if current.newReplicaSet != nil {
+			uids = append(uids, current.newReplicaSet.UID)
+		}
+		for _, uid := range uids {
+			events, err := r.EventCollector.GetEvents(ctx, deployment.Namespace, uid)
+			if err != nil {
+				log.Error(err, "failed to get deployment events", "deployment", deployment.Name)
+				continue
+			}
+			details.Events = append(details.Events, newEvents(events, settings)...)
+		}
+		sort.SliceStable(details.Events, func(i, j int) bool {
+			return details.Events[i].GetLastSeen().AsTime().Before(details.Events[j].GetLastSeen().AsTime())
+		})
+	}
+
+	var deploymentManifest string
+	if settings.Collects(observerv1alpha1.CollectorManifests) {
+		deploymentManifest, err = r.ManifestParser.Fetcher.GetDeploymentManifest(ctx, deployment.Namespace, deployment.Name)
+		if err != nil {
+			log.Error(err, "failed to get deployment manifest", "name", deployment.Name)
+		} else if deploymentManifest, err = r.ManifestParser.Redactor.Redact(deploymentManifest); err != nil {
+			return nil, fmt.Errorf("failed to redact deployment manifest: %w", err)
+		}
+		deploymentManifest = settings.Redact(deploymentManifest)
+	}
+
+	incidentID := fmt.Sprintf("%s-%s-%d", deployment.Name, failureReason, time.Now().Unix())
+	if r.ClusterID != "" {
+		incidentID = r.ClusterID + "-" + incidentID
+	}
+	return &pb.IncidentContext{
+		IncidentId:             incidentID,
+		ClusterId:              r.ClusterID,
+		PodNamespace:           deployment.Namespace,
+		FailureReason:          failureReason,
+		DeploymentManifestJson: deploymentManifest,
+		Timestamp:              timestamppb.Now(),
+		Details:                details,
+	}, nil
+}
+
+// rolloutDetails describes the rollout and the pod template changes from the previous revision.
+func (r *DeploymentReconciler) rolloutDetails(deployment *appsv1.Deployment, current *rollout, settings *policy.Effective) (*pb.RolloutDetails, error) {
+	details := &pb.RolloutDetails{
+		Replicas:                ptr.Deref(deployment.Spec.Replicas, 1),
+		UpdatedReplicas:         deployment.Status.UpdatedReplicas,
+		ReadyReplicas:           deployment.Status.ReadyReplicas,
+		AvailableReplicas:       deployment.Status.AvailableReplicas,
+		UnavailableReplicas:     deployment.Status.UnavailableReplicas,
+		OldAvailableReplicas:    current.oldAvailableReplicas(),
+		ProgressDeadlineSeconds: ptr.Deref(deployment.Spec.ProgressDeadlineSeconds, 0),
+		Strategy:                rolloutStrategy(deployment.Spec.Strategy),
+	}
+	if condition := deploymentCondition(deployment, appsv1.DeploymentProgressing); condition != nil {
+		details.ConditionReason = condition.Reason
+		details.ConditionMessage = condition.Message
+		details.LastUpdate = toTimestamp(condition.LastUpdateTime)
+	}
+	for _, failing := range current.failingPods {
+		pod := newAffectedPod(failing.pod)
+		pod.Reason = failing.reason
+		details.FailingPods = append(details.FailingPods, pod)
+	}
+	if current.newReplicaSet == nil {
+		return details, nil
+	}
+	details.NewReplicaSet = current.newReplicaSet.Name
+	details.Revision = revision(current.newReplicaSet)
+	if len(current.oldReplicaSets) == 0 {
+		return details, nil
+	}
+
+	previous := current.oldReplicaSets[0]
+	details.OldReplicaSet = previous.Name
+	details.OldRevision = revision(previous)
+	redact := func(template string) (string, error) {
+		redacted, err := r.ManifestParser.Redactor.Redact(template)
+		return settings.Redact(redacted), err
+	}
+	changes, err := templateChanges(&previous.Spec.Template, &current.newReplicaSet.Spec.Template, redact)
+	if err != nil {
+		return nil, err
+	}
+	details.TemplateChanges = changes
+	return details, nil
+}
+
+// rolloutStrategy describes a Deployment strategy, e.g. "RollingUpdate maxSurge=25% maxUnavailable=0".
+func rolloutStrategy(strategy appsv1.DeploymentStrategy) string {
+	description := string(strategy.Type)
+	if update := strategy.RollingUpdate; update != nil {
+		if update.MaxSurge != nil {
+			description += " maxSurge=" + update.MaxSurge.String()
+		}
+		if update.MaxUnavailable != nil {
+			description += " maxUnavailable=" + update.MaxUnavailable.String()
+		}
+	}
+	return description
+}
+
+// rolloutTransitions passes Deployments exceeding their progress deadline and, with resolve set,
+// completing a rollout, so that the Incidents of the Deployment resolve. Stalled rollouts are
+// enqueued by the failures of their pods.
+func rolloutTransitions(resolve bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			deployment, ok := e.Object.(*appsv1.Deployment)
+			return ok && isProgressDeadlineExceeded(deployment)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDeployment, okOld := e.ObjectOld.(*appsv1.Deployment)
+			newDeployment, okNew := e.ObjectNew.(*appsv1.Deployment)
+			if !okOld || !okNew {
+				return false
+			}
+			exceeded := isProgressDeadlineExceeded(newDeployment) && !isProgressDeadlineExceeded(oldDeployment)
+			completed := resolve && isRolloutComplete(newDeployment) && !isRolloutComplete(oldDeployment)
+			return exceeded || completed
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return true
+		},
+	}
+}
+
+// podFailures passes pods whose containers move into a failure state, like podFailureTransitions
+// without the pending, not ready and disrupted pods.
+func podFailures() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			pod, ok := e.Object.(*corev1.Pod)
+			return ok && hasFailingContainer(pod)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			return okOld && okNew && enteredFailure(oldPod, newPod)
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// podDeployment maps a pod to the Deployment owning its ReplicaSet.
+func (r *DeploymentReconciler) podDeployment(ctx context.Context, obj client.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "ReplicaSet" {
+		return nil
+	}
+	rs := &appsv1.ReplicaSet{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: owner.Name}, rs); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logf.FromContext(ctx).Error(err, "failed to get replica set of pod", "pod", obj.GetName(), "replicaSet", owner.Name)
+		}
+		return nil
+	}
+	deployment := metav1.GetControllerOf(rs)
+	if deployment == nil || deployment.Kind != "Deployment" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: rs.Namespace, Name: deployment.Name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	observed := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.Config.Observes(obj.GetNamespace())
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}, builder.WithPredicates(observed, rolloutTransitions(r.Incidents != nil))).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podDeployment), builder.WithPredicates(observed, podFailures())).
+		Named("deployment").
+		Complete(r)
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+func newPodTemplate(image, logLevel, password string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "app",
+			Image: image,
+			Env: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: logLevel},
+				{Name: "DB_PASSWORD", Value: password},
+			},
+		}}},
+	}
+}
+
+func TestTemplateChanges(t *testing.T) {
+	t.Parallel()
+
+	redact, err := harvester.NewRegexRedactionEngine()
+	require.NoError(t, err)
+	base := newPodTemplate("api:1.0", "info", "hunter1")
+	hashed := base.DeepCopy()
+	hashed.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "7d9f"
+	sidecar := base.DeepCopy()
+	sidecar.Spec.Containers = append(sidecar.Spec.Containers, corev1.Container{Name: "proxy", Image: "envoy:1.30"})
+	reordered := base.DeepCopy()
+	reordered.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "FEATURE", Value: "on"}, base.Spec.Containers[0].Env[0], base.Spec.Containers[0].Env[1]}
+
+	testCases := []struct {
+		name     string
+		old      corev1.PodTemplateSpec
+		new      corev1.PodTemplateSpec
+		expected []*pb.TemplateChange
+	}{
+		{
+			name: "image and environment",
+			old:  base,
+			new:  newPodTemplate("api:1.1", "debug", "hunter2"),
+			expected: []*pb.TemplateChange{
+				{Path: "spec.containers[app].env[LOG_LEVEL].value", OldValue: "info", NewValue: "debug"},
+				{Path: "spec.containers[app].image", OldValue: "api:1.0", NewValue: "api:1.1"},
+			},
+		},
+		{
+			name:     "container added",
+			old:      base,
+			new:      *sidecar,
+			expected: []*pb.TemplateChange{{Path: "spec.containers[proxy].image", NewValue: "envoy:1.30"}},
+		},
+		{
+			name:     "variable inserted before the others",
+			old:      base,
+			new:      *reordered,
+			expected: []*pb.TemplateChange{{Path: "spec.containers[app].env[FEATURE].value", NewValue: "on"}},
+		},
+		{name: "pod-template-hash only", old: base, new: *hashed},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := templateChanges(&tc.old, &tc.new, redact.Redact)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, changes)
+		})
+	}
+}
+
+func TestDeploymentReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "api", Namespace: "payments", UID: "api-uid", Generation: 2,
+			Annotations: map[string]string{revisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas:                ptr.To(int32(3)),
+			Selector:                &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template:                newPodTemplate("api:1.1", "debug", "hunter2"),
+			ProgressDeadlineSeconds: ptr.To(int32(600)),
+			Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3, UnavailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"}},
+		},
+	}
+	ownedBy := metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	replicaSet := func(name, hash, rev string, template corev1.PodTemplateSpec, available int32) *appsv1.ReplicaSet {
+		template.Labels = map[string]string{"app": "api", appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "payments", UID: types.UID(name + "-uid"),
+				Annotations:     map[string]string{revisionAnnotation: rev},
+				OwnerReferences: []metav1.OwnerReference{*ownedBy},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: template.Labels},
+				Template: template,
+			},
+			Status: appsv1.ReplicaSetStatus{AvailableReplicas: available},
+		}
+	}
+	oldRS := replicaSet("api-6c8b", "6c8b", "1", newPodTemplate("api:1.0", "info", "hunter1"), 3)
+	newRS := replicaSet("api-7d9f", "7d9f", "2", deployment.Spec.Template, 0)
+	crashing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "api-7d9f-x1", Namespace: "payments",
+			Labels:          newRS.Spec.Template.Labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(newRS, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "app",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: domain.ReasonCrashLoopBackOff}},
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, oldRS, newRS, crashing).Build()
+	manifestParser, err := harvester.NewManifestParser(c)
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	r := &DeploymentReconciler{
+		Client:         c,
+		EventCollector: &mockEventCollector{},
+		ManifestParser: manifestParser,
+		IncidentCache:  harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute),
+		Sink:           sink,
+		Namespaced:     true,
+		Config:         &config.ControllerConfig{DebounceTTLSeconds: time.Minute},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "payments", Name: "api"}}
+	assert.Equal(t, []ctrl.Request{req}, r.podDeployment(ctx, crashing))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{domain.ReasonRolloutStalled}, sink.reasons())
+	incident := sink.incidents[0]
+	assert.Equal(t, "payments/Deployment/api", pb.Subject(incident))
+	assert.Contains(t, incident.DeploymentManifestJson, `"value": "[REDACTED]"`)
+	rollout := incident.Details.Rollout
+	require.NotNil(t, rollout)
+	assert.Equal(t, int64(2), rollout.Revision)
+	assert.Equal(t, "api-7d9f", rollout.NewReplicaSet)
+	assert.Equal(t, "api-6c8b", rollout.OldReplicaSet)
+	assert.Equal(t, int32(3), rollout.OldAvailableReplicas)
+	assert.Equal(t, int32(600), rollout.ProgressDeadlineSeconds)
+	assert.Equal(t, []*pb.AffectedPod{{
+		Namespace: "payments", Name: "api-7d9f-x1", Phase: "Running", Workload: "ReplicaSet/api-7d9f", Reason: domain.ReasonCrashLoopBackOff,
+	}}, rollout.FailingPods)
+	assert.Equal(t, []*pb.TemplateChange{
+		{Path: "spec.containers[app].env[LOG_LEVEL].value", OldValue: "info", NewValue: "debug"},
+		{Path: "spec.containers[app].image", OldValue: "api:1.0", NewValue: "api:1.1"},
+	}, rollout.TemplateChanges, "secret values are redacted on both sides, so their change is not reported")
+
+	exceeded := deployment.DeepCopy()
+	exceeded.Status.Conditions[0].Status = corev1.ConditionFalse
+	exceeded.Status.Conditions[0].Reason = domain.ReasonProgressDeadlineExceeded
+	require.NoError(t, c.Status().Update(ctx, exceeded))
+	assert.True(t, rolloutTransitions(false).Update(event.UpdateEvent{ObjectOld: deployment, ObjectNew: exceeded}))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Len(t, sink.reasons(), 1, "a rollout is reported once per revision")
+}
+
+func TestRolloutReason(t *testing.T) {
+	t.Parallel()
+	settings := policy.Defaults(&config.ControllerConfig{})
+
+	exceeded := &appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: domain.ReasonProgressDeadlineExceeded,
+	}}}}
+	paused := exceeded.DeepCopy()
+	paused.Spec.Paused = true
+	serving := []*appsv1.ReplicaSet{{Status: appsv1.ReplicaSetStatus{AvailableReplicas: 2}}}
+	failing := []failingPod{{pod: &corev1.Pod{}, reason: domain.ReasonImagePullBackOff}}
+
+	testCases := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		rollout    *rollout
+		expected   string
+	}{
+		{name: "progress deadline exceeded", deployment: exceeded, rollout: &rollout{}, expected: domain.ReasonProgressDeadlineExceeded},
+		{name: "paused", deployment: paused, rollout: &rollout{oldReplicaSets: serving, failingPods: failing}},
+		{name: "new pods failing while old ones serve", deployment: &appsv1.Deployment{}, rollout: &rollout{oldReplicaSets: serving, failingPods: failing}, expected: domain.ReasonRolloutStalled},
+		{name: "new pods failing without old ones", deployment: &appsv1.Deployment{}, rollout: &rollout{failingPods: failing}},
+		{name: "progressing", deployment: &appsv1.Deployment{}, rollout: &rollout{oldReplicaSets: serving}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, rolloutReason(tc.deployment, tc.rollout, settings))
+		})
+	}
+}
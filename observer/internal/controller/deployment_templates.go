@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// maxTemplateChanges bounds the pod template changes reported for a rollout.
+	maxTemplateChanges = 50
+	// maxTemplateValueLength bounds each value of a template change, such as a long command line.
+	maxTemplateValueLength = 512
+)
+
+// templateChanges lists the values that differ between two pod templates, sorted by path. Both
+// templates go through redact as JSON first, so that the values of secret-like environment
+// variables are never compared in the clear.
+func templateChanges(oldTemplate, newTemplate *corev1.PodTemplateSpec, redact func(string) (string, error)) ([]*pb.TemplateChange, error) {
+	oldValues, err := flattenTemplate(oldTemplate, redact)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := flattenTemplate(newTemplate, redact)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(newValues))
+	for path := range newValues {
+		paths = append(paths, path)
+	}
+	for path := range oldValues {
+		if _, found := newValues[path]; !found {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []*pb.TemplateChange
+	for _, path := range paths {
+		if oldValues[path] == newValues[path] {
+			continue
+		}
+		if len(changes) == maxTemplateChanges {
+			break
+		}
+		changes = append(changes, &pb.TemplateChange{
+			Path:     path,
+			OldValue: truncateValue(oldValues[path]),
+			NewValue: truncateValue(newValues[path]),
+		})
+	}
+	return changes, nil
+}
+
+// flattenTemplate maps the path of every value of a pod template to the value. The
+// pod-template-hash label is left out, as it differs between every two ReplicaSets.
+func flattenTemplate(template *corev1.PodTemplateSpec, redact func(string) (string, error)) (map[string]string, error) {
+	template = template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	data, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pod template: %w", err)
+	}
+	redacted, err := redact(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to redact pod template: %w", err)
+	}
+	var tree any
+	if err := json.Unmarshal([]byte(redacted), &tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal redacted pod template: %w", err)
+	}
+	values := map[string]string{}
+	flattenValue("", tree, values)
+	return values, nil
+}
+
+func flattenValue(path string, value any, values map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if path != "" {
+				key = path + "." + key
+			}
+			flattenValue(key, child, values)
+		}
+	case []any:
+		for i, item := range v {
+			key := itemKey(item, i)
+			if fields, ok := item.(map[string]any); ok && fields["name"] == key {
+				// The name is part of the path already.
+				fields = maps.Clone(fields)
+				delete(fields, "name")
+				item = fields
+			}
+			flattenValue(fmt.Sprintf("%s[%s]", path, key), item, values)
+		}
+	case nil:
+	case string:
+		values[path] = v
+	default:
+		data, _ := json.Marshal(v)
+		values[path] = string(data)
+	}
+}
+
+// itemKey names list items by their name, as containers, environment variables and volumes
+// are, so that adding an item does not move the paths of the items after it.
+func itemKey(item any, index int) string {
+	if fields, ok := item.(map[string]any); ok {
+		if name, ok := fields["name"].(string); ok && name != "" {
+			return name
+		}
+	}
+	return strconv.Itoa(index)
+}
+
+func truncateValue(value string) string {
+	if len(value) <= maxTemplateValueLength {
+		return value
+	}
+	return value[:maxTemplateValueLength] + "..."
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+// newIncidentDetails builds the typed view of a pod for the container that triggered the incident.
+// Owner chain, events and log sections are gathered separately since they require API calls.
+// Container state messages are redacted by settings, as termination messages often repeat logs.
+func newIncidentDetails(pod *corev1.Pod, containerName string, settings *policy.Effective) *pb.IncidentDetails {
+	details := &pb.IncidentDetails{
+		ContainerName: containerName,
+		NodeName:      pod.Spec.NodeName,
+		Phase:         string(pod.Status.Phase),
+		QosClass:      string(pod.Status.QOSClass),
+		Containers:    make([]*pb.ContainerInfo, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)),
+	}
+	for _, container := range pod.Spec.InitContainers {
+		details.Containers = append(details.Containers, newContainerInfo(container, findContainerStatus(pod.Status.InitContainerStatuses, container.Name), true, settings))
+	}
+	for _, container := range pod.Spec.Containers {
+		details.Containers = append(details.Containers, newContainerInfo(container, findContainerStatus(pod.Status.ContainerStatuses, container.Name), false, settings))
+	}
+	return details
+}
+
+func newContainerInfo(container corev1.Container, status *corev1.ContainerStatus, initContainer bool, settings *policy.Effective) *pb.ContainerInfo {
+	info := &pb.ContainerInfo{
+		Name:          container.Name,
+		InitContainer: initContainer,
+		Image:         container.Image,
+		Resources: &pb.ResourceRequirements{
+			Requests: resourceListToMap(container.Resources.Requests),
+			Limits:   resourceListToMap(container.Resources.Limits),
+		},
+	}
+	if status == nil {
+		return info
+	}
+	info.ImageDigest = status.ImageID
+	info.Ready = status.Ready
+	info.RestartCount = status.RestartCount
+	info.State = newContainerState(status.State, settings)
+	if status.LastTerminationState.Terminated != nil {
+		info.LastTermination = newContainerState(status.LastTerminationState, settings)
+	}
+	return info
+}
+
+func newContainerState(state corev1.ContainerState, settings *policy.Effective) *pb.ContainerState {
+	switch {
+	case state.Waiting != nil:
+		return &pb.ContainerState{
+			Phase:   domain.ContainerStateWaiting,
+			Reason:  state.Waiting.Reason,
+			Message: settings.Redact(state.Waiting.Message),
+		}
+	case state.Running != nil:
+		return &pb.ContainerState{
+			Phase:     domain.ContainerStateRunning,
+			StartedAt: toTimestamp(state.Running.StartedAt),
+		}
+	case state.Terminated != nil:
+		return &pb.ContainerState{
+			Phase:      domain.ContainerStateTerminated,
+			Reason:     state.Terminated.Reason,
+			Message:    settings.Redact(state.Terminated.Message),
+			ExitCode:   state.Terminated.ExitCode,
+			Signal:     state.Terminated.Signal,
+			StartedAt:  toTimestamp(state.Terminated.StartedAt),
+			FinishedAt: toTimestamp(state.Terminated.FinishedAt),
+		}
+	default:
+		return nil
+	}
+}
+
+func newOwnerChain(owners []metav1.OwnerReference) []*pb.OwnerReference {
+	chain := make([]*pb.OwnerReference, 0, len(owners))
+	for _, owner := range owners {
+		chain = append(chain, &pb.OwnerReference{
+			ApiVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Uid:        string(owner.UID),
+		})
+	}
+	return chain
+}
+
+func newObservationSettings(settings *policy.Effective) *pb.ObservationSettings {
+	collectors := make([]string, 0, len(settings.Collectors))
+	for _, collector := range settings.Collectors {
+		collectors = append(collectors, string(collector))
+	}
+	return &pb.ObservationSettings{
+		Policy:          settings.Policy,
+		LogTailLines:    settings.LogTailLines,
+		Collectors:      collectors,
+		ExtraContainers: settings.ExtraContainers,
+		Severity:        settings.Severity,
+		Team:            settings.Team,
+		AnnotatedBy:     settings.AnnotatedBy,
+	}
+}
+
+// newEvents converts events, oldest first as listed, redacting their messages by settings.
+func newEvents(events []corev1.Event, settings *policy.Effective) []*pb.Event {
+	converted := make([]*pb.Event, 0, len(events))
+	for _, event := range events {
+		firstSeen := event.FirstTimestamp
+		if firstSeen.IsZero() {
+			firstSeen = metav1.Time{Time: event.EventTime.Time}
+		}
+		lastSeen := event.LastTimestamp
+		if lastSeen.IsZero() && event.Series != nil {
+			lastSeen = metav1.Time{Time: event.Series.LastObservedTime.Time}
+		}
+		count := event.Count
+		if count == 0 && event.Series != nil {
+			count = event.Series.Count
+		}
+		source := event.Source.Component
+		if source == "" {
+			source = event.ReportingController
+		}
+		converted = append(converted, &pb.Event{
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   settings.Redact(event.Message),
+			Count:     count,
+			FirstSeen: toTimestamp(firstSeen),
+			LastSeen:  toTimestamp(lastSeen),
+			Source:    source,
+		})
+	}
+	return converted
+}
+
+func findContainerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func resourceListToMap(resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	converted := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		converted[string(name)] = quantity.String()
+	}
+	return converted
+}
+
+func toTimestamp(t metav1.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+)
+
+func TestNewIncidentDetails(t *testing.T) {
+	t.Parallel()
+
+	finishedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeName:       "node-a",
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1"}},
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "app:2",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:    corev1.PodRunning,
+			QOSClass: corev1.PodQOSBurstable,
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "migrate",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				ImageID:      "docker.io/library/app@sha256:abc",
+				RestartCount: 4,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  domain.ReasonCrashLoopBackOff,
+					Message: "back-off 5m0s",
+				}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:     domain.ReasonOOMKilled,
+					ExitCode:   137,
+					Signal:     9,
+					FinishedAt: finishedAt,
+				}},
+			}},
+		},
+	}
+
+	details := newIncidentDetails(pod, "app", &policy.Effective{})
+
+	assert.Equal(t, "app", details.ContainerName)
+	assert.Equal(t, "node-a", details.NodeName)
+	assert.Equal(t, "Running", details.Phase)
+	assert.Equal(t, "Burstable", details.QosClass)
+	require.Len(t, details.Containers, 2)
+
+	initContainer := details.Containers[0]
+	assert.True(t, initContainer.InitContainer)
+	assert.Equal(t, domain.ContainerStateTerminated, initContainer.State.Phase)
+	assert.Nil(t, initContainer.LastTermination)
+
+	app := details.Containers[1]
+	assert.False(t, app.InitContainer)
+	assert.Equal(t, "app:2", app.Image)
+	assert.Equal(t, "docker.io/library/app@sha256:abc", app.ImageDigest)
+	assert.Equal(t, map[string]string{"memory": "64Mi"}, app.Resources.Requests)
+	assert.Equal(t, map[string]string{"memory": "128Mi"}, app.Resources.Limits)
+	assert.EqualValues(t, 4, app.RestartCount)
+	assert.Equal(t, domain.ContainerStateWaiting, app.State.Phase)
+	assert.Equal(t, domain.ReasonCrashLoopBackOff, app.State.Reason)
+	require.NotNil(t, app.LastTermination)
+	assert.Equal(t, domain.ReasonOOMKilled, app.LastTermination.Reason)
+	assert.EqualValues(t, 137, app.LastTermination.ExitCode)
+	assert.EqualValues(t, 9, app.LastTermination.Signal)
+	assert.Equal(t, finishedAt.Time, app.LastTermination.FinishedAt.AsTime())
+}
+
+func TestNewEvents(t *testing.T) {
+	t.Parallel()
+
+	seen := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		event          corev1.Event
+		expectedCount  int32
+		expectedSource string
+	}{
+		{
+			name: "Legacy core event",
+			event: corev1.Event{
+				Type:           corev1.EventTypeWarning,
+				Reason:         "BackOff",
+				Count:          7,
+				Source:         corev1.EventSource{Component: "kubelet"},
+				FirstTimestamp: metav1.NewTime(seen),
+				LastTimestamp:  metav1.NewTime(seen),
+			},
+			expectedCount:  7,
+			expectedSource: "kubelet",
+		},
+		{
+			name: "events.k8s.io event with a series",
+			event: corev1.Event{
+				Type:                corev1.EventTypeWarning,
+				Reason:              "FailedMount",
+				EventTime:           metav1.NewMicroTime(seen),
+				ReportingController: "kubelet",
+				Series:              &corev1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(seen)},
+			},
+			expectedCount:  3,
+			expectedSource: "kubelet",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := newEvents([]corev1.Event{tc.event}, &policy.Effective{})
+
+			require.Len(t, events, 1)
+			assert.Equal(t, tc.event.Reason, events[0].Reason)
+			assert.Equal(t, tc.expectedCount, events[0].Count)
+			assert.Equal(t, tc.expectedSource, events[0].Source)
+			assert.Equal(t, seen, events[0].FirstSeen.AsTime())
+			assert.Equal(t, seen, events[0].LastSeen.AsTime())
+		})
+	}
+}
+
+func TestNewIncidentDetails_Redaction(t *testing.T) {
+	t.Parallel()
+
+	settings, err := policy.Compile(&observerv1alpha1.ObserverPolicy{Spec: observerv1alpha1.ObserverPolicySpec{
+		Redaction: []observerv1alpha1.RedactionRule{{Pattern: `password=\S+`, Replacement: "password=[REDACTED]"}},
+	}}, &policy.Effective{})
+	require.NoError(t, err)
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "app",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason: domain.ReasonCrashLoopBackOff, Message: "bad env password=hunter2",
+			}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: domain.ReasonError, Message: "connect failed: password=hunter2 rejected",
+			}},
+		}}},
+	}
+
+	details := newIncidentDetails(pod, "app", settings)
+	events := newEvents([]corev1.Event{{Reason: "Failed", Message: "exec probe: password=hunter2"}}, settings)
+
+	app := details.Containers[0]
+	assert.Equal(t, "bad env password=[REDACTED]", app.State.Message)
+	assert.Equal(t, "connect failed: password=[REDACTED] rejected", app.LastTermination.Message)
+	assert.Equal(t, "exec probe: password=[REDACTED]", events[0].Message)
+}
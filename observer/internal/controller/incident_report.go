@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/incident"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+// incidentReporter sends the incidents of every reconciler: those of pods, nodes, Deployment
+// rollouts and Jobs all go through the same debounce, trim, silence, record and dispatch steps.
+type incidentReporter struct {
+	IncidentCache    harvester.IntelligenceCache
+	Sink             sink.Sink
+	Incidents        *incident.Store
+	Silences         *silence.Checker
+	MaxIncidentBytes int
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+}
+
+// report sends the incident returned by build, unless it is debounced under incidentKey or
+// silenced. build only runs for incidents that are not debounced.
+func (w incidentReporter) report(ctx context.Context, incidentKey string, settings *policy.Effective, build func() (*pb.IncidentContext, error)) error {
+	log := logf.FromContext(ctx)
+
+	if _, found := w.IncidentCache.Get(incidentKey); found {
+		log.Info("Incident debounced", "key", incidentKey)
+		return nil
+	}
+	w.IncidentCache.AddOrUpdate(incidentKey, true, settings.DebounceTTL)
+
+	incidentContext, err := build()
+	if err != nil {
+		return err
+	}
+	incidentContext = pb.TrimToBudget(incidentContext, w.MaxIncidentBytes)
+	if len(incidentContext.TrimmedFields) > 0 {
+		log.Info("Incident trimmed to fit the size budget", "incidentID", incidentContext.IncidentId, "trimmedFields", incidentContext.TrimmedFields)
+	}
+
+	if w.Silences != nil {
+		silenced, err := w.Silences.Silencing(ctx, incidentContext, w.now())
+		if err != nil {
+			log.Error(err, "failed to check silences", "incidentID", incidentContext.IncidentId)
+		} else if silenced != nil {
+			if w.Incidents != nil {
+				if err := w.Incidents.RecordSilenced(ctx, incidentContext, silenced.Name); err != nil {
+					log.Error(err, "failed to record incident resource", "incidentID", incidentContext.IncidentId)
+				}
+			}
+			w.Silences.RecordSuppression(ctx, silenced, incidentContext)
+			log.Info("Incident silenced", "incidentID", incidentContext.IncidentId, "silence", silenced.Name)
+			return nil
+		}
+	}
+
+	if w.Incidents != nil {
+		if err := w.Incidents.Record(ctx, incidentContext); err != nil {
+			log.Error(err, "failed to record incident resource", "incidentID", incidentContext.IncidentId)
+		}
+	}
+
+	err = func() error {
+		if len(settings.Sinks) > 0 {
+			if targeted, ok := w.Sink.(sink.TargetedSink); ok {
+				return targeted.SendTo(ctx, incidentContext, settings.Sinks)
+			}
+		}
+		return w.Sink.Send(ctx, incidentContext)
+	}()
+	if err != nil {
+		log.Error(err, "failed to dispatch incident", "incidentID", incidentContext.IncidentId)
+		return err
+	}
+	log.Info("Incident dispatched", "incidentID", incidentContext.IncidentId, "policy", settings.Policy)
+	return nil
+}
+
+func (w incidentReporter) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
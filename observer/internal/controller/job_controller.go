@@ -0,0 +1,656 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/incident"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/shard"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// jobFailureWindow is how recent the failure of a Job must be to be reported, so that the
+	// failed Jobs listed when the Observer starts are not reported again.
+	jobFailureWindow = 15 * time.Minute
+	// maxJobAttempts bounds the failed attempts kept for a Job, keeping the latest.
+	maxJobAttempts = 10
+)
+
+// Statuses of the runs of a CronJob.
+const (
+	runSucceeded = "Succeeded"
+	runFailed    = "Failed"
+	runActive    = "Active"
+)
+
+// JobReconciler reports Jobs that fail, by exceeding their backoff limit or deadline, and CronJobs
+// whose last runs failed. The pods of a Job are deleted soon after it fails, by its TTL or the
+// history limit of its CronJob, so the logs of every failed attempt are harvested as soon as its
+// pod fails and kept until the Job finishes.
+type JobReconciler struct {
+	client.Client
+	LogAggregator  harvester.LogAggregator
+	EventCollector harvester.EventCollector
+	IncidentCache  harvester.IntelligenceCache
+	Sink           sink.Sink
+	// Incidents, if set, records every incident as an Incident resource.
+	Incidents *incident.Store
+	// Policies, if set, applies the ObserverPolicies selecting the pods of a Job.
+	Policies *policy.Resolver
+	// Silences, if set, keeps incidents matched by an active Silence from being sent.
+	Silences *silence.Checker
+	// Namespaced is set when the Observer only has Roles in the watched namespaces.
+	Namespaced bool
+	// Shards, if set, restricts this replica to the Jobs and CronJobs it owns in its shard group.
+	Shards *shard.Sharder
+	// ClusterID identifies the cluster the Jobs run in, for Observers watching several clusters.
+	ClusterID string
+	Config    *config.ControllerConfig
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+
+	mu sync.Mutex
+	// attempts holds the failed attempts of the running Jobs.
+	attempts map[types.NamespacedName]*jobAttempts
+	// runs holds the failed runs of each CronJob, newest last, as the CronJob deletes failed Jobs
+	// beyond its history limit.
+	runs map[types.NamespacedName][]*pb.CronJobRun
+}
+
+// jobAttempts are the failed attempts of a Job, by pod, container and restart.
+type jobAttempts struct {
+	uid      types.UID
+	attempts map[string]*jobAttempt
+}
+
+// jobAttempt is a failed attempt of a Job with the logs of its container, if collected.
+type jobAttempt struct {
+	attempt *pb.JobAttempt
+	logs    *pb.LogSection
+}
+
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list
+
+// Reconcile harvests the failed attempts of a Job and reports the Job once it fails, or its
+// CronJob once enough runs failed in a row.
+func (r *JobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, req.NamespacedName, job); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forgetAttempts(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	workloadKind, workloadName := "Job", job.Name
+	owner := metav1.GetControllerOf(job)
+	if owner != nil && owner.Kind == "CronJob" {
+		workloadKind, workloadName = "CronJob", owner.Name
+	}
+	if r.Shards != nil && !r.Shards.Owns(shard.WorkloadKey(job.Namespace, workloadKind, workloadName, r.Shards.Key)) {
+		return ctrl.Result{}, nil
+	}
+
+	var cronJob *batchv1.CronJob
+	if workloadKind == "CronJob" {
+		cronJob = &batchv1.CronJob{}
+		key := types.NamespacedName{Namespace: job.Namespace, Name: workloadName}
+		if err := r.Get(ctx, key, cronJob); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, err
+			}
+			r.forgetRuns(key)
+			cronJob = nil
+		}
+	}
+
+	settings, err := r.settingsFor(ctx, job, cronJob)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if settings.Ignore {
+		r.forgetAttempts(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+	if err := r.harvestAttempts(ctx, job, settings); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	failed := jobFailedCondition(job)
+	if failed == nil {
+		if isJobComplete(job) {
+			r.forgetAttempts(req.NamespacedName)
+		}
+		return ctrl.Result{}, nil
+	}
+	// The attempts of a failed Job are reported now or never.
+	attempts := r.forgetAttempts(req.NamespacedName)
+	var runs []*pb.CronJobRun
+	if cronJob != nil {
+		r.recordRun(cronJob, job)
+		if runs, err = r.cronJobRuns(ctx, cronJob); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if r.now().Sub(failed.LastTransitionTime.Time) > jobFailureWindow {
+		return ctrl.Result{}, nil
+	}
+
+	failureReason := jobFailureReason(failed)
+	var incidentKey string
+	if cronJob == nil {
+		if !settings.IsIncidentReason(failureReason) {
+			return ctrl.Result{}, nil
+		}
+		incidentKey = fmt.Sprintf("%s/Job/%s/%s", job.Namespace, job.Name, job.UID)
+	} else {
+		if consecutiveFailures(cronJob, runs) < int32(settings.CronJobFailedRuns) || !settings.IsIncidentReason(domain.ReasonCronJobFailing) {
+			return ctrl.Result{}, nil
+		}
+		// A failing CronJob is reported once per debounce period, not once per failed run.
+		failureReason = domain.ReasonCronJobFailing
+		incidentKey = fmt.Sprintf("%s/CronJob/%s/%s", cronJob.Namespace, cronJob.Name, failureReason)
+	}
+
+	err = r.reporter().report(ctx, incidentKey, settings, func() (*pb.IncidentContext, error) {
+		log.Info("Job entered incident state", "job", job.Name, "namespace", job.Namespace, "reason", failureReason, "attempts", len(attempts))
+		return r.buildIncidentContext(ctx, job, cronJob, failed, runs, attempts, failureReason, settings), nil
+	})
+	return ctrl.Result{}, err
+}
+
+func (r *JobReconciler) reporter() incidentReporter {
+	return incidentReporter{
+		IncidentCache:    r.IncidentCache,
+		Sink:             r.Sink,
+		Incidents:        r.Incidents,
+		Silences:         r.Silences,
+		MaxIncidentBytes: r.Config.MaxIncidentBytes,
+		Now:              r.now,
+	}
+}
+
+func (r *JobReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// settingsFor returns the settings of the ObserverPolicy selecting the pods of the Job, overridden
+// by the annotations of the Job, its CronJob and its namespace.
+func (r *JobReconciler) settingsFor(ctx context.Context, job *batchv1.Job, cronJob *batchv1.CronJob) (*policy.Effective, error) {
+	settings := policy.Defaults(r.Config)
+	if r.Policies != nil {
+		// Policies select pods, so the Job gets the settings of the pods it creates.
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Labels: job.Spec.Template.Labels}}
+		var err error
+		if settings, err = r.Policies.For(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	objects := []policy.Annotated{{Ref: "Job/" + job.Name, Annotations: job.Annotations}}
+	if cronJob != nil {
+		objects = append(objects, policy.Annotated{Ref: "CronJob/" + cronJob.Name, Annotations: cronJob.Annotations})
+	}
+	if !r.Namespaced {
+		namespace := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: job.Namespace}, namespace); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to get namespace", "namespace", job.Namespace)
+		} else {
+			objects = append(objects, policy.Annotated{Ref: "Namespace/" + namespace.Name, Annotations: namespace.Annotations})
+		}
+	}
+	annotated, err := settings.Annotate(objects)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring invalid observer annotations", "job", job.Name, "namespace", job.Namespace)
+	}
+	return annotated, nil
+}
+
+// harvestAttempts records the containers of the Job's pods that terminated with an error, with
+// their logs, unless they are recorded already. A container restarted by the OnFailure restart
+// policy is an attempt too, whose logs are those of its previous instance.
+func (r *JobReconciler) harvestAttempts(ctx context.Context, job *batchv1.Job, settings *policy.Effective) error {
+	log := logf.FromContext(ctx)
+
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list pods of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !metav1.IsControlledBy(pod, job) {
+			continue
+		}
+		for _, status := range containerStatuses(pod) {
+			terminated, previous, restart := status.State.Terminated, false, status.RestartCount
+			if terminated == nil || terminated.ExitCode == 0 {
+				terminated, previous, restart = status.LastTerminationState.Terminated, true, status.RestartCount-1
+			}
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%d", pod.Name, status.Name, restart)
+			if r.hasAttempt(job, key) {
+				continue
+			}
+
+			attempt := &jobAttempt{attempt: &pb.JobAttempt{
+				PodName:    pod.Name,
+				NodeName:   pod.Spec.NodeName,
+				Container:  status.Name,
+				Reason:     terminated.Reason,
+				ExitCode:   terminated.ExitCode,
+				StartedAt:  toTimestamp(terminated.StartedAt),
+				FinishedAt: toTimestamp(terminated.FinishedAt),
+			}}
+			getLogs, collector := r.LogAggregator.GetLogs, observerv1alpha1.CollectorLogs
+			if previous {
+				getLogs, collector = r.LogAggregator.GetPreviousLogs, observerv1alpha1.CollectorPreviousLogs
+			}
+			if settings.Collects(collector) {
+				logs, err := getLogs(ctx, pod.Namespace, pod.Name, status.Name, settings.LogTailLines)
+				if err != nil {
+					log.Error(err, "failed to get logs of job attempt", "job", job.Name, "pod", pod.Name, "container", status.Name)
+				} else {
+					attempt.logs = &pb.LogSection{Container: status.Name, Pod: pod.Name, Previous: previous, Content: settings.Redact(logs)}
+				}
+			}
+			r.addAttempt(job, key, attempt)
+		}
+	}
+	return nil
+}
+
+func (r *JobReconciler) hasAttempt(job *batchv1.Job, key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recorded := r.attempts[client.ObjectKeyFromObject(job)]
+	if recorded == nil || recorded.uid != job.UID {
+		return false
+	}
+	_, found := recorded.attempts[key]
+	return found
+}
+
+func (r *JobReconciler) addAttempt(job *batchv1.Job, key string, attempt *jobAttempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.attempts == nil {
+		r.attempts = make(map[types.NamespacedName]*jobAttempts)
+	}
+	name := client.ObjectKeyFromObject(job)
+	recorded := r.attempts[name]
+	if recorded == nil || recorded.uid != job.UID {
+		// A Job recreated under the same name starts over.
+		recorded = &jobAttempts{uid: job.UID, attempts: make(map[string]*jobAttempt)}
+		r.attempts[name] = recorded
+	}
+	recorded.attempts[key] = attempt
+	if len(recorded.attempts) > maxJobAttempts {
+		oldest := ""
+		for k, a := range recorded.attempts {
+			if oldest == "" || a.attempt.GetFinishedAt().AsTime().Before(recorded.attempts[oldest].attempt.GetFinishedAt().AsTime()) {
+				oldest = k
+			}
+		}
+		delete(recorded.attempts, oldest)
+	}
+}
+
+// forgetAttempts drops the failed attempts of a Job and returns them, oldest first.
+func (r *JobReconciler) forgetAttempts(name types.NamespacedName) []*jobAttempt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recorded := r.attempts[name]
+	delete(r.attempts, name)
+	if recorded == nil {
+		return nil
+	}
+	attempts := make([]*jobAttempt, 0, len(recorded.attempts))
+	for _, attempt := range recorded.attempts {
+		attempts = append(attempts, attempt)
+	}
+	sort.SliceStable(attempts, func(i, j int) bool {
+		return attempts[i].attempt.GetFinishedAt().AsTime().Before(attempts[j].attempt.GetFinishedAt().AsTime())
+	})
+	return attempts
+}
+
+// recordRun remembers a failed run of a CronJob.
+func (r *JobReconciler) recordRun(cronJob *batchv1.CronJob, job *batchv1.Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[types.NamespacedName][]*pb.CronJobRun)
+	}
+	name := client.ObjectKeyFromObject(cronJob)
+	for _, run := range r.runs[name] {
+		if run.JobName == job.Name {
+			return
+		}
+	}
+	runs := append(r.runs[name], newCronJobRun(job))
+	sortRuns(runs)
+	if len(runs) > domain.MaxCronJobRuns {
+		runs = runs[len(runs)-domain.MaxCronJobRuns:]
+	}
+	r.runs[name] = runs
+}
+
+func (r *JobReconciler) forgetRuns(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.runs, name)
+}
+
+// cronJobRuns returns the latest runs of a CronJob, oldest first: the Jobs it still has and the
+// failed runs remembered since.
+func (r *JobReconciler) cronJobRuns(ctx context.Context, cronJob *batchv1.CronJob) ([]*pb.CronJobRun, error) {
+	var jobs batchv1.JobList
+	if err := r.List(ctx, &jobs, client.InNamespace(cronJob.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list jobs of cron job %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	byName := make(map[string]*pb.CronJobRun)
+	r.mu.Lock()
+	for _, run := range r.runs[client.ObjectKeyFromObject(cronJob)] {
+		byName[run.JobName] = run
+	}
+	r.mu.Unlock()
+	for i := range jobs.Items {
+		if metav1.IsControlledBy(&jobs.Items[i], cronJob) {
+			byName[jobs.Items[i].Name] = newCronJobRun(&jobs.Items[i])
+		}
+	}
+
+	runs := make([]*pb.CronJobRun, 0, len(byName))
+	for _, run := range byName {
+		runs = append(runs, run)
+	}
+	sortRuns(runs)
+	if len(runs) > domain.MaxCronJobRuns {
+		runs = runs[len(runs)-domain.MaxCronJobRuns:]
+	}
+	return runs, nil
+}
+
+// sortRuns sorts runs by start time, oldest first. Runs of a CronJob are named after their
+// scheduled time, so the name breaks ties.
+func sortRuns(runs []*pb.CronJobRun) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		ti, tj := runs[i].GetStartTime().AsTime(), runs[j].GetStartTime().AsTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return runs[i].JobName < runs[j].JobName
+	})
+}
+
+func newCronJobRun(job *batchv1.Job) *pb.CronJobRun {
+	run := &pb.CronJobRun{JobName: job.Name, Status: runActive, StartTime: toTimestamp(job.CreationTimestamp)}
+	if job.Status.StartTime != nil {
+		run.StartTime = toTimestamp(*job.Status.StartTime)
+	}
+	if failed := jobFailedCondition(job); failed != nil {
+		run.Status = runFailed
+		run.Reason = failed.Reason
+		run.FinishedAt = toTimestamp(failed.LastTransitionTime)
+	} else if isJobComplete(job) {
+		run.Status = runSucceeded
+		if job.Status.CompletionTime != nil {
+			run.FinishedAt = toTimestamp(*job.Status.CompletionTime)
+		}
+	}
+	return run
+}
+
+// consecutiveFailures counts the latest runs that failed in a row, skipping the active ones.
+// Runs started before the last successful time of the CronJob are not counted, even when their
+// Job was deleted already.
+func consecutiveFailures(cronJob *batchv1.CronJob, runs []*pb.CronJobRun) int32 {
+	var failures int32
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		if run.Status == runActive {
+			continue
+		}
+		if run.Status == runSucceeded {
+			break
+		}
+		if last := cronJob.Status.LastSuccessfulTime; last != nil && !run.StartTime.AsTime().After(last.Time) {
+			break
+		}
+		failures++
+	}
+	return failures
+}
+
+// jobFailedCondition returns the Failed condition of a Job if it is True, or nil.
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+func isJobComplete(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobFailureReason returns ReasonBackoffLimitExceeded or ReasonDeadlineExceeded after the reason of
+// the Failed condition, or ReasonJobFailed for the other reasons, such as a pod failure policy.
+func jobFailureReason(failed *batchv1.JobCondition) string {
+	switch failed.Reason {
+	case domain.ReasonBackoffLimitExceeded, domain.ReasonDeadlineExceeded:
+		return failed.Reason
+	default:
+		return domain.ReasonJobFailed
+	}
+}
+
+// buildIncidentContext describes the failed Job, its attempts and its CronJob. The logs of the
+// attempts are log sections, naming their pods; the pod fields are left empty, as the pods may be
+// gone already.
+func (r *JobReconciler) buildIncidentContext(ctx context.Context, job *batchv1.Job, cronJob *batchv1.CronJob, failed *batchv1.JobCondition, runs []*pb.CronJobRun, attempts []*jobAttempt, failureReason string, settings *policy.Effective) *pb.IncidentContext {
+	log := logf.FromContext(ctx)
+
+	jobDetails := &pb.JobDetails{
+		Name:                  job.Name,
+		ConditionReason:       failed.Reason,
+		ConditionMessage:      failed.Message,
+		FailedAt:              toTimestamp(failed.LastTransitionTime),
+		BackoffLimit:          ptr.Deref(job.Spec.BackoffLimit, 6),
+		ActiveDeadlineSeconds: ptr.Deref(job.Spec.ActiveDeadlineSeconds, 0),
+		Completions:           ptr.Deref(job.Spec.Completions, 1),
+		Parallelism:           ptr.Deref(job.Spec.Parallelism, 1),
+		Succeeded:             job.Status.Succeeded,
+		Failed:                job.Status.Failed,
+	}
+	if job.Status.StartTime != nil {
+		jobDetails.StartTime = toTimestamp(*job.Status.StartTime)
+	}
+	details := &pb.IncidentDetails{
+		OwnerChain: []*pb.OwnerReference{{
+			ApiVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+			Name:       job.Name,
+			Uid:        string(job.UID),
+		}},
+		Settings: newObservationSettings(settings),
+		Job:      jobDetails,
+	}
+	if cronJob != nil {
+		details.OwnerChain = append(details.OwnerChain, &pb.OwnerReference{
+			ApiVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+			Name:       cronJob.Name,
+			Uid:        string(cronJob.UID),
+		})
+		jobDetails.CronJob = &pb.CronJobDetails{
+			Name:                cronJob.Name,
+			Schedule:            cronJob.Spec.Schedule,
+			TimeZone:            ptr.Deref(cronJob.Spec.TimeZone, ""),
+			ConcurrencyPolicy:   string(cronJob.Spec.ConcurrencyPolicy),
+			Suspended:           ptr.Deref(cronJob.Spec.Suspend, false),
+			ConsecutiveFailures: consecutiveFailures(cronJob, runs),
+			RecentRuns:          runs,
+		}
+		if cronJob.Status.LastScheduleTime != nil {
+			jobDetails.CronJob.LastScheduleTime = toTimestamp(*cronJob.Status.LastScheduleTime)
+		}
+		if cronJob.Status.LastSuccessfulTime != nil {
+			jobDetails.CronJob.LastSuccessfulTime = toTimestamp(*cronJob.Status.LastSuccessfulTime)
+		}
+	}
+
+	var logs string
+	for _, attempt := range attempts {
+		jobDetails.Attempts = append(jobDetails.Attempts, attempt.attempt)
+		if attempt.logs != nil {
+			details.LogSections = append(details.LogSections, attempt.logs)
+			logs = attempt.logs.Content
+		}
+	}
+
+	if r.EventCollector != nil && settings.Collects(observerv1alpha1.CollectorEvents) {
+		// CronJobs record the runs they skip or miss.
+		uids := []types.UID{job.UID}
+		if cronJob != nil {
+			uids = append(uids, cronJob.UID)
+		}
+		for _, uid := range uids {
+			events, err := r.EventCollector.GetEvents(ctx, job.Namespace, uid)
+			if err != nil {
+				log.Error(err, "failed to get job events", "job", job.Name)
+				continue
+			}
+			details.Events = append(details.Events, newEvents(events, settings)...)
+		}
+		sort.SliceStable(details.Events, func(i, j int) bool {
+			return details.Events[i].GetLastSeen().AsTime().Before(details.Events[j].GetLastSeen().AsTime())
+		})
+	}
+
+	name := job.Name
+	if cronJob != nil {
+		name = cronJob.Name
+	}
+	incidentID := fmt.Sprintf("%s-%s-%d", name, failureReason, r.now().Unix())
+	if r.ClusterID != "" {
+		incidentID = r.ClusterID + "-" + incidentID
+	}
+	return &pb.IncidentContext{
+		IncidentId:    incidentID,
+		ClusterId:     r.ClusterID,
+		PodNamespace:  job.Namespace,
+		FailureReason: failureReason,
+		Logs:          logs,
+		Timestamp:     timestamppb.New(r.now()),
+		Details:       details,
+	}
+}
+
+// jobTransitions passes Jobs that fail, finish or are deleted, so that their failed attempts are
+// reported or dropped. Running Jobs are enqueued by the failures of their pods.
+func jobTransitions() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			job, ok := e.Object.(*batchv1.Job)
+			return ok && jobFailedCondition(job) != nil
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldJob, okOld := e.ObjectOld.(*batchv1.Job)
+			newJob, okNew := e.ObjectNew.(*batchv1.Job)
+			if !okOld || !okNew {
+				return false
+			}
+			failed := jobFailedCondition(newJob) != nil && jobFailedCondition(oldJob) == nil
+			completed := isJobComplete(newJob) && !isJobComplete(oldJob)
+			return failed || completed
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return true
+		},
+	}
+}
+
+// podJob maps a pod to the Job owning it.
+func podJob(_ context.Context, obj client.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "Job" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: owner.Name}}}
+}
+
+// forgetDeletedCronJobs drops the runs remembered for a CronJob once it is deleted. Its Jobs are
+// deleted with it, so no later run would find it gone.
+func (r *JobReconciler) forgetDeletedCronJobs() handler.EventHandler {
+	return handler.Funcs{
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.forgetRuns(client.ObjectKeyFromObject(e.Object))
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *JobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	observed := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.Config.Observes(obj.GetNamespace())
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, builder.WithPredicates(observed, jobTransitions())).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podJob), builder.WithPredicates(observed, podFailures())).
+		Watches(&batchv1.CronJob{}, r.forgetDeletedCronJobs(), builder.WithPredicates(observed)).
+		Named("job").
+		Complete(r)
+}
@@ -0,0 +1,273 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+func newJob(name string, started time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "reports", UID: types.UID(name + "-uid")},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(1)),
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": name}},
+				Spec:       corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever, Containers: []corev1.Container{{Name: "report"}}},
+			},
+		},
+		Status: batchv1.JobStatus{StartTime: ptr.To(metav1.NewTime(started))},
+	}
+}
+
+func failJob(job *batchv1.Job, reason string, at time.Time) {
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: reason, Message: "Job has reached the specified backoff limit",
+		LastTransitionTime: metav1.NewTime(at),
+	})
+}
+
+func newJobPod(job *batchv1.Job, name string, exitCode int32, finished time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: job.Namespace,
+			Labels:          job.Spec.Template.Labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job"))},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "report",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: domain.ReasonError, ExitCode: exitCode, FinishedAt: metav1.NewTime(finished),
+			}},
+		}}},
+	}
+}
+
+func newJobReconciler(t *testing.T, objects ...client.Object) (*JobReconciler, client.Client, *recordingSink) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	sink := &recordingSink{}
+	return &JobReconciler{
+		Client: c,
+		LogAggregator: &mockLogAggregator{getLogs: func(string) (string, error) {
+			return "export failed: warehouse connection refused\n", nil
+		}},
+		EventCollector: &mockEventCollector{},
+		IncidentCache:  harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute),
+		Sink:           sink,
+		Namespaced:     true,
+		Config:         &config.ControllerConfig{DebounceTTLSeconds: time.Minute, CronJobFailedRuns: 1},
+	}, c, sink
+}
+
+func TestJobReconciler_ReconcileFailedJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	job := newJob("export", now.Add(-time.Minute))
+	first := newJobPod(job, "export-a", 1, now.Add(-40*time.Second))
+	r, c, sink := newJobReconciler(t, job, first)
+	r.Now = func() time.Time { return now }
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "reports", Name: "export"}}
+	assert.Equal(t, []ctrl.Request{req}, podJob(ctx, first))
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, sink.reasons(), "a running Job is not reported")
+
+	// The first pod is gone by the time the Job fails.
+	require.NoError(t, c.Delete(ctx, first))
+	require.NoError(t, c.Create(ctx, newJobPod(job, "export-b", 2, now.Add(-10*time.Second))))
+	failJob(job, domain.ReasonBackoffLimitExceeded, now)
+	job.Status.Failed = 2
+	require.NoError(t, c.Status().Update(ctx, job))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{domain.ReasonBackoffLimitExceeded}, sink.reasons())
+	incident := sink.incidents[0]
+	assert.Equal(t, fmt.Sprintf("export-%s-%d", domain.ReasonBackoffLimitExceeded, now.Unix()), incident.IncidentId)
+	assert.True(t, now.Equal(incident.Timestamp.AsTime()), "the incident is stamped with the reconciler's clock")
+	assert.Equal(t, "reports", incident.PodNamespace)
+	assert.Empty(t, incident.PodName)
+	assert.Equal(t, "Job", incident.Details.OwnerChain[0].Kind)
+	details := incident.Details.Job
+	require.NotNil(t, details)
+	assert.Equal(t, domain.ReasonBackoffLimitExceeded, details.ConditionReason)
+	assert.Equal(t, int32(1), details.BackoffLimit)
+	assert.Equal(t, int32(2), details.Failed)
+	assert.Nil(t, details.CronJob)
+	require.Len(t, details.Attempts, 2)
+	assert.Equal(t, "export-a", details.Attempts[0].PodName)
+	assert.Equal(t, int32(1), details.Attempts[0].ExitCode)
+	assert.Equal(t, "node-a", details.Attempts[0].NodeName)
+	assert.Equal(t, "export-b", details.Attempts[1].PodName)
+	require.Len(t, incident.Details.LogSections, 2)
+	assert.Equal(t, "export-a", incident.Details.LogSections[0].Pod, "logs of a deleted pod are kept")
+	assert.Equal(t, "export-b", incident.Details.LogSections[1].Pod)
+	assert.Contains(t, incident.Logs, "connection refused", "the legacy logs are those of the last attempt")
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Len(t, sink.reasons(), 1, "a failed Job is reported once")
+	assert.Empty(t, r.attempts, "attempts are dropped once the Job is reported")
+}
+
+func TestPodReconciler_LeavesJobPodsToJobReconciler(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	job := newJob("export", now.Add(-time.Minute))
+	failJob(job, domain.ReasonBackoffLimitExceeded, now)
+	pod := newJobPod(job, "export-a", 1, now.Add(-10*time.Second))
+	r, c, sink := newJobReconciler(t, job, pod)
+	pods := &PodReconciler{
+		Client:        c,
+		IncidentCache: r.IncidentCache,
+		Sink:          sink,
+		Namespaced:    true,
+		JobIncidents:  true,
+		Config:        r.Config,
+	}
+
+	_, err := pods.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "reports", Name: "export-a"}})
+	require.NoError(t, err)
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "reports", Name: "export"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.ReasonBackoffLimitExceeded}, sink.reasons(), "a failed attempt is only reported with its Job")
+}
+
+func TestJobReconciler_ReconcileCronJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nightly", Namespace: "reports", UID: "nightly-uid",
+			Annotations: map[string]string{policy.AnnotationCronJobFailedRuns: "2"},
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "0 2 * * *", ConcurrencyPolicy: batchv1.ForbidConcurrent},
+		Status: batchv1.CronJobStatus{
+			LastScheduleTime:   ptr.To(metav1.NewTime(now.Add(-time.Minute))),
+			LastSuccessfulTime: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+		},
+	}
+	run := func(name string, started time.Time) *batchv1.Job {
+		job := newJob(name, started)
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))}
+		return job
+	}
+	succeeded := run("nightly-1", now.Add(-2*time.Hour))
+	succeeded.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	firstFailure := run("nightly-2", now.Add(-30*time.Minute))
+	failJob(firstFailure, domain.ReasonDeadlineExceeded, now.Add(-5*time.Minute))
+	r, c, sink := newJobReconciler(t, cronJob, succeeded, firstFailure)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "reports", Name: "nightly-2"}})
+	require.NoError(t, err)
+	assert.Empty(t, sink.reasons(), "one failed run is below the CronJob's threshold")
+
+	// The CronJob deletes the failed run beyond its history limit.
+	require.NoError(t, c.Delete(ctx, firstFailure))
+	secondFailure := run("nightly-3", now.Add(-time.Minute))
+	failJob(secondFailure, domain.ReasonBackoffLimitExceeded, now)
+	require.NoError(t, c.Create(ctx, secondFailure))
+	require.NoError(t, c.Create(ctx, newJobPod(secondFailure, "nightly-3-a", 1, now)))
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "reports", Name: "nightly-3"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{domain.ReasonCronJobFailing}, sink.reasons())
+	incident := sink.incidents[0]
+	assert.Equal(t, []string{"Job", "CronJob"}, []string{incident.Details.OwnerChain[0].Kind, incident.Details.OwnerChain[1].Kind})
+	details := incident.Details.Job
+	require.NotNil(t, details)
+	assert.Equal(t, "nightly-3", details.Name)
+	require.Len(t, details.Attempts, 1)
+	cron := details.CronJob
+	require.NotNil(t, cron)
+	assert.Equal(t, "0 2 * * *", cron.Schedule)
+	assert.Equal(t, "Forbid", cron.ConcurrencyPolicy)
+	assert.Equal(t, int32(2), cron.ConsecutiveFailures)
+	runs := make([][]string, 0, len(cron.RecentRuns))
+	for _, run := range cron.RecentRuns {
+		runs = append(runs, []string{run.JobName, run.Status, run.Reason})
+	}
+	assert.Equal(t, [][]string{
+		{"nightly-1", "Succeeded", ""},
+		{"nightly-2", "Failed", domain.ReasonDeadlineExceeded},
+		{"nightly-3", "Failed", domain.ReasonBackoffLimitExceeded},
+	}, runs)
+
+	require.NotEmpty(t, r.runs)
+	r.forgetDeletedCronJobs().Delete(ctx, event.DeleteEvent{Object: cronJob}, nil)
+	assert.Empty(t, r.runs, "the runs of a deleted CronJob are dropped with it")
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	newRun := func(status string, ago time.Duration) *pb.CronJobRun {
+		return &pb.CronJobRun{Status: status, StartTime: timestamppb.New(now.Add(-ago))}
+	}
+	cronJob := &batchv1.CronJob{Status: batchv1.CronJobStatus{LastSuccessfulTime: ptr.To(metav1.NewTime(now.Add(-3 * time.Hour)))}}
+
+	testCases := []struct {
+		name     string
+		runs     []*pb.CronJobRun
+		expected int32
+	}{
+		{
+			name:     "failures since a success",
+			runs:     []*pb.CronJobRun{newRun(runFailed, 5*time.Hour), newRun(runSucceeded, 2*time.Hour), newRun(runFailed, time.Hour), newRun(runFailed, 0)},
+			expected: 2,
+		},
+		{
+			name:     "active run skipped",
+			runs:     []*pb.CronJobRun{newRun(runFailed, time.Hour), newRun(runActive, 0)},
+			expected: 1,
+		},
+		{
+			name:     "deleted success",
+			runs:     []*pb.CronJobRun{newRun(runFailed, 4*time.Hour), newRun(runFailed, time.Hour)},
+			expected: 1,
+		},
+		{name: "no runs"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, consecutiveFailures(cronJob, tc.runs))
+		})
+	}
+}
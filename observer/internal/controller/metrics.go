@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// podEvents counts Pod watch events by whether they were enqueued for reconciliation. Filtered
+// events are the reconciles saved by the failure-transition predicate; compare with
+// controller_runtime_reconcile_total{controller="pod"}.
+var podEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kubemind_observer_pod_events_total",
+	Help: "Number of Pod watch events, by event type and whether they were enqueued or filtered.",
+}, []string{"event", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(podEvents)
+}
+
+// countPodEvent records the predicate's decision on a Pod event and returns it.
+func countPodEvent(eventType string, enqueue bool) bool {
+	result := "filtered"
+	if enqueue {
+		result = "enqueued"
+	}
+	podEvents.WithLabelValues(eventType, result).Inc()
+	return enqueue
+}
@@ -0,0 +1,392 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/shard"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+// Failure reasons of node incidents besides the pressure condition types.
+const (
+	ReasonNodeNotReady     = "NodeNotReady"
+	ReasonNodeRebooted     = "NodeRebooted"
+	ReasonKubeletRestarted = "KubeletRestarted"
+)
+
+// kubeletStartWindow is how recent a kubelet "Starting" event must be to count as a restart, so
+// that the events listed when the Observer starts are not reported.
+const kubeletStartWindow = 2 * time.Minute
+
+// pressureConditions are the node conditions that are a problem when True.
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// NodeReconciler reports node problems that pod incidents are often symptoms of: a node turning
+// NotReady or under pressure, rebooting, or restarting its kubelet. Pod incidents carry their
+// node name, so the Brain can correlate them with the node incident.
+type NodeReconciler struct {
+	client.Client
+	// APIReader lists the pods of a node from the API server, as the cache may only hold the
+	// observed namespaces.
+	APIReader      client.Reader
+	EventCollector harvester.EventCollector
+	IncidentCache  harvester.IntelligenceCache
+	Sink           sink.Sink
+	// Silences, if set, keeps incidents matched by an active Silence from being sent.
+	Silences *silence.Checker
+	// Shards, if set, restricts this replica to the nodes it owns in its shard group.
+	Shards *shard.Sharder
+	// ClusterID identifies the cluster the nodes belong to, for Observers watching several clusters.
+	ClusterID string
+	Config    *config.ControllerConfig
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+
+	// reboots holds the nodes whose boot ID changed until their reboot is reported.
+	reboots sync.Map
+}
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// The kubelet "Starting" events are watched to detect kubelet restarts.
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list;watch
+
+// Reconcile reports the problems of one node that are not debounced.
+func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	if r.Shards != nil && !r.Shards.Owns("Node/"+req.Name) {
+		return ctrl.Result{}, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.reboots.Delete(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	reasons := nodeProblems(node)
+	if _, rebooted := r.reboots.LoadAndDelete(node.Name); rebooted {
+		reasons = append(reasons, ReasonNodeRebooted)
+	}
+	restarted, err := r.kubeletRestarted(ctx, node)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if restarted {
+		reasons = append(reasons, ReasonKubeletRestarted)
+	}
+
+	settings := policy.Defaults(r.Config)
+	for _, reason := range reasons {
+		key := fmt.Sprintf("node/%s/%s", node.Name, reason)
+		err := r.reporter().report(ctx, key, settings, func() (*pb.IncidentContext, error) {
+			log.Info("Node entered incident state", "node", node.Name, "reason", reason)
+			return r.buildIncidentContext(ctx, node, reason)
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *NodeReconciler) reporter() incidentReporter {
+	return incidentReporter{
+		IncidentCache:    r.IncidentCache,
+		Sink:             r.Sink,
+		Silences:         r.Silences,
+		MaxIncidentBytes: r.Config.MaxIncidentBytes,
+		Now:              r.now,
+	}
+}
+
+func (r *NodeReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// kubeletRestarted reports whether the kubelet of the node started recently.
+func (r *NodeReconciler) kubeletRestarted(ctx context.Context, node *corev1.Node) (bool, error) {
+	var events corev1.EventList
+	if err := r.List(ctx, &events, client.MatchingFields{"involvedObject.name": node.Name}); err != nil {
+		return false, fmt.Errorf("failed to list kubelet events of node %s: %w", node.Name, err)
+	}
+	for i := range events.Items {
+		if isKubeletStart(&events.Items[i]) && r.now().Sub(eventLastSeen(&events.Items[i])) < kubeletStartWindow {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// buildIncidentContext harvests the status, resources, taints, pods and events of a node.
+func (r *NodeReconciler) buildIncidentContext(ctx context.Context, node *corev1.Node, reason string) (*pb.IncidentContext, error) {
+	details, err := r.nodeDetails(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	incidentID := fmt.Sprintf("node-%s-%s-%d", node.Name, reason, r.now().Unix())
+	if r.ClusterID != "" {
+		incidentID = r.ClusterID + "-" + incidentID
+	}
+	return &pb.IncidentContext{
+		IncidentId:    incidentID,
+		ClusterId:     r.ClusterID,
+		FailureReason: reason,
+		Timestamp:     timestamppb.New(r.now()),
+		Node:          details,
+	}, nil
+}
+
+func (r *NodeReconciler) nodeDetails(ctx context.Context, node *corev1.Node) (*pb.NodeDetails, error) {
+	details := &pb.NodeDetails{
+		Name:           node.Name,
+		Capacity:       resourceListToMap(node.Status.Capacity),
+		Allocatable:    resourceListToMap(node.Status.Allocatable),
+		Unschedulable:  node.Spec.Unschedulable,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		BootId:         node.Status.NodeInfo.BootID,
+	}
+	for _, condition := range node.Status.Conditions {
+		details.Conditions = append(details.Conditions, newNodeCondition(condition))
+	}
+	for _, taint := range node.Spec.Taints {
+		details.Taints = append(details.Taints, &pb.Taint{Key: taint.Key, Value: taint.Value, Effect: string(taint.Effect)})
+	}
+
+	var pods corev1.PodList
+	if err := r.APIReader.List(ctx, &pods, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of node %s: %w", node.Name, err)
+	}
+	requested := corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		addResources(requested, podRequests(pod))
+		details.AffectedPods = append(details.AffectedPods, newAffectedPod(pod))
+	}
+	details.Requested = resourceListToMap(requested)
+
+	// Node events are recorded against the node UID by the node controller and against the
+	// node name by the kubelet.
+	for _, uid := range []types.UID{node.UID, types.UID(node.Name)} {
+		events, err := r.EventCollector.GetEvents(ctx, "", uid)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "failed to get node events", "node", node.Name)
+			continue
+		}
+		// No ObserverPolicy selects nodes, so no redaction rules apply to their events.
+		details.Events = append(details.Events, newEvents(events, policy.Defaults(r.Config))...)
+	}
+	sort.SliceStable(details.Events, func(i, j int) bool {
+		return details.Events[i].GetLastSeen().AsTime().Before(details.Events[j].GetLastSeen().AsTime())
+	})
+	return details, nil
+}
+
+func newNodeCondition(condition corev1.NodeCondition) *pb.NodeCondition {
+	return &pb.NodeCondition{
+		Type:               string(condition.Type),
+		Status:             string(condition.Status),
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: toTimestamp(condition.LastTransitionTime),
+	}
+}
+
+func newAffectedPod(pod *corev1.Pod) *pb.AffectedPod {
+	return &pb.AffectedPod{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Phase:     string(pod.Status.Phase),
+		Ready:     isPodReady(pod),
+		Workload:  podWorkload(pod),
+		QosClass:  string(pod.Status.QOSClass),
+	}
+}
+
+// podWorkload names the controller of a pod as Kind/name, without resolving the owner chain.
+func podWorkload(pod *corev1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller {
+			return owner.Kind + "/" + owner.Name
+		}
+	}
+	return "Pod/" + pod.Name
+}
+
+// podRequests returns the resources the scheduler accounts a pod for: the larger of its app
+// containers' and each init container's requests, plus its overhead.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResources(requests, pod.Spec.Overhead)
+	return requests
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// nodeProblems returns the failure reasons of the node's current conditions.
+func nodeProblems(node *corev1.Node) []string {
+	var reasons []string
+	if status := nodeConditionStatus(node, corev1.NodeReady); status != "" && status != corev1.ConditionTrue {
+		reasons = append(reasons, ReasonNodeNotReady)
+	}
+	for _, conditionType := range pressureConditions {
+		if nodeConditionStatus(node, conditionType) == corev1.ConditionTrue {
+			reasons = append(reasons, string(conditionType))
+		}
+	}
+	return reasons
+}
+
+func nodeConditionStatus(node *corev1.Node, conditionType corev1.NodeConditionType) corev1.ConditionStatus {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return ""
+}
+
+// nodeTransitions passes new nodes with problems and updates that add a problem or reboot the
+// node, but not the periodic status updates of healthy or already reported nodes. Reboots are
+// only visible in the update, so they are remembered for Reconcile.
+func (r *NodeReconciler) nodeTransitions() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			node, ok := e.Object.(*corev1.Node)
+			return ok && len(nodeProblems(node)) > 0
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+			if oldNode.Status.NodeInfo.BootID != "" && oldNode.Status.NodeInfo.BootID != newNode.Status.NodeInfo.BootID {
+				r.reboots.Store(newNode.Name, struct{}{})
+				return true
+			}
+			previous := map[string]bool{}
+			for _, reason := range nodeProblems(oldNode) {
+				previous[reason] = true
+			}
+			for _, reason := range nodeProblems(newNode) {
+				if !previous[reason] {
+					return true
+				}
+			}
+			return false
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return true
+		},
+	}
+}
+
+func isKubeletStart(e *corev1.Event) bool {
+	return e.InvolvedObject.Kind == "Node" && e.Reason == "Starting" && e.Source.Component == "kubelet"
+}
+
+func eventLastSeen(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// WatchKubeletStarts restricts the cache of Events to the kubelet "Starting" events of nodes in
+// every namespace, so that the Observer does not cache the events of the whole cluster.
+func WatchKubeletStarts(opts *cache.Options) {
+	if opts.ByObject == nil {
+		opts.ByObject = map[client.Object]cache.ByObject{}
+	}
+	opts.ByObject[&corev1.Event{}] = cache.ByObject{
+		Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+		Field: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "Node",
+			"reason":              "Starting",
+			"source":              "kubelet",
+		}),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager. The cache must be restricted with
+// WatchKubeletStarts.
+func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Event{}, "involvedObject.name", func(obj client.Object) []string {
+		return []string{obj.(*corev1.Event).InvolvedObject.Name}
+	}); err != nil {
+		return fmt.Errorf("failed to index events by involved object: %w", err)
+	}
+	recentKubeletStart := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		e, ok := obj.(*corev1.Event)
+		return ok && isKubeletStart(e) && r.now().Sub(eventLastSeen(e)) < kubeletStartWindow
+	})
+	toNode := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.(*corev1.Event).InvolvedObject.Name}}}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}, builder.WithPredicates(r.nodeTransitions())).
+		Watches(&corev1.Event{}, toNode, builder.WithPredicates(recentKubeletStart)).
+		Named("node").
+		Complete(r)
+}
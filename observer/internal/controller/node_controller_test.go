@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/harvester"
+	pb "kube-mind/observer/proto"
+)
+
+// recordingSink is a sink.Sink keeping the incidents it was sent.
+type recordingSink struct {
+	mu        sync.Mutex
+	incidents []*pb.IncidentContext
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, incident *pb.IncidentContext) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.incidents = append(s.incidents, incident)
+	return nil
+}
+
+func (s *recordingSink) reasons() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var reasons []string
+	for _, incident := range s.incidents {
+		reasons = append(reasons, incident.FailureReason)
+	}
+	return reasons
+}
+
+// mockEventCollector is a harvester.EventCollector backed by a function.
+type mockEventCollector struct {
+	getEvents func(namespace string, uid types.UID) []corev1.Event
+}
+
+func (m *mockEventCollector) GetEvents(_ context.Context, namespace string, uid types.UID) ([]corev1.Event, error) {
+	if m.getEvents == nil {
+		return nil, nil
+	}
+	return m.getEvents(namespace, uid), nil
+}
+
+func newNode(name, bootID string, conditions ...corev1.NodeCondition) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid")},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "node.kubernetes.io/memory-pressure", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: conditions,
+			Capacity:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+			NodeInfo:   corev1.NodeSystemInfo{BootID: bootID, KubeletVersion: "v1.35.0"},
+		},
+	}
+}
+
+func nodeCondition(conditionType corev1.NodeConditionType, status corev1.ConditionStatus) corev1.NodeCondition {
+	return corev1.NodeCondition{Type: conditionType, Status: status, Reason: "KubeletHasInsufficientMemory"}
+}
+
+func TestNodeTransitions(t *testing.T) {
+	t.Parallel()
+
+	healthy := newNode("node-1", "boot-1", nodeCondition(corev1.NodeReady, corev1.ConditionTrue))
+	pressure := newNode("node-1", "boot-1",
+		nodeCondition(corev1.NodeReady, corev1.ConditionTrue), nodeCondition(corev1.NodeMemoryPressure, corev1.ConditionTrue))
+	notReady := newNode("node-1", "boot-1", nodeCondition(corev1.NodeReady, corev1.ConditionUnknown))
+	rebooted := newNode("node-1", "boot-2", nodeCondition(corev1.NodeReady, corev1.ConditionTrue))
+
+	testCases := []struct {
+		name     string
+		oldNode  *corev1.Node
+		newNode  *corev1.Node
+		expected bool
+	}{
+		{name: "healthy heartbeat", oldNode: healthy, newNode: healthy, expected: false},
+		{name: "memory pressure", oldNode: healthy, newNode: pressure, expected: true},
+		{name: "pressure persists", oldNode: pressure, newNode: pressure, expected: false},
+		{name: "pressure relieved", oldNode: pressure, newNode: healthy, expected: false},
+		{name: "not ready", oldNode: pressure, newNode: notReady, expected: true},
+		{name: "rebooted", oldNode: healthy, newNode: rebooted, expected: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := &NodeReconciler{}
+			assert.Equal(t, tc.expected, r.nodeTransitions().Update(event.UpdateEvent{ObjectOld: tc.oldNode, ObjectNew: tc.newNode}))
+		})
+	}
+
+	r := &NodeReconciler{}
+	assert.True(t, r.nodeTransitions().Create(event.CreateEvent{Object: notReady}))
+	assert.False(t, r.nodeTransitions().Create(event.CreateEvent{Object: healthy}))
+}
+
+func TestNodeReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := newNode("node-1", "boot-2",
+		nodeCondition(corev1.NodeReady, corev1.ConditionTrue), nodeCondition(corev1.NodeMemoryPressure, corev1.ConditionTrue))
+	controller := true
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "api-7d9f-abcde", Namespace: "payments",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-7d9f", Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}}},
+			InitContainers: []corev1.Container{{Name: "migrate", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, QOSClass: corev1.PodQOSBurstable},
+	}
+	completed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	elsewhere := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "payments"},
+		Spec:       corev1.PodSpec{NodeName: "node-2"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	kubeletStart := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "node-1.start", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"},
+		Reason:         "Starting",
+		Source:         corev1.EventSource{Component: "kubelet"},
+		LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node, running, completed, elsewhere, kubeletStart).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithIndex(&corev1.Event{}, "involvedObject.name", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Event).InvolvedObject.Name}
+		}).
+		Build()
+
+	sink := &recordingSink{}
+	r := &NodeReconciler{
+		Client:    c,
+		APIReader: c,
+		EventCollector: &mockEventCollector{getEvents: func(_ string, uid types.UID) []corev1.Event {
+			if uid != "node-1" {
+				return nil
+			}
+			return []corev1.Event{{Reason: "EvictionThresholdMet", LastTimestamp: metav1.NewTime(now)}}
+		}},
+		IncidentCache: harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute),
+		Sink:          sink,
+		ClusterID:     "prod-eu",
+		Config:        &config.ControllerConfig{DebounceTTLSeconds: time.Minute},
+		Now:           func() time.Time { return now },
+	}
+	// The reboot is seen by the watch predicate before the node is reconciled.
+	r.nodeTransitions().Update(event.UpdateEvent{ObjectOld: newNode("node-1", "boot-1"), ObjectNew: node})
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-1"}}
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MemoryPressure", ReasonNodeRebooted, ReasonKubeletRestarted}, sink.reasons())
+
+	incident := sink.incidents[0]
+	assert.Equal(t, "prod-eu-node-node-1-MemoryPressure-1792497600", incident.IncidentId)
+	assert.Equal(t, "prod-eu", incident.ClusterId)
+	assert.Empty(t, incident.PodNamespace)
+	assert.Equal(t, "Node/node-1", pb.Workload(incident))
+	details := incident.Node
+	assert.Equal(t, "node-1", details.Name)
+	assert.Equal(t, "v1.35.0", details.KubeletVersion)
+	assert.Equal(t, map[string]string{"memory": "8Gi"}, details.Capacity)
+	assert.Equal(t, map[string]string{"memory": "2Gi"}, details.Requested, "init containers count when they request more")
+	require.Len(t, details.Conditions, 2)
+	assert.Equal(t, "MemoryPressure", details.Conditions[1].Type)
+	assert.Equal(t, []*pb.Taint{{Key: "node.kubernetes.io/memory-pressure", Effect: "NoSchedule"}}, details.Taints)
+	require.Len(t, details.AffectedPods, 1, "completed pods and pods of other nodes are not affected")
+	assert.Equal(t, &pb.AffectedPod{
+		Namespace: "payments", Name: "api-7d9f-abcde", Phase: "Running", Workload: "ReplicaSet/api-7d9f", QosClass: "Burstable",
+	}, details.AffectedPods[0])
+	require.Len(t, details.Events, 1)
+	assert.Equal(t, "EvictionThresholdMet", details.Events[0].Reason)
+
+	// Reconciling again reports nothing new within the debounce window.
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Len(t, sink.reasons(), 3)
+}
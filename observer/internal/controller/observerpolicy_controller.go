@@ -0,0 +1,186 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// coverageResyncPeriod is how often coverage is recomputed as pods come and go.
+	coverageResyncPeriod = 5 * time.Minute
+	// maxListedWorkloads bounds the workloads listed in a policy's status.
+	maxListedWorkloads = 100
+)
+
+// ObserverPolicyReconciler validates ObserverPolicies and reports the workloads each of them
+// applies to once precedence between overlapping policies is taken into account.
+type ObserverPolicyReconciler struct {
+	client.Client
+	// Sinks reports whether a policy may target a sink name; nil skips the check.
+	Sinks func(name string) bool
+}
+
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=observerpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=observerpolicies/status,verbs=get;update
+
+// Reconcile updates the validity and coverage of one policy.
+func (r *ObserverPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &observerv1alpha1.ObserverPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	condition := metav1.Condition{
+		Type:               observerv1alpha1.ObserverPolicyValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            "The policy is applied to the pods it selects",
+		ObservedGeneration: obj.Generation,
+	}
+	if err := r.validate(obj); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = err.Error()
+	}
+
+	pods, workloads, err := r.coverage(ctx, obj.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	obj.Status.ObservedGeneration = obj.Generation
+	obj.Status.CoveredPods = int32(pods)
+	obj.Status.CoveredWorkloads = int32(len(workloads))
+	if len(workloads) > maxListedWorkloads {
+		workloads = workloads[:maxListedWorkloads]
+	}
+	obj.Status.Workloads = workloads
+	meta.SetStatusCondition(&obj.Status.Conditions, condition)
+	if err := r.Status().Update(ctx, obj); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update observer policy %s status: %w", obj.Name, err)
+	}
+	return ctrl.Result{RequeueAfter: coverageResyncPeriod}, nil
+}
+
+// validate checks what the API server's schema cannot: selectors, patterns and sink names.
+func (r *ObserverPolicyReconciler) validate(obj *observerv1alpha1.ObserverPolicy) error {
+	for _, selector := range []*metav1.LabelSelector{obj.Spec.NamespaceSelector, obj.Spec.Selector} {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+	if _, err := policy.Compile(obj, &policy.Effective{}); err != nil {
+		return err
+	}
+	if r.Sinks != nil {
+		for _, name := range obj.Spec.Sinks {
+			if !r.Sinks(name) {
+				return fmt.Errorf("unknown sink %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// coverage returns the number of pods the named policy applies to and their sorted workloads.
+func (r *ObserverPolicyReconciler) coverage(ctx context.Context, name string) (int, []string, error) {
+	var policies observerv1alpha1.ObserverPolicyList
+	if err := r.List(ctx, &policies); err != nil {
+		return 0, nil, fmt.Errorf("failed to list observer policies: %w", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces); err != nil {
+		return 0, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	namespaceLabels := make(map[string]map[string]string, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		namespaceLabels[ns.Name] = ns.Labels
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return 0, nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Invalid policies are reported by their Valid condition rather than once per pod.
+	selectCtx := logf.IntoContext(ctx, logr.Discard())
+	covered := 0
+	workloads := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		selected := policy.Select(selectCtx, policies.Items, pod, namespaceLabels[pod.Namespace])
+		if selected == nil || selected.Name != name {
+			continue
+		}
+		covered++
+		workload := pb.Workload(&pb.IncidentContext{
+			PodName: pod.Name,
+			Details: &pb.IncidentDetails{OwnerChain: newOwnerChain(ownerChain(ctx, r.Client, pod))},
+		})
+		workloads[pod.Namespace+"/"+workload] = true
+	}
+
+	sorted := make([]string, 0, len(workloads))
+	for workload := range workloads {
+		sorted = append(sorted, workload)
+	}
+	sort.Strings(sorted)
+	return covered, sorted, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Any change to a policy's spec
+// requeues every policy, as precedence makes their coverage depend on each other.
+func (r *ObserverPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Watches(&observerv1alpha1.ObserverPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.allPolicies),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("observerpolicy").
+		Complete(r)
+}
+
+func (r *ObserverPolicyReconciler) allPolicies(ctx context.Context, _ client.Object) []reconcile.Request {
+	var policies observerv1alpha1.ObserverPolicyList
+	if err := r.List(ctx, &policies); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name}})
+	}
+	return requests
+}
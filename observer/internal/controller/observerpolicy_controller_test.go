@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+)
+
+func TestObserverPolicyReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+
+	pod := func(name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", Labels: labels}}
+	}
+	payments := &observerv1alpha1.ObserverPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec:       observerv1alpha1.ObserverPolicySpec{Namespaces: []string{"payments"}},
+	}
+	api := &observerv1alpha1.ObserverPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: observerv1alpha1.ObserverPolicySpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Sinks:    []string{"missing"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&observerv1alpha1.ObserverPolicy{}).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+			pod("api-1", map[string]string{"app": "api"}),
+			pod("worker-1", map[string]string{"app": "worker"}),
+			pod("worker-2", map[string]string{"app": "worker"}),
+			payments, api,
+		).Build()
+	r := &ObserverPolicyReconciler{Client: c, Sinks: func(name string) bool { return name == "brain" }}
+
+	for _, name := range []string{"payments", "api"} {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "payments"}, payments))
+	assert.Equal(t, int32(2), payments.Status.CoveredPods, "pods selected by a more specific policy are not covered")
+	assert.Equal(t, []string{"payments/Pod/worker-1", "payments/Pod/worker-2"}, payments.Status.Workloads)
+	assert.True(t, meta.IsStatusConditionTrue(payments.Status.Conditions, observerv1alpha1.ObserverPolicyValid))
+
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "api"}, api))
+	assert.Equal(t, int32(1), api.Status.CoveredWorkloads)
+	valid := meta.FindStatusCondition(api.Status.Conditions, observerv1alpha1.ObserverPolicyValid)
+	require.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Contains(t, valid.Message, `unknown sink "missing"`)
+}
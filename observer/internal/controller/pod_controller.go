@@ -21,19 +21,27 @@ import (
 	"fmt"
 	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"kube-mind/observer/internal/comms"
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
 	"kube-mind/observer/internal/config"
 	"kube-mind/observer/internal/domain"
 	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/incident"
+	"kube-mind/observer/internal/policy"
+	"kube-mind/observer/internal/shard"
+	"kube-mind/observer/internal/silence"
+	"kube-mind/observer/internal/sink"
 	pb "kube-mind/observer/proto"
 )
 
@@ -42,15 +50,54 @@ type PodReconciler struct {
 	client.Client
 	Scheme         *runtime.Scheme
 	LogAggregator  harvester.LogAggregator
+	EventCollector harvester.EventCollector
 	ManifestParser *harvester.ManifestParser
 	IncidentCache  harvester.IntelligenceCache
-	GrpcClient     comms.GrpcClient
-	Config         *config.ControllerConfig
+	Sink           sink.Sink
+	// Incidents, if set, records every incident as an Incident resource.
+	Incidents *incident.Store
+	// Policies, if set, applies ObserverPolicies; otherwise the global settings apply to every pod.
+	Policies *policy.Resolver
+	// Silences, if set, keeps incidents matched by an active Silence from being sent.
+	Silences *silence.Checker
+	// Namespaced is set when the Observer only has Roles in the watched namespaces, so that
+	// cluster-scoped objects such as Namespaces are not read.
+	Namespaced bool
+	// Shards, if set, restricts this replica to the pods it owns in its shard group.
+	Shards *shard.Sharder
+	// ClusterID identifies the cluster the pods run in, for Observers watching several clusters.
+	ClusterID string
+	// APIReader, if set, reads nodes and pods from the API server to summarize the capacity
+	// available to unschedulable pods and the conditions of nodes evicting pods.
+	APIReader client.Reader
+	Config    *config.ControllerConfig
+	// JobIncidents is set when a JobReconciler reports failed Jobs, so that the failed attempts of
+	// their pods are not reported a second time.
+	JobIncidents bool
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
 }
 
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=core,resources=pods/finalizers,verbs=update
+// The Observer only reads workloads; the resources it writes are its own Incidents and the
+// status of its Silences.
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// Logs and previous logs collectors.
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// Events collector, which lists the events of a failing pod without caching them.
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list
+// Owner chain, cached as metadata only, and the Deployment manifest of the manifests collector.
+// +kubebuilder:rbac:groups=apps,resources=replicasets;deployments;statefulsets;daemonsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=incidents,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=incidents/status,verbs=get;update
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=silences,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=silences/status,verbs=get;update
+// ObserverPolicies and namespace annotations, in cluster-scoped deployments only.
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=observerpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// Node capacity available to unschedulable pods and conditions of nodes evicting pods, in
+// cluster-scoped deployments only.
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -61,106 +108,392 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// Ownership may have moved since the request was queued.
+	if r.Shards != nil && !r.Shards.OwnsPod(pod) {
+		return ctrl.Result{}, nil
+	}
 
+	settings, err := r.settingsFor(ctx, pod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if settings.Ignore {
+		return ctrl.Result{}, nil
+	}
+	// The containers of evicted and preempted pods were killed, so their states are not failures.
+	if reason := disruptionReason(pod); reason != "" {
+		wait, err := r.reconcileDisruption(ctx, pod, reason, settings, r.now())
+		return ctrl.Result{RequeueAfter: wait}, err
+	}
+
+	failing := false
+	var result ctrl.Result
+	now := r.now()
+	// The failed attempts of a Job's pods are reported once, as the failure of the Job.
+	leftToJob := r.JobIncidents && len(podJob(ctx, pod)) > 0
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		var failureReason string
-		if containerStatus.State.Waiting != nil && isIncidentReason(containerStatus.State.Waiting.Reason) {
-			failureReason = containerStatus.State.Waiting.Reason
-		} else if containerStatus.State.Terminated != nil && isIncidentReason(containerStatus.State.Terminated.Reason) {
-			failureReason = containerStatus.State.Terminated.Reason
+		if !leftToJob {
+			failureReason = incidentReason(containerStatus, settings)
+		}
+		incidentKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, containerStatus.Name)
+		if failureReason == "" {
+			var wait time.Duration
+			failureReason, wait = notReadyReason(pod, containerStatus, settings, now)
+			result.RequeueAfter = sooner(result.RequeueAfter, wait)
+			// Probe failures are debounced apart from the crashes of the same container.
+			incidentKey += "/" + failureReason
 		}
-
 		if failureReason != "" {
+			failing = true
 			log.Info("Pod entered incident state", "pod", pod.Name, "namespace", pod.Namespace, "container", containerStatus.Name, "reason", failureReason)
 
-			incidentKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, containerStatus.Name)
-			if _, found := r.IncidentCache.Get(incidentKey); found {
-				log.Info("Incident debounced", "key", incidentKey)
-				return ctrl.Result{}, nil
+			if err := r.report(ctx, pod, incidentKey, containerStatus.Name, failureReason, settings); err != nil {
+				return ctrl.Result{}, err
 			}
+		}
+	}
 
+	if failureReason, wait := pendingReason(pod, settings, now); failureReason != "" {
+		failing = true
+		log.Info("Pod entered incident state", "pod", pod.Name, "namespace", pod.Namespace, "reason", failureReason)
 
-			r.IncidentCache.AddOrUpdate(incidentKey, true, r.Config.DebounceTTLSeconds)
+		// Container names are lowercase, so the reason cannot clash with a container's key.
+		incidentKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, failureReason)
+		if err := r.report(ctx, pod, incidentKey, "", failureReason, settings); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		result.RequeueAfter = sooner(result.RequeueAfter, wait)
+	}
 
-			logs, err := r.LogAggregator.GetLogs(ctx, pod.Namespace, pod.Name, containerStatus.Name, domain.DefaultLogTailLines)
-			if err != nil {
-				log.Error(err, "failed to get pod logs", "pod", pod.Name, "container", containerStatus.Name)
+	if r.Incidents != nil && !failing && isPodReady(pod) {
+		siblingFailing, err := r.workloadFailing(ctx, pod, settings)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !siblingFailing {
+			workload := func() string { return r.workloadOf(ctx, pod) }
+			if err := r.Incidents.Resolve(ctx, pod.Namespace, workload); err != nil {
 				return ctrl.Result{}, err
 			}
+		}
+	}
 
-			podManifest, err := r.ManifestParser.GetAndRedactPodManifest(ctx, pod.Namespace, pod.Name)
-			if err != nil {
-				log.Error(err, "failed to get and redact pod manifest", "pod", pod.Name)
-				return ctrl.Result{}, err
-			}
+	return result, nil
+}
 
+func (r *PodReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
 
-			var deploymentManifest string
-			for _, ownerRef := range pod.OwnerReferences {
-				if ownerRef.Kind == "ReplicaSet" {
-					replicaset := &appsv1.ReplicaSet{}
-					if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ownerRef.Name}, replicaset); err != nil {
-						log.Error(err, "failed to get ReplicaSet", "name", ownerRef.Name)
-						continue
-					}
-					for _, rsOwnerRef := range replicaset.OwnerReferences {
-						if rsOwnerRef.Kind == "Deployment" {
-							deploymentManifest, err = r.ManifestParser.Fetcher.GetDeploymentManifest(ctx, pod.Namespace, rsOwnerRef.Name)
-							if err != nil {
-								log.Error(err, "failed to get deployment manifest", "name", rsOwnerRef.Name)
-							}
-							break
-						}
-					}
-				}
-				if deploymentManifest != "" {
-					break
+// workloadOf returns the workload of a pod as Incidents name it, e.g. "Deployment/api".
+func (r *PodReconciler) workloadOf(ctx context.Context, pod *corev1.Pod) string {
+	return pb.Workload(&pb.IncidentContext{
+		PodName: pod.Name,
+		Details: &pb.IncidentDetails{OwnerChain: newOwnerChain(r.resolveOwnerChain(ctx, pod))},
+	})
+}
+
+// workloadFailing reports whether another pod of the workload of a ready pod has a container in
+// an incident state, in which case the Incidents of the workload stay open. Pods are listed from
+// the cache, and the owners of failing pods only are resolved.
+func (r *PodReconciler) workloadFailing(ctx context.Context, pod *corev1.Pod, settings *policy.Effective) (bool, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(pod.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list pods in %s: %w", pod.Namespace, err)
+	}
+	workload := ""
+	for i := range pods.Items {
+		sibling := &pods.Items[i]
+		if sibling.UID == pod.UID || sibling.DeletionTimestamp != nil || disruptionReason(sibling) != "" || !hasIncidentReason(sibling, settings) {
+			continue
+		}
+		if workload == "" {
+			workload = r.workloadOf(ctx, pod)
+		}
+		if r.workloadOf(ctx, sibling) == workload {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hasIncidentReason(pod *corev1.Pod, settings *policy.Effective) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if incidentReason(status, settings) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// report sends the incident of a failing container, or of the pod itself if containerName is
+// empty, unless it is debounced under incidentKey or silenced.
+func (r *PodReconciler) report(ctx context.Context, pod *corev1.Pod, incidentKey, containerName, failureReason string, settings *policy.Effective) error {
+	return r.reporter().report(ctx, incidentKey, settings, func() (*pb.IncidentContext, error) {
+		return r.buildIncidentContext(ctx, pod, containerName, failureReason, settings)
+	})
+}
+
+func (r *PodReconciler) reporter() incidentReporter {
+	return incidentReporter{
+		IncidentCache:    r.IncidentCache,
+		Sink:             r.Sink,
+		Incidents:        r.Incidents,
+		Silences:         r.Silences,
+		MaxIncidentBytes: r.Config.MaxIncidentBytes,
+		Now:              r.now,
+	}
+}
+
+// settingsFor returns the settings of the ObserverPolicy that applies to the pod, overridden by
+// the annotations of the pod, its owners and its namespace.
+func (r *PodReconciler) settingsFor(ctx context.Context, pod *corev1.Pod) (*policy.Effective, error) {
+	settings := policy.Defaults(r.Config)
+	if r.Policies != nil {
+		var err error
+		if settings, err = r.Policies.For(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	annotated, err := settings.Annotate(r.annotatedObjects(ctx, pod))
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring invalid observer annotations", "pod", pod.Name, "namespace", pod.Namespace)
+	}
+	return annotated, nil
+}
+
+// annotatedObjects returns the pod, its owners and its namespace, nearest first.
+func (r *PodReconciler) annotatedObjects(ctx context.Context, pod *corev1.Pod) []policy.Annotated {
+	objects := []policy.Annotated{{Ref: "Pod/" + pod.Name, Annotations: pod.Annotations}}
+	chain, owners := walkOwners(ctx, r.Client, pod)
+	for i, owner := range owners {
+		objects = append(objects, policy.Annotated{Ref: chain[i].Kind + "/" + chain[i].Name, Annotations: owner.Annotations})
+	}
+	if r.Namespaced {
+		return objects
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, namespace); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to get namespace", "namespace", pod.Namespace)
+	} else {
+		objects = append(objects, policy.Annotated{Ref: "Namespace/" + namespace.Name, Annotations: namespace.Annotations})
+	}
+	return objects
+}
+
+// buildIncidentContext harvests logs, manifests, owners and events for a failing container, or
+// for the pod if containerName is empty, running only the collectors enabled by settings.
+// The legacy string fields are populated alongside the typed details for older Brains.
+func (r *PodReconciler) buildIncidentContext(ctx context.Context, pod *corev1.Pod, containerName, failureReason string, settings *policy.Effective) (*pb.IncidentContext, error) {
+	log := logf.FromContext(ctx)
+
+	var logs string
+	var err error
+	if containerName != "" && settings.Collects(observerv1alpha1.CollectorLogs) {
+		logs, err = r.LogAggregator.GetLogs(ctx, pod.Namespace, pod.Name, containerName, settings.LogTailLines)
+		if err != nil {
+			log.Error(err, "failed to get pod logs", "pod", pod.Name, "container", containerName)
+			return nil, err
+		}
+		logs = settings.Redact(logs)
+	}
+
+	ownerChain := r.resolveOwnerChain(ctx, pod)
+
+	var podManifest, deploymentManifest string
+	if settings.Collects(observerv1alpha1.CollectorManifests) {
+		podManifest, err = r.ManifestParser.GetAndRedactPodManifest(ctx, pod.Namespace, pod.Name)
+		if err != nil {
+			log.Error(err, "failed to get and redact pod manifest", "pod", pod.Name)
+			return nil, err
+		}
+		podManifest = settings.Redact(podManifest)
+
+		for _, owner := range ownerChain {
+			if owner.Kind == "Deployment" {
+				deploymentManifest, err = r.ManifestParser.Fetcher.GetDeploymentManifest(ctx, pod.Namespace, owner.Name)
+				if err != nil {
+					log.Error(err, "failed to get deployment manifest", "name", owner.Name)
 				}
+				deploymentManifest = settings.Redact(deploymentManifest)
+				break
 			}
+		}
+	}
+
+	details := newIncidentDetails(pod, containerName, settings)
+	details.OwnerChain = newOwnerChain(ownerChain)
+	details.LogSections = r.collectLogSections(ctx, pod, containerName, logs, settings)
+	details.Settings = newObservationSettings(settings)
+	var events []corev1.Event
+	if r.EventCollector != nil && settings.Collects(observerv1alpha1.CollectorEvents) {
+		events, err = r.EventCollector.GetEvents(ctx, pod.Namespace, pod.UID)
+		if err != nil {
+			log.Error(err, "failed to get pod events", "pod", pod.Name)
+		}
+		details.Events = newEvents(events, settings)
+	}
+	if containerName != "" {
+		details.Probes = newProbeDetails(pod, containerName, failureReason, events, settings)
+	}
+	switch failureReason {
+	case domain.ReasonUnschedulable, domain.ReasonPendingTimeout:
+		details.Scheduling = r.schedulingDetails(ctx, pod, details.Events)
+	case domain.ReasonEvicted, domain.ReasonPreempted:
+		details.Eviction = r.evictionDetails(ctx, pod, failureReason)
+	}
 
+	incidentID := fmt.Sprintf("%s-%s-%s-%d", pod.Name, containerName, failureReason, r.now().Unix())
+	if containerName == "" {
+		incidentID = fmt.Sprintf("%s-%s-%d", pod.Name, failureReason, r.now().Unix())
+	}
+	if r.ClusterID != "" {
+		incidentID = r.ClusterID + "-" + incidentID
+	}
+	return &pb.IncidentContext{
+		IncidentId:             incidentID,
+		ClusterId:              r.ClusterID,
+		PodName:                pod.Name,
+		PodNamespace:           pod.Namespace,
+		FailureReason:          failureReason,
+		Logs:                   logs,
+		PodManifestJson:        podManifest,
+		DeploymentManifestJson: deploymentManifest,
+		Timestamp:              timestamppb.Now(),
+		Details:                details,
+	}, nil
+}
 
-			incidentContext := &pb.IncidentContext{
-				IncidentId:             fmt.Sprintf("%s-%s-%s-%d", pod.Name, containerStatus.Name, failureReason, time.Now().Unix()),
-				PodName:                pod.Name,
-				PodNamespace:           pod.Namespace,
-				FailureReason:          failureReason,
-				Logs:                   logs,
-				PodManifestJson:        podManifest,
-				DeploymentManifestJson: deploymentManifest,
-				Timestamp:              timestamppb.Now(),
-			}
+// resolveOwnerChain follows controller owner references from the pod upwards, immediate owner first.
+func (r *PodReconciler) resolveOwnerChain(ctx context.Context, pod *corev1.Pod) []metav1.OwnerReference {
+	return ownerChain(ctx, r.Client, pod)
+}
 
+// ownerChain follows controller owner references from the pod upwards, immediate owner first.
+// Owners are read as metadata only, so any workload kind can be resolved without caching its spec.
+func ownerChain(ctx context.Context, c client.Reader, pod *corev1.Pod) []metav1.OwnerReference {
+	chain, _ := walkOwners(ctx, c, pod)
+	return chain
+}
 
-			if err := r.GrpcClient.StreamIncident(ctx, incidentContext); err != nil {
-				log.Error(err, "failed to stream incident to Brain", "incidentID", incidentContext.IncidentId)
-				return ctrl.Result{}, err
-			}
+// walkOwners returns the owner chain of a pod and the metadata of the owners that could be
+// read, in the same order. The last owner of the chain may be missing from the metadata.
+func walkOwners(ctx context.Context, c client.Reader, pod *corev1.Pod) ([]metav1.OwnerReference, []*metav1.PartialObjectMetadata) {
+	log := logf.FromContext(ctx)
 
-			log.Info("Incident streamed to Brain", "incidentID", incidentContext.IncidentId)
+	var chain []metav1.OwnerReference
+	var owners []*metav1.PartialObjectMetadata
+	var current client.Object = pod
+	for len(chain) < domain.MaxOwnerChainDepth {
+		owner := metav1.GetControllerOf(current)
+		if owner == nil {
+			break
 		}
+		chain = append(chain, *owner)
+
+		ownerMeta := &metav1.PartialObjectMetadata{}
+		ownerMeta.SetGroupVersionKind(schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind))
+		if err := c.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: owner.Name}, ownerMeta); err != nil {
+			log.Error(err, "failed to get owner", "kind", owner.Kind, "name", owner.Name)
+			break
+		}
+		owners = append(owners, ownerMeta)
+		current = ownerMeta
 	}
+	return chain, owners
+}
+
+// collectLogSections gathers the log tail of every failing container in the pod, including
+// the previous instance of containers that restarted. Missing sections are logged and skipped.
+func (r *PodReconciler) collectLogSections(ctx context.Context, pod *corev1.Pod, failingContainer, failingLogs string, settings *policy.Effective) []*pb.LogSection {
+	log := logf.FromContext(ctx)
 
-	return ctrl.Result{}, nil
+	var sections []*pb.LogSection
+	collectLogs := settings.Collects(observerv1alpha1.CollectorLogs)
+	if collectLogs && failingContainer != "" {
+		sections = append(sections, &pb.LogSection{Container: failingContainer, Content: failingLogs})
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Name != failingContainer {
+			if incidentReason(status, settings) == "" && !settings.CollectsContainer(status.Name) {
+				continue
+			}
+			if collectLogs {
+				logs, err := r.LogAggregator.GetLogs(ctx, pod.Namespace, pod.Name, status.Name, settings.LogTailLines)
+				if err != nil {
+					log.Error(err, "failed to get container logs", "pod", pod.Name, "container", status.Name)
+				} else {
+					sections = append(sections, &pb.LogSection{Container: status.Name, Content: settings.Redact(logs)})
+				}
+			}
+		}
+		if status.RestartCount > 0 && settings.Collects(observerv1alpha1.CollectorPreviousLogs) {
+			logs, err := r.LogAggregator.GetPreviousLogs(ctx, pod.Namespace, pod.Name, status.Name, settings.LogTailLines)
+			if err != nil {
+				log.Error(err, "failed to get previous container logs", "pod", pod.Name, "container", status.Name)
+				continue
+			}
+			sections = append(sections, &pb.LogSection{Container: status.Name, Previous: true, Content: settings.Redact(logs)})
+		}
+	}
+	return sections
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Pod{}).
-		Named("pod").
-		Complete(r)
-}
-
-// isIncidentReason checks if the provided reason is one that should trigger an incident.
-func isIncidentReason(reason string) bool {
-	switch reason {
-	case domain.ReasonCrashLoopBackOff,
-		domain.ReasonImagePullBackOff,
-		domain.ReasonErrImagePull,
-		domain.ReasonOOMKilled,
-		domain.ReasonError:
-		return true
-	default:
-		return false
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeIndex, func(obj client.Object) []string {
+		return []string{obj.(*corev1.Pod).Spec.NodeName}
+	}); err != nil {
+		return fmt.Errorf("failed to index pods by node: %w", err)
+	}
+	if r.Incidents != nil {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &observerv1alpha1.Incident{}, incident.WorkloadIndex, incident.IndexWorkload); err != nil {
+			return fmt.Errorf("failed to index incidents by workload: %w", err)
+		}
+	}
+	// The cache already drops pods outside the observed namespaces; the predicate keeps the
+	// watch consistent with the configuration if the cache is shared or left unrestricted.
+	observed := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.Config.Observes(obj.GetNamespace())
+	})
+	predicates := []predicate.Predicate{observed, podFailureTransitions(r.Incidents != nil)}
+	if r.Shards != nil {
+		predicates = append(predicates, r.ownedPods())
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(predicates...)).
+		Named("pod")
+	if r.Shards != nil {
+		b = b.WatchesRawSource(r.rebalanceSource())
+	}
+	return b.Complete(r)
+}
+
+// incidentReason returns the waiting or termination reason of a container if it counts as an
+// incident under settings, or an empty string.
+func incidentReason(status corev1.ContainerStatus, settings *policy.Effective) string {
+	if status.State.Waiting != nil && settings.IsIncidentReason(status.State.Waiting.Reason) {
+		return status.State.Waiting.Reason
+	}
+	if status.State.Terminated != nil && settings.IsIncidentReason(status.State.Terminated.Reason) {
+		return status.State.Terminated.Reason
+	}
+	return ""
+}
+
+// isPodReady checks if the pod reports the Ready condition.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
 	}
+	return false
 }
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+)
+
+func TestPodReconciler_SettingsFor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+
+	controllerOf := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "payments",
+		Annotations: map[string]string{policy.AnnotationDisabled: "true", policy.AnnotationTeam: "payments"},
+	}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:        "api",
+		Namespace:   "payments",
+		Annotations: map[string]string{policy.AnnotationDisabled: "false", policy.AnnotationLogTailLines: "1000"},
+	}}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "api-7d9f",
+		Namespace:       "payments",
+		OwnerReferences: controllerOf("Deployment", "api"),
+	}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "api-7d9f-abcde",
+		Namespace:       "payments",
+		OwnerReferences: controllerOf("ReplicaSet", "api-7d9f"),
+		Annotations:     map[string]string{policy.AnnotationExtraContainers: "istio-proxy"},
+	}}
+	worker := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "payments"}}
+
+	r := &PodReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, deployment, replicaSet, pod, worker).Build(),
+		Config: &config.ControllerConfig{},
+	}
+
+	settings, err := r.settingsFor(ctx, pod)
+	require.NoError(t, err)
+	assert.False(t, settings.Ignore, "the Deployment opts back in")
+	assert.Equal(t, int64(1000), settings.LogTailLines)
+	assert.Equal(t, "payments", settings.Team)
+	assert.True(t, settings.CollectsContainer("istio-proxy"))
+	assert.Equal(t, []string{"Pod/api-7d9f-abcde", "Deployment/api", "Namespace/payments"}, settings.AnnotatedBy)
+
+	payload := newObservationSettings(settings)
+	assert.Equal(t, "payments", payload.Team)
+	assert.Equal(t, []string{"istio-proxy"}, payload.ExtraContainers)
+	assert.Contains(t, payload.Collectors, string(observerv1alpha1.CollectorLogs))
+
+	settings, err = r.settingsFor(ctx, worker)
+	require.NoError(t, err)
+	assert.True(t, settings.Ignore, "the namespace opts out its other workloads")
+
+	r.Namespaced = true
+	settings, err = r.settingsFor(ctx, worker)
+	require.NoError(t, err)
+	assert.False(t, settings.Ignore, "namespaces are not read with namespaced Roles only")
+}
+
+func TestPodReconciler_WorkloadFailing(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	controllerOf := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "api-7d9f",
+		Namespace:       "payments",
+		OwnerReferences: controllerOf("Deployment", "api"),
+	}}
+	newPod := func(name, owner, waiting string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", UID: types.UID(name), OwnerReferences: controllerOf("ReplicaSet", owner)},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: waiting == ""}}},
+		}
+		if waiting != "" {
+			pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting}
+		}
+		return pod
+	}
+	ready := newPod("api-7d9f-a", "api-7d9f", "")
+	crashing := newPod("api-7d9f-b", "api-7d9f", domain.ReasonCrashLoopBackOff)
+	otherWorkload := newPod("worker-6c8b-a", "worker-6c8b", domain.ReasonCrashLoopBackOff)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(replicaSet, ready, crashing, otherWorkload).Build()
+	r := &PodReconciler{Client: c, Config: &config.ControllerConfig{}}
+	settings := policy.Defaults(r.Config)
+
+	failing, err := r.workloadFailing(ctx, ready, settings)
+	require.NoError(t, err)
+	assert.True(t, failing, "a crash-looping replica keeps the Incidents of its Deployment open")
+
+	require.NoError(t, c.Delete(ctx, crashing))
+	failing, err = r.workloadFailing(ctx, ready, settings)
+	require.NoError(t, err)
+	assert.False(t, failing, "failing pods of other workloads do not count")
+}
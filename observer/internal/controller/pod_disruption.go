@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+// podNodeIndex indexes cached pods by the node they run on, to group the evictions of a node.
+const podNodeIndex = "spec.nodeName"
+
+const (
+	// evictionSettleTime is how long an eviction waits before it is reported, so that the other
+	// evictions of a node under pressure are reported with it rather than on their own.
+	evictionSettleTime = 30 * time.Second
+	// evictionWindow is how old an eviction may be and still be reported or grouped. Evicted pods
+	// are kept until garbage collected, so older ones are listed whenever the Observer starts.
+	evictionWindow = 10 * time.Minute
+)
+
+// disruptionReason returns ReasonEvicted or ReasonPreempted for a pod the kubelet evicted or
+// that was preempted, or an empty string.
+func disruptionReason(pod *corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodFailed {
+		switch pod.Status.Reason {
+		case domain.ReasonEvicted:
+			return domain.ReasonEvicted
+		// Set by the kubelet when it preempts pods to admit a critical pod.
+		case "Preempting":
+			return domain.ReasonPreempted
+		}
+	}
+	if condition := podCondition(pod, corev1.DisruptionTarget); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason == corev1.PodReasonPreemptionByScheduler {
+		return domain.ReasonPreempted
+	}
+	return ""
+}
+
+// evictionTime returns when a pod was disrupted: the DisruptionTarget condition if the cluster
+// sets it, or the pod turning not ready otherwise.
+func evictionTime(pod *corev1.Pod) time.Time {
+	if condition := podCondition(pod, corev1.DisruptionTarget); condition != nil && !condition.LastTransitionTime.IsZero() {
+		return condition.LastTransitionTime.Time
+	}
+	if condition := podCondition(pod, corev1.PodReady); condition != nil && !condition.LastTransitionTime.IsZero() {
+		return condition.LastTransitionTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// evictionKey debounces the evictions of a node together, so that a mass eviction is a single
+// incident. Pods without a node are debounced on their own.
+func evictionKey(pod *corev1.Pod, reason string) string {
+	if pod.Spec.NodeName == "" {
+		return fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, reason)
+	}
+	return fmt.Sprintf("evictions/%s/%s", pod.Spec.NodeName, reason)
+}
+
+// reconcileDisruption reports an evicted or preempted pod once its eviction has settled, along
+// with the pods evicted from the same node. Later evictions of the node are debounced into it.
+func (r *PodReconciler) reconcileDisruption(ctx context.Context, pod *corev1.Pod, reason string, settings *policy.Effective, now time.Time) (time.Duration, error) {
+	if !settings.IsIncidentReason(reason) {
+		return 0, nil
+	}
+	age := now.Sub(evictionTime(pod))
+	if age > evictionWindow {
+		return 0, nil
+	}
+	if age < evictionSettleTime {
+		return evictionSettleTime - age, nil
+	}
+	logf.FromContext(ctx).Info("Pod entered incident state", "pod", pod.Name, "namespace", pod.Namespace, "node", pod.Spec.NodeName, "reason", reason)
+	return 0, r.report(ctx, pod, evictionKey(pod, reason), "", reason, settings)
+}
+
+// evictionDetails describe the eviction of a pod, the node's conditions and the other pods
+// disrupted for the same reason on the node.
+func (r *PodReconciler) evictionDetails(ctx context.Context, pod *corev1.Pod, reason string) *pb.EvictionDetails {
+	log := logf.FromContext(ctx)
+
+	details := &pb.EvictionDetails{
+		Reason:            pod.Status.Reason,
+		Message:           pod.Status.Message,
+		EvictedAt:         timestamppb.New(evictionTime(pod)),
+		PriorityClassName: pod.Spec.PriorityClassName,
+		QosClass:          string(pod.Status.QOSClass),
+	}
+	if pod.Spec.Priority != nil {
+		details.Priority = *pod.Spec.Priority
+	}
+	// Pods preempted by the scheduler only carry the reason in their DisruptionTarget condition.
+	if condition := podCondition(pod, corev1.DisruptionTarget); condition != nil && details.Reason == "" {
+		details.Reason = condition.Reason
+		details.Message = condition.Message
+	}
+	if pod.Spec.NodeName == "" {
+		return details
+	}
+
+	if !r.Namespaced && r.APIReader != nil {
+		node := &corev1.Node{}
+		if err := r.APIReader.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+			log.Error(err, "failed to get node of evicted pod", "pod", pod.Name, "node", pod.Spec.NodeName)
+		} else {
+			// Readiness and the pressure conditions that hold.
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == corev1.NodeReady || condition.Status == corev1.ConditionTrue {
+					details.NodeConditions = append(details.NodeConditions, newNodeCondition(condition))
+				}
+			}
+		}
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeIndex: pod.Spec.NodeName}); err != nil {
+		log.Error(err, "failed to list evicted pods of node", "node", pod.Spec.NodeName)
+		return details
+	}
+	evictedAt := evictionTime(pod)
+	var evicted []*corev1.Pod
+	for i := range pods.Items {
+		other := &pods.Items[i]
+		if disruptionReason(other) == reason && evictedAt.Sub(evictionTime(other)).Abs() <= evictionWindow {
+			evicted = append(evicted, other)
+		}
+	}
+	sort.SliceStable(evicted, func(i, j int) bool {
+		return evictionTime(evicted[i]).Before(evictionTime(evicted[j]))
+	})
+	for _, other := range evicted {
+		details.EvictedPods = append(details.EvictedPods, newAffectedPod(other))
+	}
+	return details
+}
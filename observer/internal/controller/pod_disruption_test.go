@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+)
+
+func newEvictedPod(name, node string, evictedAt time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments"},
+		Spec:       corev1.PodSpec{NodeName: node, Priority: ptr.To(int32(100)), PriorityClassName: "batch"},
+		Status: corev1.PodStatus{
+			Phase:    corev1.PodFailed,
+			Reason:   domain.ReasonEvicted,
+			Message:  "The node was low on resource: memory.",
+			QOSClass: corev1.PodQOSBestEffort,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.DisruptionTarget,
+				Status:             corev1.ConditionTrue,
+				Reason:             corev1.PodReasonTerminationByKubelet,
+				LastTransitionTime: metav1.NewTime(evictedAt),
+			}},
+		},
+	}
+}
+
+func TestDisruptionReason(t *testing.T) {
+	t.Parallel()
+
+	preempted := &corev1.Pod{Status: corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{
+			Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: corev1.PodReasonPreemptionByScheduler,
+		}},
+	}}
+	drained := &corev1.Pod{Status: corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{
+			Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "EvictionByEvictionAPI",
+		}},
+	}}
+	criticalAdmission := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Preempting"}}
+	failed := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}}
+
+	testCases := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected string
+	}{
+		{name: "evicted by the kubelet", pod: newEvictedPod("api-1", "node-1", time.Now()), expected: domain.ReasonEvicted},
+		{name: "preempted by the scheduler", pod: preempted, expected: domain.ReasonPreempted},
+		{name: "preempted by the kubelet", pod: criticalAdmission, expected: domain.ReasonPreempted},
+		{name: "drained through the eviction API", pod: drained},
+		{name: "failed", pod: failed},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, disruptionReason(tc.pod))
+		})
+	}
+
+	evicted := newEvictedPod("api-1", "node-1", time.Now())
+	assert.True(t, podFailureTransitions(false).Create(event.CreateEvent{Object: evicted}))
+	assert.False(t, podFailureTransitions(false).Update(event.UpdateEvent{ObjectOld: evicted, ObjectNew: evicted}))
+}
+
+func TestPodReconciler_ReconcileEvictions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+			{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+		}},
+	}
+	first := newEvictedPod("api-1", "node-1", now.Add(-2*time.Minute))
+	second := newEvictedPod("api-2", "node-1", now.Add(-time.Minute))
+	stale := newEvictedPod("api-0", "node-1", now.Add(-time.Hour))
+	fresh := newEvictedPod("api-3", "node-1", now.Add(-10*time.Second))
+	elsewhere := newEvictedPod("web-1", "node-2", now.Add(-time.Minute))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node, first, second, stale, fresh, elsewhere).
+		WithIndex(&corev1.Pod{}, podNodeIndex, func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	manifestParser, err := harvester.NewManifestParser(c)
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	r := &PodReconciler{
+		Client:         c,
+		APIReader:      c,
+		EventCollector: &mockEventCollector{},
+		ManifestParser: manifestParser,
+		IncidentCache:  harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute),
+		Sink:           sink,
+		Config:         &config.ControllerConfig{DebounceTTLSeconds: time.Minute},
+		Now:            func() time.Time { return now },
+	}
+	reconcile := func(pod *corev1.Pod) ctrl.Result {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+		require.NoError(t, err)
+		return result
+	}
+
+	assert.Zero(t, reconcile(stale), "evictions listed on startup are not reported")
+	result := reconcile(fresh)
+	assert.Equal(t, 20*time.Second, result.RequeueAfter, "evictions settle before they are reported")
+	assert.Empty(t, sink.reasons())
+
+	reconcile(second)
+	require.Equal(t, []string{domain.ReasonEvicted}, sink.reasons())
+	incident := sink.incidents[0]
+	assert.Empty(t, incident.Details.ContainerName)
+	assert.Empty(t, incident.Details.LogSections)
+	eviction := incident.Details.Eviction
+	require.NotNil(t, eviction)
+	assert.Equal(t, domain.ReasonEvicted, eviction.Reason)
+	assert.Equal(t, "The node was low on resource: memory.", eviction.Message)
+	assert.Equal(t, int32(100), eviction.Priority)
+	assert.Equal(t, "batch", eviction.PriorityClassName)
+	assert.Equal(t, "BestEffort", eviction.QosClass)
+	require.Len(t, eviction.NodeConditions, 2, "Ready and the pressure conditions that hold")
+	assert.Equal(t, "MemoryPressure", eviction.NodeConditions[1].Type)
+	var grouped []string
+	for _, pod := range eviction.EvictedPods {
+		grouped = append(grouped, pod.Name)
+	}
+	assert.Equal(t, []string{"api-1", "api-2", "api-3"}, grouped, "pods evicted from the node in the window, oldest first")
+
+	reconcile(first)
+	assert.Len(t, sink.reasons(), 1, "other evictions of the node are grouped into the first incident")
+	reconcile(elsewhere)
+	assert.Len(t, sink.reasons(), 2)
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+// maxNodeSummaries bounds the candidate nodes described for a pending pod.
+const maxNodeSummaries = 50
+
+// isPending reports whether a pod waits to be scheduled or for its containers to start.
+func isPending(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodPending && pod.DeletionTimestamp == nil
+}
+
+// isUnschedulable reports whether the scheduler failed to place the pod.
+func isUnschedulable(pod *corev1.Pod) bool {
+	condition := podCondition(pod, corev1.PodScheduled)
+	return condition != nil && condition.Status == corev1.ConditionFalse
+}
+
+func podCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// pendingReason returns the failure reason of a pod Pending for longer than its threshold. A pod
+// still within it returns the time left, so that it is checked again once the threshold passes.
+// Pods whose containers fail are reported by their container reason instead.
+func pendingReason(pod *corev1.Pod, settings *policy.Effective, now time.Time) (string, time.Duration) {
+	if !isPending(pod) || settings.PendingThreshold <= 0 || hasFailingContainer(pod) {
+		return "", 0
+	}
+	reason := domain.ReasonPendingTimeout
+	if isUnschedulable(pod) {
+		reason = domain.ReasonUnschedulable
+	}
+	if !settings.IsIncidentReason(reason) {
+		return "", 0
+	}
+	if wait := pod.CreationTimestamp.Add(settings.PendingThreshold).Sub(now); wait > 0 {
+		return "", wait
+	}
+	return reason, 0
+}
+
+// schedulingDetails describe why a pod stays Pending: the scheduler's verdict, what the pod asks
+// for and, for unscheduled pods, the nodes it could run on.
+func (r *PodReconciler) schedulingDetails(ctx context.Context, pod *corev1.Pod, events []*pb.Event) *pb.SchedulingDetails {
+	details := &pb.SchedulingDetails{
+		PendingSince:      toTimestamp(pod.CreationTimestamp),
+		Scheduled:         pod.Spec.NodeName != "",
+		Requests:          resourceListToMap(podRequests(pod)),
+		NodeSelector:      pod.Spec.NodeSelector,
+		PriorityClassName: pod.Spec.PriorityClassName,
+	}
+	if condition := podCondition(pod, corev1.PodScheduled); condition != nil {
+		details.ConditionReason = condition.Reason
+		details.ConditionMessage = condition.Message
+	}
+	for _, event := range events {
+		if event.Reason == "FailedScheduling" {
+			details.FailedScheduling = append(details.FailedScheduling, event.Message)
+		}
+	}
+	if pod.Spec.Affinity != nil {
+		if affinity, err := json.Marshal(pod.Spec.Affinity); err == nil {
+			details.AffinityJson = string(affinity)
+		}
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		converted := &pb.Toleration{
+			Key:      toleration.Key,
+			Operator: string(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   string(toleration.Effect),
+		}
+		if toleration.TolerationSeconds != nil {
+			converted.TolerationSeconds = *toleration.TolerationSeconds
+		}
+		details.Tolerations = append(details.Tolerations, converted)
+	}
+	if !details.Scheduled && !r.Namespaced {
+		nodes, err := r.nodeSummaries(ctx, pod)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "failed to summarize node capacity", "pod", pod.Name)
+		}
+		details.Nodes = nodes
+	}
+	return details
+}
+
+// nodeSummaries returns the allocatable and requested resources of every node, schedulable nodes
+// first, each ordered by how full the pod would leave it. Nodes and their pods are read from the
+// cache, so requests only count the pods the Observer caches, and are only summed for the
+// schedulable nodes, which are ranked by them, and for the cordoned nodes returned.
+func (r *PodReconciler) nodeSummaries(ctx context.Context, pod *corev1.Pod) ([]*pb.NodeSummary, error) {
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	type candidate struct {
+		node        *corev1.Node
+		requested   corev1.ResourceList
+		schedulable bool
+		fill        float64
+	}
+	requests := podRequests(pod)
+	candidates := make([]candidate, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		c := candidate{
+			node:        node,
+			schedulable: nodeConditionStatus(node, corev1.NodeReady) == corev1.ConditionTrue && !node.Spec.Unschedulable,
+		}
+		if c.schedulable {
+			requested, err := r.nodeRequests(ctx, node.Name)
+			if err != nil {
+				return nil, err
+			}
+			c.requested = requested
+			c.fill = fillAfter(node.Status.Allocatable, requested, requests)
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].schedulable != candidates[j].schedulable {
+			return candidates[i].schedulable
+		}
+		if candidates[i].fill != candidates[j].fill {
+			return candidates[i].fill < candidates[j].fill
+		}
+		return candidates[i].node.Name < candidates[j].node.Name
+	})
+
+	summaries := make([]*pb.NodeSummary, 0, min(len(candidates), maxNodeSummaries))
+	for _, c := range candidates[:min(len(candidates), maxNodeSummaries)] {
+		node := c.node
+		if !c.schedulable {
+			requested, err := r.nodeRequests(ctx, node.Name)
+			if err != nil {
+				return nil, err
+			}
+			c.requested = requested
+		}
+		summary := &pb.NodeSummary{
+			Name:          node.Name,
+			Ready:         nodeConditionStatus(node, corev1.NodeReady) == corev1.ConditionTrue,
+			Unschedulable: node.Spec.Unschedulable,
+			Allocatable:   resourceListToMap(node.Status.Allocatable),
+			Requested:     resourceListToMap(c.requested),
+		}
+		for _, taint := range node.Spec.Taints {
+			summary.Taints = append(summary.Taints, &pb.Taint{Key: taint.Key, Value: taint.Value, Effect: string(taint.Effect)})
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// nodeRequests sums the requests of the cached pods on a node; terminal pods no longer hold
+// resources.
+func (r *PodReconciler) nodeRequests(ctx context.Context, nodeName string) (corev1.ResourceList, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeIndex: nodeName}); err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+	}
+	requested := corev1.ResourceList{}
+	for i := range pods.Items {
+		running := &pods.Items[i]
+		if running.Status.Phase == corev1.PodSucceeded || running.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		addResources(requested, podRequests(running))
+	}
+	return requested, nil
+}
+
+// fillAfter returns the largest share of a node's allocatable resources that would be requested
+// once the pod is added, over the resources the pod requests, or CPU and memory if none. Above
+// 1 the pod does not fit.
+func fillAfter(allocatable, requested, pod corev1.ResourceList) float64 {
+	names := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	if len(pod) > 0 {
+		names = names[:0]
+		for name := range pod {
+			names = append(names, name)
+		}
+	}
+	fill := 0.0
+	for _, name := range names {
+		capacity := allocatable[name]
+		if capacity.IsZero() {
+			if _, asked := pod[name]; asked {
+				return math.Inf(1)
+			}
+			continue
+		}
+		used := requested[name].DeepCopy()
+		used.Add(pod[name])
+		fill = max(fill, used.AsApproximateFloat64()/capacity.AsApproximateFloat64())
+	}
+	return fill
+}
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+func newPendingPod(created time.Time, unschedulable bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "payments", CreationTimestamp: metav1.NewTime(created)},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if unschedulable {
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: "0/2 nodes are available: 1 Insufficient cpu, 1 node(s) were unschedulable.",
+		}}
+	} else {
+		pod.Spec.NodeName = "node-1"
+	}
+	return pod
+}
+
+func TestPendingReason(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	settings := policy.Defaults(&config.ControllerConfig{PendingThreshold: 10 * time.Minute})
+
+	imagePull := newPendingPod(now.Add(-time.Hour), false)
+	imagePull.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "app",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+	}}
+	running := newPendingPod(now.Add(-time.Hour), false)
+	running.Status.Phase = corev1.PodRunning
+	onlyCrashes := *settings
+	onlyCrashes.Reasons = []string{domain.ReasonCrashLoopBackOff}
+	disabled := *settings
+	disabled.PendingThreshold = 0
+
+	testCases := []struct {
+		name           string
+		pod            *corev1.Pod
+		settings       *policy.Effective
+		expectedReason string
+		expectedWait   time.Duration
+	}{
+		{
+			name:         "unschedulable within the threshold",
+			pod:          newPendingPod(now.Add(-4*time.Minute), true),
+			settings:     settings,
+			expectedWait: 6 * time.Minute,
+		},
+		{
+			name:           "unschedulable past the threshold",
+			pod:            newPendingPod(now.Add(-10*time.Minute), true),
+			settings:       settings,
+			expectedReason: domain.ReasonUnschedulable,
+		},
+		{
+			name:           "scheduled but not started",
+			pod:            newPendingPod(now.Add(-time.Hour), false),
+			settings:       settings,
+			expectedReason: domain.ReasonPendingTimeout,
+		},
+		{
+			name:     "container failure takes precedence",
+			pod:      imagePull,
+			settings: settings,
+		},
+		{
+			name:     "running pod",
+			pod:      running,
+			settings: settings,
+		},
+		{
+			name:     "reason not counted by the policy",
+			pod:      newPendingPod(now.Add(-time.Hour), true),
+			settings: &onlyCrashes,
+		},
+		{
+			name:     "detection disabled",
+			pod:      newPendingPod(now.Add(-time.Hour), true),
+			settings: &disabled,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reason, wait := pendingReason(tc.pod, tc.settings, now)
+
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedWait, wait)
+		})
+	}
+}
+
+func TestPodFailureTransitions_Pending(t *testing.T) {
+	t.Parallel()
+	created := time.Now()
+
+	pending := newPendingPod(created, false)
+	unschedulable := newPendingPod(created, true)
+	assert.True(t, podFailureTransitions(false).Create(event.CreateEvent{Object: pending}), "pending pods are checked again at their threshold")
+	assert.True(t, podFailureTransitions(false).Update(event.UpdateEvent{ObjectOld: pending, ObjectNew: unschedulable}))
+	assert.False(t, podFailureTransitions(false).Update(event.UpdateEvent{ObjectOld: unschedulable, ObjectNew: unschedulable}))
+}
+
+func TestPodReconciler_SchedulingDetails(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := func(name string, cpu string, cordoned bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	busy := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "jobs"},
+		Spec: corev1.PodSpec{NodeName: "node-a", Containers: []corev1.Container{{Name: "work", Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+		}}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	done := busy.DeepCopy()
+	done.Name = "finished"
+	done.Spec.NodeName = "node-b"
+	done.Status.Phase = corev1.PodSucceeded
+
+	pod := newPendingPod(time.Now().Add(-time.Hour), true)
+	pod.Spec.NodeSelector = map[string]string{"pool": "general"}
+	pod.Spec.PriorityClassName = "high"
+	pod.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}},
+	}}
+	pod.Spec.Tolerations = []corev1.Toleration{{
+		Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.To(int64(300)),
+	}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(node("node-a", "4", false), node("node-b", "4", false), node("node-c", "8", true), busy, done).
+		WithIndex(&corev1.Pod{}, podNodeIndex, func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	r := &PodReconciler{Client: c}
+
+	details := r.schedulingDetails(ctx, pod, []*pb.Event{
+		{Reason: "FailedScheduling", Message: "0/3 nodes are available: 1 Insufficient cpu."},
+		{Reason: "NotTriggerScaleUp", Message: "pod didn't trigger scale-up"},
+	})
+
+	assert.False(t, details.Scheduled)
+	assert.Equal(t, corev1.PodReasonUnschedulable, details.ConditionReason)
+	assert.Equal(t, []string{"0/3 nodes are available: 1 Insufficient cpu."}, details.FailedScheduling)
+	assert.Equal(t, map[string]string{"cpu": "2"}, details.Requests)
+	assert.Equal(t, map[string]string{"pool": "general"}, details.NodeSelector)
+	assert.Equal(t, "high", details.PriorityClassName)
+	assert.JSONEq(t, `{"podAntiAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"topologyKey":"kubernetes.io/hostname"}]}}`, details.AffinityJson)
+	assert.Equal(t, []*pb.Toleration{{Key: "node.kubernetes.io/not-ready", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: 300}}, details.Tolerations)
+
+	require.Len(t, details.Nodes, 3)
+	var names []string
+	for _, summary := range details.Nodes {
+		names = append(names, summary.Name)
+	}
+	assert.Equal(t, []string{"node-b", "node-a", "node-c"}, names, "free nodes first, cordoned nodes last")
+	assert.Equal(t, map[string]string{"cpu": "3"}, details.Nodes[1].Requested)
+	assert.Empty(t, details.Nodes[0].Requested, "finished pods hold no resources")
+
+	r.Namespaced = true
+	assert.Empty(t, r.schedulingDetails(ctx, pod, nil).Nodes, "nodes are not read with namespaced Roles only")
+}
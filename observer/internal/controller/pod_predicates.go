@@ -0,0 +1,111 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Waiting reasons of containers that are starting normally.
+var startingReasons = map[string]bool{
+	"":                  true,
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// podFailureTransitions only enqueues pods whose containers move into a failure state: a new
+// waiting or terminated reason, or another restart. Label, annotation and readiness updates of
+// healthy pods are dropped, which is the bulk of pod updates on a large cluster. Pending pods are
+// enqueued when created or found unschedulable, and Reconcile requeues them until they exceed
+// their pending threshold; running pods turning not ready are requeued likewise until their
+// not-ready threshold. Pods are enqueued too when evicted or preempted. With resolve set, pods
+// becoming ready are enqueued too, so that the Incidents of their workload resolve.
+// Which reasons count as incidents is left to Reconcile, as it depends on ObserverPolicies.
+func podFailureTransitions(resolve bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			pod, ok := e.Object.(*corev1.Pod)
+			return countPodEvent("create", ok && (isWatchedForIncidents(pod) || resolve && isPodReady(pod)))
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if !okOld || !okNew {
+				return countPodEvent("update", false)
+			}
+			becameReady := resolve && !isPodReady(oldPod) && isPodReady(newPod)
+			becameUnschedulable := isPending(newPod) && isUnschedulable(newPod) && !isUnschedulable(oldPod)
+			disrupted := disruptionReason(newPod) != "" && disruptionReason(oldPod) == ""
+			becameNotReady := isRunningNotReady(newPod) && !isRunningNotReady(oldPod)
+			return countPodEvent("update", enteredFailure(oldPod, newPod) || becameUnschedulable || disrupted || becameNotReady || becameReady)
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return countPodEvent("delete", false)
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return countPodEvent("generic", true)
+		},
+	}
+}
+
+// isWatchedForIncidents reports whether a pod fails or may turn into an incident once a threshold
+// passes.
+func isWatchedForIncidents(pod *corev1.Pod) bool {
+	return hasFailingContainer(pod) || isPending(pod) || isRunningNotReady(pod) || disruptionReason(pod) != ""
+}
+
+func containerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	return append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+}
+
+func hasFailingContainer(pod *corev1.Pod) bool {
+	for _, status := range containerStatuses(pod) {
+		if isFailureState(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFailureState reports whether a container is waiting for another reason than starting, or
+// terminated with an error.
+func isFailureState(status corev1.ContainerStatus) bool {
+	if status.State.Waiting != nil {
+		return !startingReasons[status.State.Waiting.Reason]
+	}
+	if terminated := status.State.Terminated; terminated != nil {
+		return terminated.ExitCode != 0 || terminated.Reason != "Completed"
+	}
+	return false
+}
+
+// enteredFailure reports whether a container of the pod is in a failure state that it was not
+// in before, or restarted again.
+func enteredFailure(oldPod, newPod *corev1.Pod) bool {
+	previous := make(map[string]corev1.ContainerStatus)
+	for _, status := range containerStatuses(oldPod) {
+		previous[status.Name] = status
+	}
+	for _, status := range containerStatuses(newPod) {
+		if !isFailureState(status) {
+			continue
+		}
+		old, found := previous[status.Name]
+		if !found || !isFailureState(old) || status.RestartCount > old.RestartCount ||
+			stateReason(status.State) != stateReason(old.State) {
+			return true
+		}
+	}
+	return false
+}
+
+func stateReason(state corev1.ContainerState) string {
+	switch {
+	case state.Waiting != nil:
+		return "waiting/" + state.Waiting.Reason
+	case state.Terminated != nil:
+		return "terminated/" + state.Terminated.Reason
+	default:
+		return ""
+	}
+}
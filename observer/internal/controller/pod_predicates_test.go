@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPodFailureTransitions(t *testing.T) {
+	t.Parallel()
+
+	waiting := func(reason string, restarts int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:         "app",
+			RestartCount: restarts,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+		}
+	}
+	running := func(restarts int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:         "app",
+			RestartCount: restarts,
+			State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}
+	}
+	newPod := func(ready bool, labels map[string]string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{}
+		pod.Labels = labels
+		pod.Status.ContainerStatuses = statuses
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		return pod
+	}
+
+	testCases := []struct {
+		name     string
+		resolve  bool
+		oldPod   *corev1.Pod
+		newPod   *corev1.Pod
+		expected bool
+	}{
+		{
+			name:     "container starts crash looping",
+			oldPod:   newPod(true, nil, running(0)),
+			newPod:   newPod(false, nil, waiting("CrashLoopBackOff", 1)),
+			expected: true,
+		},
+		{
+			name:     "crash looping container restarts again",
+			oldPod:   newPod(false, nil, waiting("CrashLoopBackOff", 1)),
+			newPod:   newPod(false, nil, waiting("CrashLoopBackOff", 2)),
+			expected: true,
+		},
+		{
+			name:     "image pull reason changes",
+			oldPod:   newPod(false, nil, waiting("ErrImagePull", 0)),
+			newPod:   newPod(false, nil, waiting("ImagePullBackOff", 0)),
+			expected: true,
+		},
+		{
+			name:     "label change on a failing pod",
+			oldPod:   newPod(false, nil, waiting("CrashLoopBackOff", 1)),
+			newPod:   newPod(false, map[string]string{"team": "payments"}, waiting("CrashLoopBackOff", 1)),
+			expected: false,
+		},
+		{
+			name:     "container creation",
+			oldPod:   newPod(false, nil),
+			newPod:   newPod(false, nil, waiting("ContainerCreating", 0)),
+			expected: false,
+		},
+		{
+			name:     "readiness flip without incidents",
+			oldPod:   newPod(false, nil, running(0)),
+			newPod:   newPod(true, nil, running(0)),
+			expected: false,
+		},
+		{
+			name:     "pod becomes ready while incidents are resolved",
+			resolve:  true,
+			oldPod:   newPod(false, nil, running(1)),
+			newPod:   newPod(true, nil, running(1)),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			enqueued := podFailureTransitions(tc.resolve).Update(event.UpdateEvent{ObjectOld: tc.oldPod, ObjectNew: tc.newPod})
+
+			assert.Equal(t, tc.expected, enqueued)
+		})
+	}
+}
+
+func TestPodFailureTransitions_Create(t *testing.T) {
+	t.Parallel()
+
+	failing := &corev1.Pod{}
+	failing.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "app",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+	}}
+	completed := &corev1.Pod{}
+	completed.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "migrate",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}},
+	}}
+
+	assert.True(t, podFailureTransitions(false).Create(event.CreateEvent{Object: failing}), "failing pods are picked up on startup")
+	assert.False(t, podFailureTransitions(false).Create(event.CreateEvent{Object: completed}))
+	assert.False(t, podFailureTransitions(true).Delete(event.DeleteEvent{Object: failing}))
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+// maxProbeFailures bounds the Unhealthy events reported for a container.
+const maxProbeFailures = 10
+
+// isRunningNotReady reports whether a running pod is not ready, which is how failing readiness
+// probes show up.
+func isRunningNotReady(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil && !isPodReady(pod)
+}
+
+// notReadyReason returns ReasonReadinessProbeFailed for a running container that has not been
+// ready for longer than its threshold. A container still within it returns the time left, so that
+// it is checked again once the threshold passes.
+func notReadyReason(pod *corev1.Pod, status corev1.ContainerStatus, settings *policy.Effective, now time.Time) (string, time.Duration) {
+	if !isRunningNotReady(pod) || settings.NotReadyThreshold <= 0 || status.Ready || status.State.Running == nil {
+		return "", 0
+	}
+	// Readiness probes only run once the startup probe succeeded.
+	if status.Started != nil && !*status.Started {
+		return "", 0
+	}
+	container := findContainer(pod.Spec.Containers, status.Name)
+	if container == nil || container.ReadinessProbe == nil || !settings.IsIncidentReason(domain.ReasonReadinessProbeFailed) {
+		return "", 0
+	}
+	if wait := notReadySince(pod, status).Add(settings.NotReadyThreshold).Sub(now); wait > 0 {
+		return "", wait
+	}
+	return domain.ReasonReadinessProbeFailed, 0
+}
+
+// notReadySince returns when a running container was last seen turning not ready: when it started,
+// or when the pod's containers stopped being ready if that is later.
+func notReadySince(pod *corev1.Pod, status corev1.ContainerStatus) time.Time {
+	since := status.State.Running.StartedAt.Time
+	if condition := podCondition(pod, corev1.ContainersReady); condition != nil &&
+		condition.Status != corev1.ConditionTrue && condition.LastTransitionTime.After(since) {
+		since = condition.LastTransitionTime.Time
+	}
+	return since
+}
+
+// newProbeDetails describes the probes of a container and its recent Unhealthy events, for probe
+// failures and for the crashes that failed liveness probes cause. It returns nil for containers
+// that did not fail a probe.
+func newProbeDetails(pod *corev1.Pod, containerName, failureReason string, events []corev1.Event, settings *policy.Effective) *pb.ProbeDetails {
+	container := findContainer(append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...), containerName)
+	if container == nil {
+		return nil
+	}
+
+	fieldPaths := map[string]bool{
+		fmt.Sprintf("spec.containers{%s}", containerName):     true,
+		fmt.Sprintf("spec.initContainers{%s}", containerName): true,
+	}
+	var unhealthy []corev1.Event
+	for _, event := range events {
+		if event.Reason == "Unhealthy" && fieldPaths[event.InvolvedObject.FieldPath] {
+			unhealthy = append(unhealthy, event)
+		}
+	}
+	if len(unhealthy) == 0 && failureReason != domain.ReasonReadinessProbeFailed {
+		return nil
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return eventLastSeen(&unhealthy[i]).Before(eventLastSeen(&unhealthy[j]))
+	})
+	unhealthy = unhealthy[max(len(unhealthy)-maxProbeFailures, 0):]
+
+	details := &pb.ProbeDetails{
+		Readiness: newProbe(container.ReadinessProbe, settings),
+		Liveness:  newProbe(container.LivenessProbe, settings),
+		Startup:   newProbe(container.StartupProbe, settings),
+	}
+	if status := findContainerStatus(pod.Status.ContainerStatuses, containerName); status != nil && !status.Ready && status.State.Running != nil {
+		details.NotReadySince = timestamppb.New(notReadySince(pod, *status))
+	}
+	for i := range unhealthy {
+		event := &unhealthy[i]
+		count := event.Count
+		if count == 0 && event.Series != nil {
+			count = event.Series.Count
+		}
+		// The kubelet reports failures as "<Kind> probe failed: <output>".
+		probe, message, found := strings.Cut(event.Message, " probe failed: ")
+		if !found {
+			probe, message = "", event.Message
+		}
+		details.Failures = append(details.Failures, &pb.ProbeFailure{
+			Probe:    probe,
+			Message:  settings.Redact(message),
+			Count:    count,
+			LastSeen: timestamppb.New(eventLastSeen(event)),
+		})
+	}
+	return details
+}
+
+func newProbe(probe *corev1.Probe, settings *policy.Effective) *pb.Probe {
+	if probe == nil {
+		return nil
+	}
+	converted := &pb.Probe{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+	switch {
+	case probe.HTTPGet != nil:
+		scheme := strings.ToLower(string(probe.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		converted.Handler = fmt.Sprintf("httpGet %s %s:%s%s", scheme, probe.HTTPGet.Host, probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		converted.Handler = fmt.Sprintf("tcpSocket %s:%s", probe.TCPSocket.Host, probe.TCPSocket.Port.String())
+	case probe.GRPC != nil:
+		converted.Handler = fmt.Sprintf("grpc :%d", probe.GRPC.Port)
+		if probe.GRPC.Service != nil && *probe.GRPC.Service != "" {
+			converted.Handler += " " + *probe.GRPC.Service
+		}
+	case probe.Exec != nil:
+		// Commands may embed credentials, like the logs and manifests.
+		converted.Handler = settings.Redact("exec " + strings.Join(probe.Exec.Command, " "))
+	}
+	return converted
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+// sooner returns the shorter of two requeue delays, where zero means no requeue.
+func sooner(a, b time.Duration) time.Duration {
+	if a == 0 || b > 0 && b < a {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/harvester"
+	"kube-mind/observer/internal/policy"
+	pb "kube-mind/observer/proto"
+)
+
+// mockLogAggregator is a harvester.LogAggregator backed by a function of the container name.
+type mockLogAggregator struct {
+	getLogs         func(container string) (string, error)
+	getPreviousLogs func(container string) (string, error)
+}
+
+func (m *mockLogAggregator) GetLogs(_ context.Context, _, _, container string, _ int64) (string, error) {
+	if m.getLogs == nil {
+		return "", nil
+	}
+	return m.getLogs(container)
+}
+
+func (m *mockLogAggregator) GetPreviousLogs(_ context.Context, _, _, container string, _ int64) (string, error) {
+	if m.getPreviousLogs == nil {
+		return "", nil
+	}
+	return m.getPreviousLogs(container)
+}
+
+func newNotReadyPod(startedAt time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "payments", UID: "api-1-uid"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080)}},
+				PeriodSeconds:    10,
+				FailureThreshold: 3,
+			},
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")}},
+			},
+		}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+				{Type: corev1.ContainersReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(startedAt)},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "app",
+				Started: ptr.To(true),
+				State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(startedAt)}},
+			}},
+		},
+	}
+}
+
+func TestNotReadyReason(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	settings := policy.Defaults(&config.ControllerConfig{NotReadyThreshold: 5 * time.Minute})
+
+	flapped := newNotReadyPod(now.Add(-time.Hour))
+	flapped.Status.Conditions[1].LastTransitionTime = metav1.NewTime(now.Add(-time.Minute))
+	starting := newNotReadyPod(now.Add(-time.Hour))
+	starting.Status.ContainerStatuses[0].Started = ptr.To(false)
+	unprobed := newNotReadyPod(now.Add(-time.Hour))
+	unprobed.Spec.Containers[0].ReadinessProbe = nil
+	ready := newNotReadyPod(now.Add(-time.Hour))
+	ready.Status.ContainerStatuses[0].Ready = true
+	patient := *settings
+	patient.NotReadyThreshold = 2 * time.Hour
+
+	testCases := []struct {
+		name           string
+		pod            *corev1.Pod
+		settings       *policy.Effective
+		expectedReason string
+		expectedWait   time.Duration
+	}{
+		{
+			name:           "not ready past the threshold",
+			pod:            newNotReadyPod(now.Add(-10 * time.Minute)),
+			settings:       settings,
+			expectedReason: domain.ReasonReadinessProbeFailed,
+		},
+		{
+			name:         "not ready again since a minute",
+			pod:          flapped,
+			settings:     settings,
+			expectedWait: 4 * time.Minute,
+		},
+		{
+			name:         "workload threshold",
+			pod:          newNotReadyPod(now.Add(-10 * time.Minute)),
+			settings:     &patient,
+			expectedWait: 110 * time.Minute,
+		},
+		{name: "startup probe pending", pod: starting, settings: settings},
+		{name: "no readiness probe", pod: unprobed, settings: settings},
+		{name: "container ready", pod: ready, settings: settings},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reason, wait := notReadyReason(tc.pod, tc.pod.Status.ContainerStatuses[0], tc.settings, now)
+
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedWait, wait)
+		})
+	}
+
+	readyPod := newNotReadyPod(now)
+	readyPod.Status.Conditions[0].Status = corev1.ConditionTrue
+	assert.True(t, podFailureTransitions(false).Update(event.UpdateEvent{ObjectOld: readyPod, ObjectNew: newNotReadyPod(now)}), "pods turning not ready are checked at their threshold")
+}
+
+func TestPodReconciler_ReconcileNotReady(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	failing := newNotReadyPod(now.Add(-10 * time.Minute))
+	recent := newNotReadyPod(now.Add(-time.Minute))
+	recent.Name = "api-2"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(failing, recent).Build()
+	manifestParser, err := harvester.NewManifestParser(c)
+	require.NoError(t, err)
+
+	unhealthy := func(message string, lastSeen time.Time) corev1.Event {
+		return corev1.Event{
+			Reason:         "Unhealthy",
+			Message:        message,
+			Count:          12,
+			InvolvedObject: corev1.ObjectReference{FieldPath: "spec.containers{app}"},
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	sink := &recordingSink{}
+	r := &PodReconciler{
+		Client: c,
+		LogAggregator: &mockLogAggregator{getLogs: func(string) (string, error) {
+			return "GET /ready 503 upstream unavailable\n", nil
+		}},
+		EventCollector: &mockEventCollector{getEvents: func(_ string, _ types.UID) []corev1.Event {
+			return []corev1.Event{
+				unhealthy("Readiness probe failed: HTTP probe failed with statuscode: 503", now),
+				unhealthy("Liveness probe failed: dial tcp 10.0.0.7:8080: connect: connection refused", now.Add(-time.Minute)),
+				{Reason: "Pulled", Message: "Container image already present on machine"},
+			}
+		}},
+		ManifestParser: manifestParser,
+		IncidentCache:  harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute),
+		Sink:           sink,
+		Namespaced:     true,
+		Config:         &config.ControllerConfig{DebounceTTLSeconds: time.Minute, NotReadyThreshold: 5 * time.Minute},
+		Now:            func() time.Time { return now },
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "payments", Name: "api-2"}})
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Minute, result.RequeueAfter)
+	assert.Empty(t, sink.reasons())
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "payments", Name: "api-1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{domain.ReasonReadinessProbeFailed}, sink.reasons())
+	incident := sink.incidents[0]
+	assert.Equal(t, "app", incident.Details.ContainerName)
+	assert.Contains(t, incident.Logs, "503")
+	probes := incident.Details.Probes
+	require.NotNil(t, probes)
+	assert.Equal(t, "httpGet http :8080/ready", probes.Readiness.Handler)
+	assert.Equal(t, int32(3), probes.Readiness.FailureThreshold)
+	assert.Equal(t, "tcpSocket :http", probes.Liveness.Handler)
+	assert.Nil(t, probes.Startup)
+	assert.Equal(t, failing.Status.ContainerStatuses[0].State.Running.StartedAt.Unix(), probes.NotReadySince.AsTime().Unix())
+	assert.Equal(t, []*pb.ProbeFailure{
+		{Probe: "Liveness", Message: "dial tcp 10.0.0.7:8080: connect: connection refused", Count: 12, LastSeen: probes.Failures[0].LastSeen},
+		{Probe: "Readiness", Message: "HTTP probe failed with statuscode: 503", Count: 12, LastSeen: probes.Failures[1].LastSeen},
+	}, probes.Failures, "Unhealthy events of the container, oldest first")
+
+	// A debounced crash of another container keeps neither the requeue nor the other checks from
+	// running.
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(recent), recent))
+	recent.Status.ContainerStatuses = append([]corev1.ContainerStatus{{
+		Name:  "sidecar",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: domain.ReasonCrashLoopBackOff}},
+	}}, recent.Status.ContainerStatuses...)
+	require.NoError(t, c.Status().Update(ctx, recent))
+	r.IncidentCache.AddOrUpdate("payments/api-2/sidecar", true, time.Minute)
+
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "payments", Name: "api-2"}})
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Minute, result.RequeueAfter)
+	assert.Len(t, sink.reasons(), 1)
+}
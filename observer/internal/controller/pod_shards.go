@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ownedPods drops the events of pods observed by another shard member.
+func (r *PodReconciler) ownedPods() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		pod, ok := obj.(*corev1.Pod)
+		return ok && r.Shards.OwnsPod(pod)
+	})
+}
+
+// rebalanceSource requeues the failing, pending and not ready pods this replica owns after a shard
+// membership change, since their transitions were seen, and dropped, while another member owned
+// them. The debounce state handed over by that member keeps them from being reported twice.
+func (r *PodReconciler) rebalanceSource() source.Source {
+	events := make(chan event.GenericEvent)
+	r.Shards.OnRebalance(func(ctx context.Context) {
+		var pods corev1.PodList
+		if err := r.List(ctx, &pods); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list pods after shard rebalance")
+			return
+		}
+		var owned []*corev1.Pod
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if r.Config.Observes(pod.Namespace) && isWatchedForIncidents(pod) && r.Shards.OwnsPod(pod) {
+				owned = append(owned, pod)
+			}
+		}
+		// Sent in the background, so that membership renewals never wait on the controller.
+		go func() {
+			for _, pod := range owned {
+				select {
+				case events <- event.GenericEvent{Object: pod}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	})
+	return source.Channel(events, &handler.EnqueueRequestForObject{})
+}
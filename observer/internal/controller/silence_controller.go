@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/silence"
+)
+
+// SilenceReconciler keeps the state of Silences up to date as their windows open and close.
+type SilenceReconciler struct {
+	client.Client
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=silences,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observer.tutorial.kubebuilder.io,resources=silences/status,verbs=get;update
+
+// Reconcile updates the state of one silence and requeues it at its next transition.
+func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &observerv1alpha1.Silence{}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	if r.Now != nil {
+		now = r.Now()
+	}
+
+	status := obj.Status
+	status.Message = ""
+	if err := silence.Validate(&obj.Spec); err != nil {
+		status.State = observerv1alpha1.SilencePending
+		status.Message = err.Error()
+	} else {
+		state, _ := silence.State(&obj.Spec, now)
+		status.State = state
+	}
+
+	if status.State != obj.Status.State || status.Message != obj.Status.Message {
+		obj.Status = status
+		if err := r.Status().Update(ctx, obj); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update silence %s/%s status: %w", obj.Namespace, obj.Name, err)
+		}
+	}
+
+	if status.Message != "" {
+		return ctrl.Result{}, nil
+	}
+	next := silence.NextTransition(&obj.Spec, now)
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now) + time.Second}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observerv1alpha1.Silence{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("silence").
+		Complete(r)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+)
+
+func TestSilenceReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	startsAt := metav1.NewTime(now.Add(time.Hour))
+	scheduled := &observerv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "payments"},
+		Spec:       observerv1alpha1.SilenceSpec{StartsAt: &startsAt},
+	}
+	invalid := &observerv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "payments"},
+		Spec: observerv1alpha1.SilenceSpec{
+			Recurrence: &observerv1alpha1.Recurrence{StartTime: "22:00", EndTime: "06:00", TimeZone: "Mars/Olympus"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&observerv1alpha1.Silence{}).
+		WithObjects(scheduled, invalid).
+		Build()
+	r := &SilenceReconciler{Client: c, Now: func() time.Time { return now }}
+
+	key := types.NamespacedName{Namespace: "payments", Name: "scheduled"}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour+time.Second, result.RequeueAfter, "the silence is requeued when it starts")
+	require.NoError(t, c.Get(ctx, key, scheduled))
+	assert.Equal(t, observerv1alpha1.SilencePending, scheduled.Status.State)
+
+	now = now.Add(2 * time.Hour)
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	require.NoError(t, c.Get(ctx, key, scheduled))
+	assert.Equal(t, observerv1alpha1.SilenceActive, scheduled.Status.State)
+
+	key = types.NamespacedName{Namespace: "payments", Name: "invalid"}
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, key, invalid))
+	assert.Equal(t, observerv1alpha1.SilencePending, invalid.Status.State)
+	assert.Contains(t, invalid.Status.Message, "time zone")
+}
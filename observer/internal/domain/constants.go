@@ -12,10 +12,52 @@ const (
 	ReasonOOMKilled = "OOMKilled"
 	// ReasonError is a generic error reason for a terminated container.
 	ReasonError = "Error"
+	// ReasonUnschedulable is the reason for a pod the scheduler cannot place on any node.
+	ReasonUnschedulable = "Unschedulable"
+	// ReasonPendingTimeout is the reason for a scheduled pod whose containers did not start in time.
+	ReasonPendingTimeout = "PendingTimeout"
+	// ReasonEvicted is the reason for a pod the kubelet evicted to relieve node pressure.
+	ReasonEvicted = "Evicted"
+	// ReasonPreempted is the reason for a pod terminated to make room for a higher-priority pod.
+	ReasonPreempted = "Preempted"
+	// ReasonReadinessProbeFailed is the reason for a running container that stays not ready.
+	ReasonReadinessProbeFailed = "ReadinessProbeFailed"
+	// ReasonProgressDeadlineExceeded is the reason for a Deployment rollout that did not progress
+	// within its progress deadline.
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// ReasonRolloutStalled is the reason for a Deployment rollout whose new pods fail while the
+	// old ones still serve.
+	ReasonRolloutStalled = "RolloutStalled"
+	// ReasonBackoffLimitExceeded is the reason for a Job whose pods failed more often than its
+	// backoff limit allows.
+	ReasonBackoffLimitExceeded = "BackoffLimitExceeded"
+	// ReasonDeadlineExceeded is the reason for a Job that ran longer than its active deadline.
+	ReasonDeadlineExceeded = "DeadlineExceeded"
+	// ReasonJobFailed is the reason for a Job that failed for another reason, such as its pod
+	// failure policy.
+	ReasonJobFailed = "JobFailed"
+	// ReasonCronJobFailing is the reason for a CronJob whose last runs failed.
+	ReasonCronJobFailing = "CronJobFailing"
 )
 
 // Harvester constants for data gathering parameters.
 const (
 	// DefaultLogTailLines is the default number of log lines to fetch.
 	DefaultLogTailLines = 200
+	// MaxLogTailLines bounds the log lines a workload can request through annotations.
+	MaxLogTailLines = 5000
+	// MaxOwnerChainDepth bounds how many controller owners are followed from a pod.
+	MaxOwnerChainDepth = 5
+	// MaxCronJobRuns bounds the runs of a CronJob that are remembered and reported.
+	MaxCronJobRuns = 10
+)
+
+// Container state phases reported in the structured incident payload.
+const (
+	// ContainerStateWaiting is the phase of a container that has not started yet.
+	ContainerStateWaiting = "waiting"
+	// ContainerStateRunning is the phase of a running container.
+	ContainerStateRunning = "running"
+	// ContainerStateTerminated is the phase of a container that has exited.
+	ContainerStateTerminated = "terminated"
 )
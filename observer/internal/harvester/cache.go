@@ -33,3 +33,29 @@ func (c *GoCacheIntelligenceCache) AddOrUpdate(key string, obj any, ttl time.Dur
 func (c *GoCacheIntelligenceCache) Get(key string) (any, bool) {
 	return c.cache.Get(key)
 }
+
+// Snapshot returns the expiry of every unexpired entry, to hand debounce state over to
+// another replica.
+func (c *GoCacheIntelligenceCache) Snapshot() map[string]time.Time {
+	items := c.cache.Items()
+	snapshot := make(map[string]time.Time, len(items))
+	for key, item := range items {
+		if item.Expiration > 0 {
+			snapshot[key] = time.Unix(0, item.Expiration)
+		}
+	}
+	return snapshot
+}
+
+// Restore adds the entries of a snapshot that are not cached yet and have not expired.
+func (c *GoCacheIntelligenceCache) Restore(entries map[string]time.Time) {
+	for key, expiry := range entries {
+		ttl := time.Until(expiry)
+		if ttl <= 0 {
+			continue
+		}
+		if _, found := c.cache.Get(key); !found {
+			c.cache.Set(key, true, ttl)
+		}
+	}
+}
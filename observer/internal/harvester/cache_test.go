@@ -91,3 +91,22 @@ func TestGoCacheIntelligenceCache(t *testing.T) {
 		})
 	}
 }
+
+func TestGoCacheIntelligenceCache_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	source := harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute)
+	source.AddOrUpdate("payments/api-1/app", true, time.Minute)
+	snapshot := source.Snapshot()
+	require.Contains(t, snapshot, "payments/api-1/app")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), snapshot["payments/api-1/app"], time.Second)
+
+	snapshot["payments/api-2/app"] = time.Now().Add(-time.Second)
+	target := harvester.NewGoCacheIntelligenceCache(time.Minute, time.Minute)
+	target.Restore(snapshot)
+
+	_, found := target.Get("payments/api-1/app")
+	assert.True(t, found)
+	_, found = target.Get("payments/api-2/app")
+	assert.False(t, found, "expired entries are not restored")
+}
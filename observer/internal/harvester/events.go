@@ -0,0 +1,83 @@
+package harvester
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EventLister defines an interface for listing the events of a namespace.
+type EventLister interface {
+	ListEvents(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.EventList, error)
+}
+
+// K8sEventLister implements EventLister using kubernetes clientset.
+// Events are listed straight from the API server so the manager never caches every event in the cluster.
+type K8sEventLister struct {
+	Clientset kubernetes.Interface
+}
+
+// ListEvents implements EventLister for actual Kubernetes API calls.
+func (l *K8sEventLister) ListEvents(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.EventList, error) {
+	return l.Clientset.CoreV1().Events(namespace).List(ctx, opts)
+}
+
+// EventCollector defines the interface for collecting the events of an object.
+type EventCollector interface {
+	GetEvents(ctx context.Context, namespace string, uid types.UID) ([]corev1.Event, error)
+}
+
+// K8sEventCollector implements EventCollector using an EventLister.
+type K8sEventCollector struct {
+	Lister EventLister
+}
+
+// NewK8sEventCollector creates a new K8sEventCollector with a default K8sEventLister.
+func NewK8sEventCollector(clientset kubernetes.Interface) *K8sEventCollector {
+	return &K8sEventCollector{
+		Lister: &K8sEventLister{Clientset: clientset},
+	}
+}
+
+// NewK8sEventCollectorWithLister creates a new K8sEventCollector with a custom EventLister.
+func NewK8sEventCollectorWithLister(lister EventLister) *K8sEventCollector {
+	return &K8sEventCollector{
+		Lister: lister,
+	}
+}
+
+// GetEvents retrieves the events whose involved object has the given UID, oldest first.
+func (c *K8sEventCollector) GetEvents(ctx context.Context, namespace string, uid types.UID) ([]corev1.Event, error) {
+	selector := fields.OneTermEqualSelector("involvedObject.uid", string(uid)).String()
+	list, err := c.Lister.ListEvents(ctx, namespace, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s/%s: %w", namespace, uid, err)
+	}
+
+	events := list.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events, nil
+}
+
+// eventTime returns the most recent timestamp an event carries.
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
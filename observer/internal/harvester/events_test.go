@@ -0,0 +1,98 @@
+package harvester_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kube-mind/observer/internal/harvester"
+)
+
+func TestK8sEventCollector_GetEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	testCases := []struct {
+		name                string
+		events              []corev1.Event
+		listErr             error
+		expectedReasons     []string
+		expectErr           bool
+		expectedErrContains string
+	}{
+		{
+			name: "Events are returned oldest first",
+			events: []corev1.Event{
+				{Reason: "BackOff", LastTimestamp: metav1.NewTime(now)},
+				{Reason: "Pulled", LastTimestamp: metav1.NewTime(now.Add(-2 * time.Minute))},
+				{Reason: "Started", EventTime: metav1.NewMicroTime(now.Add(-time.Minute))},
+			},
+			expectedReasons: []string{"Pulled", "Started", "BackOff"},
+		},
+		{
+			name:            "No events",
+			events:          nil,
+			expectedReasons: nil,
+		},
+		{
+			name:                "Error listing events",
+			listErr:             errors.New("forbidden"),
+			expectErr:           true,
+			expectedErrContains: "failed to list events",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requestedSelector string
+			lister := &mockEventLister{
+				listFunc: func(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.EventList, error) {
+					requestedSelector = opts.FieldSelector
+					if tc.listErr != nil {
+						return nil, tc.listErr
+					}
+					return &corev1.EventList{Items: tc.events}, nil
+				},
+			}
+
+			collector := harvester.NewK8sEventCollectorWithLister(lister)
+
+			events, err := collector.GetEvents(ctx, "default", "pod-uid")
+
+			if tc.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "involvedObject.uid=pod-uid", requestedSelector)
+			var reasons []string
+			for _, event := range events {
+				reasons = append(reasons, event.Reason)
+			}
+			assert.Equal(t, tc.expectedReasons, reasons)
+		})
+	}
+}
+
+// mockEventLister is a mock implementation of harvester.EventLister for testing.
+type mockEventLister struct {
+	listFunc func(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.EventList, error)
+}
+
+func (m *mockEventLister) ListEvents(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.EventList, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, namespace, opts)
+	}
+	return nil, errors.New("listFunc not implemented")
+}
@@ -13,8 +13,9 @@ import (
 )
 
 // PodLogStreamer defines an interface for streaming pod logs.
+// When previous is true the logs of the container's previous instance are streamed.
 type PodLogStreamer interface {
-	StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (io.ReadCloser, error)
+	StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error)
 }
 
 // K8sPodLogStreamer implements PodLogStreamer using kubernetes clientset.
@@ -23,10 +24,11 @@ type K8sPodLogStreamer struct {
 }
 
 // StreamPodLogs implements PodLogStreamer for actual Kubernetes API calls.
-func (s *K8sPodLogStreamer) StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (io.ReadCloser, error) {
+func (s *K8sPodLogStreamer) StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error) {
 	podLogOptions := &corev1.PodLogOptions{
 		Container: containerName,
 		TailLines: tailLines,
+		Previous:  previous,
 	}
 	req := s.Clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOptions)
 	return req.Stream(ctx)
@@ -35,6 +37,7 @@ func (s *K8sPodLogStreamer) StreamPodLogs(ctx context.Context, namespace, podNam
 // LogAggregator defines the interface for log aggregation.
 type LogAggregator interface {
 	GetLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error)
+	GetPreviousLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error)
 }
 
 // K8sLogAggregator implements LogAggregator using a PodLogStreamer.
@@ -58,7 +61,17 @@ func NewK8sLogAggregatorWithStreamer(streamer PodLogStreamer) *K8sLogAggregator
 
 // GetLogs retrieves the last 'tailLines' of logs for a specific container in a pod.
 func (a *K8sLogAggregator) GetLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
-	podLogs, err := a.Streamer.StreamPodLogs(ctx, namespace, podName, containerName, &tailLines)
+	return a.readLogs(ctx, namespace, podName, containerName, tailLines, false)
+}
+
+// GetPreviousLogs retrieves the last 'tailLines' of logs of the previous instance of a container,
+// which is where the output of a crashed container ends up after a restart.
+func (a *K8sLogAggregator) GetPreviousLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
+	return a.readLogs(ctx, namespace, podName, containerName, tailLines, true)
+}
+
+func (a *K8sLogAggregator) readLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64, previous bool) (string, error) {
+	podLogs, err := a.Streamer.StreamPodLogs(ctx, namespace, podName, containerName, &tailLines, previous)
 	if err != nil {
 		return "", fmt.Errorf("error in opening stream: %w", err)
 	}
@@ -56,7 +56,7 @@ func TestK8sLogAggregator_GetLogs_TableDriven(t *testing.T) {
 			t.Parallel()
 
 			mockStreamer := &mockPodLogStreamer{
-				streamFunc: func(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (io.ReadCloser, error) {
+				streamFunc: func(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error) {
 					return tc.mockStream()
 				},
 			}
@@ -76,14 +76,35 @@ func TestK8sLogAggregator_GetLogs_TableDriven(t *testing.T) {
 	}
 }
 
+func TestK8sLogAggregator_GetPreviousLogs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var requestedPrevious bool
+	mockStreamer := &mockPodLogStreamer{
+		streamFunc: func(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error) {
+			requestedPrevious = previous
+			return io.NopCloser(strings.NewReader("panic: boom")), nil
+		},
+	}
+
+	aggregator := harvester.NewK8sLogAggregatorWithStreamer(mockStreamer)
+
+	logs, err := aggregator.GetPreviousLogs(ctx, "default", "test-pod", "test-container", 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, "panic: boom", logs)
+	assert.True(t, requestedPrevious)
+}
+
 // mockPodLogStreamer is a mock implementation of harvester.PodLogStreamer for testing.
 type mockPodLogStreamer struct {
-	streamFunc func(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (io.ReadCloser, error)
+	streamFunc func(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error)
 }
 
-func (m *mockPodLogStreamer) StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64) (io.ReadCloser, error) {
+func (m *mockPodLogStreamer) StreamPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines *int64, previous bool) (io.ReadCloser, error) {
 	if m.streamFunc != nil {
-		return m.streamFunc(ctx, namespace, podName, containerName, tailLines)
+		return m.streamFunc(ctx, namespace, podName, containerName, tailLines, previous)
 	}
 	return nil, errors.New("streamFunc not implemented")
 }
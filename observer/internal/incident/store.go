@@ -0,0 +1,237 @@
+// Package incident records detected incidents as Incident resources, one per fingerprint, so
+// that they can be listed with kubectl and consumed by other tools.
+package incident
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	// WorkloadIndex is the field index of Incidents by their workload, as "Kind/name".
+	WorkloadIndex = "spec.workload"
+
+	// DefaultLogLines is the number of trailing log lines kept in an Incident.
+	DefaultLogLines = 10
+	maxLogExcerpt   = 4096
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Store creates and updates Incident resources. It implements sink.DeliveryReporter to record
+// the delivery state of every occurrence.
+type Store struct {
+	Client client.Client
+	// Reader reads Incidents before updating them. It should bypass the cache, as occurrences
+	// and their deliveries are recorded in quick succession.
+	Reader client.Reader
+	// LogLines is the number of trailing log lines kept as the excerpt. The excerpt is taken from
+	// the incident's logs, which the reconcilers redact with the ObserverPolicy's rules.
+	LogLines int
+}
+
+// NewStore creates a Store keeping the default number of log lines.
+func NewStore(c client.Client, reader client.Reader) *Store {
+	return &Store{Client: c, Reader: reader, LogLines: DefaultLogLines}
+}
+
+// Name returns the name of the Incident resource for an incident: its reason and fingerprint.
+func Name(incident *pb.IncidentContext) string {
+	reason := invalidNameChars.ReplaceAllString(strings.ToLower(incident.FailureReason), "-")
+	return strings.Trim(reason, "-") + "-" + pb.Fingerprint(incident)
+}
+
+// IndexWorkload is the field indexer for WorkloadIndex.
+func IndexWorkload(obj client.Object) []string {
+	return []string{obj.(*observerv1alpha1.Incident).Spec.Workload.String()}
+}
+
+// Record creates the Incident of an occurrence or updates it, counting the occurrence and
+// reopening it if it was resolved.
+func (s *Store) Record(ctx context.Context, incident *pb.IncidentContext) error {
+	return s.record(ctx, incident, "")
+}
+
+// RecordSilenced records an occurrence that the named Silence kept from being sent.
+func (s *Store) RecordSilenced(ctx context.Context, incident *pb.IncidentContext, silence string) error {
+	return s.record(ctx, incident, silence)
+}
+
+func (s *Store) record(ctx context.Context, incident *pb.IncidentContext, silencedBy string) error {
+	spec, err := s.spec(incident)
+	if err != nil {
+		return err
+	}
+	key := client.ObjectKey{Namespace: incident.PodNamespace, Name: Name(incident)}
+	now := metav1.Now()
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := &observerv1alpha1.Incident{}
+		if err := s.Reader.Get(ctx, key, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			obj = &observerv1alpha1.Incident{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Spec:       spec,
+			}
+			if err := s.Client.Create(ctx, obj); err != nil {
+				return err
+			}
+		} else if obj.Spec != spec {
+			obj.Spec = spec
+			if err := s.Client.Update(ctx, obj); err != nil {
+				return err
+			}
+		}
+
+		status := &obj.Status
+		status.Count++
+		if status.FirstSeen == nil {
+			status.FirstSeen = &now
+		}
+		status.LastSeen = &now
+		status.LastIncidentID = incident.IncidentId
+		status.LastPod = incident.PodName
+		status.ResolvedAt = nil
+		status.SilencedBy = silencedBy
+		return s.Client.Status().Update(ctx, obj)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record incident %s: %w", key, err)
+	}
+	return nil
+}
+
+// Resolve marks the unresolved Incidents of a workload as resolved. workload is only called
+// when the namespace has unresolved Incidents, as resolving it may need API calls.
+func (s *Store) Resolve(ctx context.Context, namespace string, workload func() string) error {
+	var open observerv1alpha1.IncidentList
+	if err := s.Client.List(ctx, &open, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list incidents in %s: %w", namespace, err)
+	}
+	if !hasUnresolved(open.Items) {
+		return nil
+	}
+
+	var list observerv1alpha1.IncidentList
+	if err := s.Client.List(ctx, &list, client.InNamespace(namespace), client.MatchingFields{WorkloadIndex: workload()}); err != nil {
+		return fmt.Errorf("failed to list incidents in %s: %w", namespace, err)
+	}
+	now := metav1.Now()
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.Status.ResolvedAt != nil {
+			continue
+		}
+		obj.Status.ResolvedAt = &now
+		if err := s.Client.Status().Update(ctx, obj); err != nil {
+			return fmt.Errorf("failed to resolve incident %s/%s: %w", obj.Namespace, obj.Name, err)
+		}
+		logf.FromContext(ctx).Info("Incident resolved", "incident", obj.Name, "workload", obj.Spec.Workload.String())
+	}
+	return nil
+}
+
+// DeliveryQueued implements sink.DeliveryReporter.
+func (s *Store) DeliveryQueued(ctx context.Context, sinkName string, incident *pb.IncidentContext) {
+	s.reportDelivery(ctx, sinkName, incident, observerv1alpha1.DeliveryPending, "")
+}
+
+// DeliveryFinished implements sink.DeliveryReporter.
+func (s *Store) DeliveryFinished(ctx context.Context, sinkName string, incident *pb.IncidentContext, err error) {
+	if err != nil {
+		s.reportDelivery(ctx, sinkName, incident, observerv1alpha1.DeliveryFailed, err.Error())
+		return
+	}
+	s.reportDelivery(ctx, sinkName, incident, observerv1alpha1.DeliveryDelivered, "")
+}
+
+// reportDelivery records the delivery state of the latest occurrence. States of older
+// occurrences are ignored so that a slow sink cannot overwrite a newer one.
+func (s *Store) reportDelivery(ctx context.Context, sinkName string, incident *pb.IncidentContext, state observerv1alpha1.DeliveryState, message string) {
+	key := client.ObjectKey{Namespace: incident.PodNamespace, Name: Name(incident)}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := &observerv1alpha1.Incident{}
+		if err := s.Reader.Get(ctx, key, obj); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if obj.Status.LastIncidentID != incident.IncidentId {
+			return nil
+		}
+		delivery := observerv1alpha1.IncidentDelivery{
+			Sink:               sinkName,
+			State:              state,
+			IncidentID:         incident.IncidentId,
+			LastTransitionTime: metav1.Now(),
+			Message:            message,
+		}
+		found := false
+		for i := range obj.Status.Deliveries {
+			if obj.Status.Deliveries[i].Sink == sinkName {
+				obj.Status.Deliveries[i] = delivery
+				found = true
+			}
+		}
+		if !found {
+			obj.Status.Deliveries = append(obj.Status.Deliveries, delivery)
+		}
+		return s.Client.Status().Update(ctx, obj)
+	})
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to record incident delivery", "incident", key, "sink", sinkName)
+	}
+}
+
+// spec builds the redacted summary of an incident.
+func (s *Store) spec(incident *pb.IncidentContext) (observerv1alpha1.IncidentSpec, error) {
+	workload := pb.Workload(incident)
+	kind, name, _ := strings.Cut(workload, "/")
+	spec := observerv1alpha1.IncidentSpec{
+		Fingerprint: pb.Fingerprint(incident),
+		ClusterID:   incident.ClusterId,
+		Workload:    observerv1alpha1.WorkloadReference{Kind: kind, Name: name},
+		Container:   incident.GetDetails().GetContainerName(),
+		Reason:      incident.FailureReason,
+	}
+	spec.Summary = fmt.Sprintf("%s in %s", spec.Reason, workload)
+	if spec.Container != "" {
+		spec.Summary += fmt.Sprintf(" (container %s)", spec.Container)
+	}
+
+	excerpt := tail(incident.Logs, s.LogLines)
+	if len(excerpt) > maxLogExcerpt {
+		excerpt = excerpt[len(excerpt)-maxLogExcerpt:]
+	}
+	spec.LogExcerpt = excerpt
+	return spec, nil
+}
+
+func hasUnresolved(incidents []observerv1alpha1.Incident) bool {
+	for _, obj := range incidents {
+		if obj.Status.ResolvedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// tail returns at most n trailing lines of logs.
+func tail(logs string, n int) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,152 @@
+package incident_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/incident"
+	pb "kube-mind/observer/proto"
+)
+
+func newStore(t *testing.T) *incident.Store {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&observerv1alpha1.Incident{}).
+		WithIndex(&observerv1alpha1.Incident{}, incident.WorkloadIndex, incident.IndexWorkload).
+		Build()
+	return incident.NewStore(c, c)
+}
+
+func newIncident(id, pod string) *pb.IncidentContext {
+	return &pb.IncidentContext{
+		IncidentId:    id,
+		PodName:       pod,
+		PodNamespace:  "payments",
+		FailureReason: "CrashLoopBackOff",
+		Logs:          "starting\npassword: [REDACTED]\npanic: boom\n",
+		Details: &pb.IncidentDetails{
+			ContainerName: "app",
+			OwnerChain:    []*pb.OwnerReference{{Kind: "ReplicaSet", Name: "api-7d9f"}, {Kind: "Deployment", Name: "api"}},
+		},
+	}
+}
+
+func getIncident(t *testing.T, store *incident.Store, incidentContext *pb.IncidentContext) *observerv1alpha1.Incident {
+	t.Helper()
+	obj := &observerv1alpha1.Incident{}
+	key := client.ObjectKey{Namespace: incidentContext.PodNamespace, Name: incident.Name(incidentContext)}
+	require.NoError(t, store.Client.Get(context.Background(), key, obj))
+	return obj
+}
+
+func TestStore_Record(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	first := newIncident("incident-1", "api-7d9f-abcde")
+	second := newIncident("incident-2", "api-7d9f-fghij")
+	require.NoError(t, store.Record(ctx, first))
+	require.NoError(t, store.Record(ctx, second))
+
+	assert.Equal(t, incident.Name(first), incident.Name(second), "occurrences of one failure share a resource")
+	assert.Regexp(t, `^crashloopbackoff-[0-9a-f]{16}$`, incident.Name(first))
+
+	obj := getIncident(t, store, second)
+	assert.Equal(t, pb.Fingerprint(first), obj.Spec.Fingerprint)
+	assert.Equal(t, observerv1alpha1.WorkloadReference{Kind: "Deployment", Name: "api"}, obj.Spec.Workload)
+	assert.Equal(t, "CrashLoopBackOff in Deployment/api (container app)", obj.Spec.Summary)
+	assert.Equal(t, "starting\npassword: [REDACTED]\npanic: boom", obj.Spec.LogExcerpt, "the excerpt is the tail of the logs sent")
+	assert.Equal(t, int64(2), obj.Status.Count)
+	assert.Equal(t, "incident-2", obj.Status.LastIncidentID)
+	assert.Equal(t, "api-7d9f-fghij", obj.Status.LastPod)
+	require.NotNil(t, obj.Status.FirstSeen)
+	require.NotNil(t, obj.Status.LastSeen)
+	assert.Nil(t, obj.Status.ResolvedAt)
+}
+
+func TestStore_Deliveries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	first := newIncident("incident-1", "api-7d9f-abcde")
+	second := newIncident("incident-2", "api-7d9f-abcde")
+	require.NoError(t, store.Record(ctx, first))
+	store.DeliveryQueued(ctx, "brain", first)
+	require.NoError(t, store.Record(ctx, second))
+	store.DeliveryQueued(ctx, "brain", second)
+	store.DeliveryQueued(ctx, "webhook", second)
+	store.DeliveryFinished(ctx, "brain", first, nil)
+	store.DeliveryFinished(ctx, "brain", second, nil)
+	store.DeliveryFinished(ctx, "webhook", second, errors.New("400 Bad Request"))
+
+	obj := getIncident(t, store, second)
+	require.Len(t, obj.Status.Deliveries, 2)
+	states := map[string]observerv1alpha1.IncidentDelivery{}
+	for _, delivery := range obj.Status.Deliveries {
+		states[delivery.Sink] = delivery
+	}
+	assert.Equal(t, observerv1alpha1.DeliveryDelivered, states["brain"].State)
+	assert.Equal(t, "incident-2", states["brain"].IncidentID, "deliveries of older occurrences are ignored")
+	assert.Equal(t, observerv1alpha1.DeliveryFailed, states["webhook"].State)
+	assert.Equal(t, "400 Bad Request", states["webhook"].Message)
+}
+
+func TestStore_Resolve(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	failing := newIncident("incident-1", "api-7d9f-abcde")
+	require.NoError(t, store.Record(ctx, failing))
+
+	calls := 0
+	workload := func(name string) func() string {
+		return func() string {
+			calls++
+			return name
+		}
+	}
+
+	require.NoError(t, store.Resolve(ctx, "payments", workload("Deployment/other")))
+	assert.Nil(t, getIncident(t, store, failing).Status.ResolvedAt, "other workloads must not resolve the incident")
+
+	require.NoError(t, store.Resolve(ctx, "payments", workload("Deployment/api")))
+	assert.NotNil(t, getIncident(t, store, failing).Status.ResolvedAt)
+
+	calls = 0
+	require.NoError(t, store.Resolve(ctx, "payments", workload("Deployment/api")))
+	assert.Zero(t, calls, "the workload is only resolved while incidents are open")
+
+	require.NoError(t, store.Record(ctx, newIncident("incident-2", "api-7d9f-fghij")))
+	obj := getIncident(t, store, failing)
+	assert.Nil(t, obj.Status.ResolvedAt, "a recurrence reopens the incident")
+	assert.Equal(t, int64(2), obj.Status.Count)
+}
+
+func TestStore_RecordSilenced(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	first := newIncident("incident-1", "api-7d9f-abcde")
+	require.NoError(t, store.RecordSilenced(ctx, first, "migration"))
+	obj := getIncident(t, store, first)
+	assert.Equal(t, "migration", obj.Status.SilencedBy)
+	assert.Equal(t, int64(1), obj.Status.Count, "silenced occurrences are still counted")
+
+	require.NoError(t, store.Record(ctx, newIncident("incident-2", "api-7d9f-abcde")))
+	assert.Empty(t, getIncident(t, store, first).Status.SilencedBy)
+}
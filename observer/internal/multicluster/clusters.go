@@ -0,0 +1,227 @@
+// Package multicluster lets one Observer watch several clusters. Each remote cluster is given by a
+// kubeconfig, read from a Secret or a directory, and runs its own controllers until its kubeconfig
+// changes or disappears.
+package multicluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ClusterLabel selects the Secrets holding the kubeconfig of a remote cluster. Its value, if
+	// set, is the cluster identity; otherwise the name of the Secret is.
+	ClusterLabel = "observer.kube-mind.io/cluster"
+	// KubeconfigKey is the Secret key holding the kubeconfig. The "value" key written by Cluster
+	// API is read as a fallback.
+	KubeconfigKey = "kubeconfig"
+
+	defaultRetryInterval = 30 * time.Second
+)
+
+// RunFunc runs the controllers of the cluster id until ctx is done.
+type RunFunc func(ctx context.Context, id string, config *rest.Config) error
+
+// Clusters runs a RunFunc for every remote cluster. Sources add and remove clusters by key at any
+// time; a cluster whose kubeconfig or identity changes is restarted, and one whose controllers
+// fail is retried. It is a manager.Runnable: clusters only start once it is started.
+type Clusters struct {
+	run           RunFunc
+	retryInterval time.Duration
+
+	mu      sync.Mutex
+	ctx     context.Context
+	entries map[string]*entry
+	wg      sync.WaitGroup
+}
+
+// entry is a cluster added by a source under a key, such as the Secret or file it was read from.
+type entry struct {
+	id     string
+	hash   [sha256.Size]byte
+	config *rest.Config
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClusters creates a Clusters running run for every cluster.
+func NewClusters(run RunFunc) *Clusters {
+	return &Clusters{
+		run:           run,
+		retryInterval: defaultRetryInterval,
+		entries:       map[string]*entry{},
+	}
+}
+
+// Apply adds or updates the cluster id under key from a kubeconfig. It fails if the kubeconfig is
+// invalid or another key already provides id; the running cluster is then left untouched.
+func (c *Clusters) Apply(key, id string, kubeconfig []byte) error {
+	hash := sha256.Sum256(append([]byte(id+"\x00"), kubeconfig...))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if current, ok := c.entries[key]; ok && current.hash == hash {
+		return nil
+	}
+	for other, e := range c.entries {
+		if other != key && e.id == id {
+			return fmt.Errorf("cluster %q is already provided by %s", id, other)
+		}
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig for cluster %q: %w", id, err)
+	}
+
+	c.stopLocked(key)
+	e := &entry{id: id, hash: hash, config: config}
+	c.entries[key] = e
+	if c.ctx != nil && c.ctx.Err() == nil {
+		c.startLocked(e)
+	}
+	clustersConfigured.Set(float64(len(c.entries)))
+	return nil
+}
+
+// Remove stops and forgets the cluster added under key, if any.
+func (c *Clusters) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopLocked(key)
+	delete(c.entries, key)
+	clustersConfigured.Set(float64(len(c.entries)))
+}
+
+// Keys returns the keys of the configured clusters, sorted.
+func (c *Clusters) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IDs returns the identities of the configured clusters, sorted.
+func (c *Clusters) IDs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.entries))
+	for _, e := range c.entries {
+		ids = append(ids, e.id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Start starts the configured clusters and those added later, and stops them all when ctx is done.
+func (c *Clusters) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	for _, e := range c.entries {
+		c.startLocked(e)
+	}
+	c.mu.Unlock()
+
+	<-ctx.Done()
+	c.wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection reports that remote clusters are only observed by the leader, like the local one.
+func (c *Clusters) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *Clusters) startLocked(e *entry) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer close(e.done)
+		c.runUntilStopped(ctx, e)
+	}()
+}
+
+// stopLocked cancels the cluster under key and waits for its controllers to stop, so that a
+// restarted cluster never runs twice.
+func (c *Clusters) stopLocked(key string) {
+	e, ok := c.entries[key]
+	if !ok || e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+}
+
+func (c *Clusters) runUntilStopped(ctx context.Context, e *entry) {
+	log := logf.FromContext(ctx).WithValues("cluster", e.id)
+	for {
+		log.Info("Starting remote cluster")
+		clustersRunning.Inc()
+		err := c.run(ctx, e.id, rest.CopyConfig(e.config))
+		clustersRunning.Dec()
+		if ctx.Err() != nil {
+			log.Info("Stopped remote cluster")
+			return
+		}
+		clusterFailures.WithLabelValues(e.id).Inc()
+		log.Error(err, "remote cluster stopped, retrying", "retryAfter", c.retryInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.retryInterval):
+		}
+	}
+}
+
+// SecretKey returns the key of the cluster provided by a Secret.
+func SecretKey(namespace, name string) string {
+	return "secret:" + namespace + "/" + name
+}
+
+// FromSecret returns the identity and kubeconfig of the cluster a Secret provides.
+func FromSecret(secret *corev1.Secret) (string, []byte, error) {
+	id := secret.Labels[ClusterLabel]
+	if id == "" {
+		id = secret.Name
+	}
+	kubeconfig := secret.Data[KubeconfigKey]
+	if len(kubeconfig) == 0 {
+		kubeconfig = secret.Data["value"]
+	}
+	if len(kubeconfig) == 0 {
+		return "", nil, fmt.Errorf("secret %s/%s has no %q key", secret.Namespace, secret.Name, KubeconfigKey)
+	}
+	return id, kubeconfig, nil
+}
+
+// WatchSecrets restricts the cache of Secrets to the kubeconfig Secrets in namespace, so that the
+// Observer neither caches nor needs access to any other Secret.
+func WatchSecrets(opts *cache.Options, namespace string) {
+	if opts.ByObject == nil {
+		opts.ByObject = map[client.Object]cache.ByObject{}
+	}
+	labelled, _ := labels.NewRequirement(ClusterLabel, selection.Exists, nil)
+	opts.ByObject[&corev1.Secret{}] = cache.ByObject{
+		Namespaces: map[string]cache.Config{namespace: {}},
+		Label:      labels.NewSelector().Add(*labelled),
+	}
+}
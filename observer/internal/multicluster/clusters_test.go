@@ -0,0 +1,208 @@
+package multicluster_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"kube-mind/observer/internal/multicluster"
+)
+
+func kubeconfig(server string) []byte {
+	return []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: ` + server + `
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: observer
+users:
+- name: observer
+  user:
+    token: secret-token
+current-context: remote
+`)
+}
+
+// recorder is a RunFunc recording which clusters are running and with which API server.
+type recorder struct {
+	mu      sync.Mutex
+	running map[string]string
+	starts  map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{running: map[string]string{}, starts: map[string]int{}}
+}
+
+func (r *recorder) run(ctx context.Context, id string, config *rest.Config) error {
+	r.mu.Lock()
+	if _, ok := r.running[id]; ok {
+		r.mu.Unlock()
+		panic("cluster " + id + " started twice")
+	}
+	r.running[id] = config.Host
+	r.starts[id]++
+	r.mu.Unlock()
+
+	<-ctx.Done()
+
+	r.mu.Lock()
+	delete(r.running, id)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *recorder) snapshot() (map[string]string, map[string]int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	running, starts := map[string]string{}, map[string]int{}
+	for id, host := range r.running {
+		running[id] = host
+	}
+	for id, n := range r.starts {
+		starts[id] = n
+	}
+	return running, starts
+}
+
+func (r *recorder) waitFor(t *testing.T, want map[string]string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		running, _ := r.snapshot()
+		return assert.ObjectsAreEqual(want, running)
+	}, time.Second, 5*time.Millisecond)
+}
+
+func startClusters(t *testing.T, clusters *multicluster.Clusters) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- clusters.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func TestClusters(t *testing.T) {
+	t.Parallel()
+
+	rec := newRecorder()
+	clusters := multicluster.NewClusters(rec.run)
+	require.NoError(t, clusters.Apply("secret:a", "prod-eu", kubeconfig("https://eu.example.com")))
+
+	running, _ := rec.snapshot()
+	assert.Empty(t, running, "clusters only start with the runnable")
+	startClusters(t, clusters)
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu.example.com"})
+
+	// Adding a cluster at runtime.
+	require.NoError(t, clusters.Apply("secret:b", "prod-us", kubeconfig("https://us.example.com")))
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu.example.com", "prod-us": "https://us.example.com"})
+	assert.Equal(t, []string{"prod-eu", "prod-us"}, clusters.IDs())
+
+	// Reapplying the same kubeconfig keeps the cluster running; a new one restarts it.
+	require.NoError(t, clusters.Apply("secret:a", "prod-eu", kubeconfig("https://eu.example.com")))
+	require.NoError(t, clusters.Apply("secret:a", "prod-eu", kubeconfig("https://eu2.example.com")))
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu2.example.com", "prod-us": "https://us.example.com"})
+	_, starts := rec.snapshot()
+	assert.Equal(t, map[string]int{"prod-eu": 2, "prod-us": 1}, starts)
+
+	// Invalid and duplicate clusters leave the running ones untouched.
+	assert.ErrorContains(t, clusters.Apply("secret:a", "prod-eu", []byte("not a kubeconfig")), "invalid kubeconfig")
+	assert.ErrorContains(t, clusters.Apply("secret:c", "prod-us", kubeconfig("https://other.example.com")), "already provided by secret:b")
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu2.example.com", "prod-us": "https://us.example.com"})
+
+	clusters.Remove("secret:b")
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu2.example.com"})
+	assert.Equal(t, []string{"secret:a"}, clusters.Keys())
+}
+
+func TestDirectory_Sync(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod-eu.yaml"), kubeconfig("https://eu.example.com"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod-us"), kubeconfig("https://us.example.com"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("{"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+
+	rec := newRecorder()
+	clusters := multicluster.NewClusters(rec.run)
+	startClusters(t, clusters)
+	directory := multicluster.NewDirectory(dir, clusters)
+
+	assert.ErrorContains(t, directory.Sync(), "broken")
+	assert.Equal(t, []string{"prod-eu", "prod-us"}, clusters.IDs())
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu.example.com", "prod-us": "https://us.example.com"})
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "prod-us")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "broken.yaml")))
+	require.NoError(t, directory.Sync())
+	assert.Equal(t, []string{"prod-eu"}, clusters.IDs())
+	rec.waitFor(t, map[string]string{"prod-eu": "https://eu.example.com"})
+}
+
+func TestFromSecret(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		secret  *corev1.Secret
+		wantID  string
+		wantErr string
+	}{
+		{
+			name: "identity from label",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "eu-kubeconfig", Labels: map[string]string{multicluster.ClusterLabel: "prod-eu"}},
+				Data:       map[string][]byte{multicluster.KubeconfigKey: kubeconfig("https://eu.example.com")},
+			},
+			wantID: "prod-eu",
+		},
+		{
+			name: "Cluster API secret named after the cluster",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-us", Labels: map[string]string{multicluster.ClusterLabel: ""}},
+				Data:       map[string][]byte{"value": kubeconfig("https://us.example.com")},
+			},
+			wantID: "prod-us",
+		},
+		{
+			name: "missing kubeconfig",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "empty", Labels: map[string]string{multicluster.ClusterLabel: ""}},
+			},
+			wantErr: `no "kubeconfig" key`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			id, config, err := multicluster.FromSecret(tc.secret)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, id)
+			assert.NotEmpty(t, config)
+		})
+	}
+}
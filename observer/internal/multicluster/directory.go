@@ -0,0 +1,86 @@
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultDirectoryInterval = 30 * time.Second
+	directoryKeyPrefix       = "file:"
+)
+
+// Directory adds a cluster for every kubeconfig file in a directory, such as a mounted Secret,
+// and polls it for added, changed and removed files. The cluster identity is the file name
+// without its extension.
+type Directory struct {
+	Path     string
+	Clusters *Clusters
+	Interval time.Duration
+}
+
+// NewDirectory creates a Directory adding the kubeconfigs in path to clusters.
+func NewDirectory(path string, clusters *Clusters) *Directory {
+	return &Directory{Path: path, Clusters: clusters, Interval: defaultDirectoryInterval}
+}
+
+// Sync applies the kubeconfigs currently in the directory and removes the clusters of deleted
+// files. Invalid files are reported in the error and do not stop the others from being applied.
+func (d *Directory) Sync() error {
+	files, err := os.ReadDir(d.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig directory %s: %w", d.Path, err)
+	}
+	seen := map[string]bool{}
+	var errs []string
+	for _, file := range files {
+		// Mounted Secrets hold their data in hidden, timestamped directories behind symlinks.
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(d.Path, file.Name())
+		key := directoryKeyPrefix + path
+		seen[key] = true
+		kubeconfig, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		id := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if err := d.Clusters.Apply(key, id, kubeconfig); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, key := range d.Clusters.Keys() {
+		if strings.HasPrefix(key, directoryKeyPrefix+d.Path+string(filepath.Separator)) && !seen[key] {
+			d.Clusters.Remove(key)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to load kubeconfigs: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Start polls the directory until ctx is done.
+func (d *Directory) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithValues("path", d.Path)
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Sync(); err != nil {
+			log.Error(err, "failed to sync remote clusters from directory")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package multicluster
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	clustersConfigured = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kubemind_observer_remote_clusters",
+		Help: "Number of remote clusters configured from kubeconfig Secrets and files.",
+	})
+
+	clustersRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kubemind_observer_remote_clusters_running",
+		Help: "Number of remote clusters whose controllers are running.",
+	})
+
+	clusterFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubemind_observer_remote_cluster_failures_total",
+		Help: "Number of times the controllers of a remote cluster stopped with an error and were retried.",
+	}, []string{"cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(clustersConfigured, clustersRunning, clusterFailures)
+}
@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"kube-mind/observer/internal/domain"
+)
+
+// Annotations let teams tune how their own workloads are observed without editing the
+// cluster-wide configuration. They are read from the pod, its owners and its namespace, and
+// the nearest object setting a key wins over the ObserverPolicy and the global settings.
+const (
+	AnnotationPrefix = "observer.kube-mind.io/"
+	// AnnotationDisabled set to "true" stops observing a workload; "false" on a nearer object
+	// opts it back in, even if its ObserverPolicy ignores it.
+	AnnotationDisabled = AnnotationPrefix + "disabled"
+	// AnnotationLogTailLines sets the number of log lines collected per container.
+	AnnotationLogTailLines = AnnotationPrefix + "log-tail-lines"
+	// AnnotationExtraContainers is a comma-separated list of containers whose logs are
+	// collected even when they did not fail, such as a proxy sidecar.
+	AnnotationExtraContainers = AnnotationPrefix + "extra-containers"
+	// AnnotationSeverity is the severity the owning team assigns to the workload's incidents.
+	AnnotationSeverity = AnnotationPrefix + "severity"
+	// AnnotationTeam is the team owning the workload.
+	AnnotationTeam = AnnotationPrefix + "team"
+	// AnnotationPendingThreshold is how long a pod may stay Pending before it is an incident,
+	// as a duration such as "20m", for workloads that legitimately wait for capacity.
+	AnnotationPendingThreshold = AnnotationPrefix + "pending-threshold"
+	// AnnotationNotReadyThreshold is how long a running container may fail its readiness probe
+	// before it is an incident, as a duration such as "2m".
+	AnnotationNotReadyThreshold = AnnotationPrefix + "not-ready-threshold"
+	// AnnotationCronJobFailedRuns is how many runs of a CronJob must fail in a row before it is
+	// an incident, for CronJobs running often enough that a single failure is expected.
+	AnnotationCronJobFailedRuns = AnnotationPrefix + "cronjob-failed-runs"
+)
+
+var annotationKeys = []string{
+	AnnotationDisabled,
+	AnnotationLogTailLines,
+	AnnotationExtraContainers,
+	AnnotationSeverity,
+	AnnotationTeam,
+	AnnotationPendingThreshold,
+	AnnotationNotReadyThreshold,
+	AnnotationCronJobFailedRuns,
+}
+
+// Annotated is an object whose annotations apply to a pod: the pod, an owner or the namespace.
+type Annotated struct {
+	// Ref names the object as Kind/name.
+	Ref         string
+	Annotations map[string]string
+}
+
+// Annotate applies the annotations of objects, ordered nearest first, over the settings. Invalid
+// values are skipped and reported in the error, which is returned alongside the settings.
+func (e *Effective) Annotate(objects []Annotated) (*Effective, error) {
+	effective := *e
+	effective.AnnotatedBy = nil
+	applied := map[string]bool{}
+	var errs []error
+	for _, obj := range objects {
+		used := false
+		for _, key := range annotationKeys {
+			value, ok := obj.Annotations[key]
+			if !ok || applied[key] {
+				continue
+			}
+			if err := effective.annotate(key, strings.TrimSpace(value)); err != nil {
+				errs = append(errs, fmt.Errorf("annotation %s on %s: %w", key, obj.Ref, err))
+				continue
+			}
+			applied[key] = true
+			used = true
+		}
+		if used {
+			effective.AnnotatedBy = append(effective.AnnotatedBy, obj.Ref)
+		}
+	}
+	return &effective, errors.Join(errs...)
+}
+
+func (e *Effective) annotate(key, value string) error {
+	switch key {
+	case AnnotationDisabled:
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		e.Ignore = disabled
+	case AnnotationLogTailLines:
+		lines, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || lines < 1 || lines > domain.MaxLogTailLines {
+			return fmt.Errorf("%q is not a number of lines between 1 and %d", value, domain.MaxLogTailLines)
+		}
+		e.LogTailLines = lines
+	case AnnotationExtraContainers:
+		var containers []string
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				containers = append(containers, name)
+			}
+		}
+		e.ExtraContainers = containers
+	case AnnotationPendingThreshold, AnnotationNotReadyThreshold:
+		threshold, err := time.ParseDuration(value)
+		if err != nil || threshold <= 0 {
+			return fmt.Errorf("%q is not a positive duration", value)
+		}
+		if key == AnnotationPendingThreshold {
+			e.PendingThreshold = threshold
+		} else {
+			e.NotReadyThreshold = threshold
+		}
+	case AnnotationCronJobFailedRuns:
+		runs, err := strconv.Atoi(value)
+		if err != nil || runs < 1 || runs > domain.MaxCronJobRuns {
+			return fmt.Errorf("%q is not a number of runs between 1 and %d", value, domain.MaxCronJobRuns)
+		}
+		e.CronJobFailedRuns = runs
+	case AnnotationSeverity, AnnotationTeam:
+		if value == "" {
+			return errors.New("value is empty")
+		}
+		if key == AnnotationSeverity {
+			e.Severity = value
+		} else {
+			e.Team = value
+		}
+	}
+	return nil
+}
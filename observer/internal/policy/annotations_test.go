@@ -0,0 +1,127 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+)
+
+func TestEffective_Annotate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		ignored   bool
+		objects   []policy.Annotated
+		expected  func(*policy.Effective)
+		expectErr string
+	}{
+		{
+			name:     "no annotations",
+			objects:  []policy.Annotated{{Ref: "Pod/api-1"}},
+			expected: func(*policy.Effective) {},
+		},
+		{
+			name: "namespace opt-out",
+			objects: []policy.Annotated{
+				{Ref: "Pod/api-1"},
+				{Ref: "Namespace/payments", Annotations: map[string]string{policy.AnnotationDisabled: "true"}},
+			},
+			expected: func(e *policy.Effective) {
+				e.Ignore = true
+				e.AnnotatedBy = []string{"Namespace/payments"}
+			},
+		},
+		{
+			name:    "nearest object wins",
+			ignored: true,
+			objects: []policy.Annotated{
+				{Ref: "Pod/api-1", Annotations: map[string]string{policy.AnnotationTeam: "checkout"}},
+				{Ref: "Deployment/api", Annotations: map[string]string{
+					policy.AnnotationDisabled:        "false",
+					policy.AnnotationTeam:            "payments",
+					policy.AnnotationExtraContainers: "istio-proxy, ,log-shipper",
+				}},
+				{Ref: "Namespace/payments", Annotations: map[string]string{
+					policy.AnnotationDisabled: "true",
+					policy.AnnotationSeverity: "critical",
+				}},
+			},
+			expected: func(e *policy.Effective) {
+				e.Ignore = false
+				e.Team = "checkout"
+				e.Severity = "critical"
+				e.ExtraContainers = []string{"istio-proxy", "log-shipper"}
+				e.AnnotatedBy = []string{"Pod/api-1", "Deployment/api", "Namespace/payments"}
+			},
+		},
+		{
+			name: "invalid values are skipped",
+			objects: []policy.Annotated{
+				{Ref: "Pod/api-1", Annotations: map[string]string{policy.AnnotationLogTailLines: "100000"}},
+				{Ref: "Deployment/api", Annotations: map[string]string{policy.AnnotationLogTailLines: "500"}},
+			},
+			expected: func(e *policy.Effective) {
+				e.LogTailLines = 500
+				e.AnnotatedBy = []string{"Deployment/api"}
+			},
+			expectErr: "annotation observer.kube-mind.io/log-tail-lines on Pod/api-1",
+		},
+		{
+			name: "thresholds",
+			objects: []policy.Annotated{
+				{Ref: "Pod/batch-1", Annotations: map[string]string{policy.AnnotationPendingThreshold: "0s"}},
+				{Ref: "Job/batch", Annotations: map[string]string{
+					policy.AnnotationPendingThreshold:  "45m",
+					policy.AnnotationNotReadyThreshold: "90s",
+				}},
+			},
+			expected: func(e *policy.Effective) {
+				e.PendingThreshold = 45 * time.Minute
+				e.NotReadyThreshold = 90 * time.Second
+				e.AnnotatedBy = []string{"Job/batch"}
+			},
+			expectErr: "annotation observer.kube-mind.io/pending-threshold on Pod/batch-1",
+		},
+		{
+			name: "cronjob failed runs",
+			objects: []policy.Annotated{
+				{Ref: "Job/report-28812345", Annotations: map[string]string{policy.AnnotationCronJobFailedRuns: "11"}},
+				{Ref: "CronJob/report", Annotations: map[string]string{policy.AnnotationCronJobFailedRuns: "3"}},
+			},
+			expected: func(e *policy.Effective) {
+				e.CronJobFailedRuns = 3
+				e.AnnotatedBy = []string{"CronJob/report"}
+			},
+			expectErr: "annotation observer.kube-mind.io/cronjob-failed-runs on Job/report-28812345",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			defaults := policy.Defaults(&config.ControllerConfig{})
+			defaults.Ignore = tc.ignored
+			expected := *defaults
+			tc.expected(&expected)
+
+			effective, err := defaults.Annotate(tc.objects)
+
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, &expected, effective)
+			assert.Equal(t, int64(domain.DefaultLogTailLines), defaults.LogTailLines, "the input settings are not modified")
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Package policy resolves the ObserverPolicy that applies to a pod into the effective settings
+// used to detect, harvest and deliver its incidents.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+)
+
+const defaultReplacement = "[REDACTED]"
+
+// DefaultReasons are the reasons that count as incidents when no policy sets them.
+var DefaultReasons = []string{
+	domain.ReasonCrashLoopBackOff,
+	domain.ReasonImagePullBackOff,
+	domain.ReasonErrImagePull,
+	domain.ReasonOOMKilled,
+	domain.ReasonError,
+	domain.ReasonUnschedulable,
+	domain.ReasonPendingTimeout,
+	domain.ReasonEvicted,
+	domain.ReasonPreempted,
+	domain.ReasonReadinessProbeFailed,
+	domain.ReasonProgressDeadlineExceeded,
+	domain.ReasonRolloutStalled,
+	domain.ReasonBackoffLimitExceeded,
+	domain.ReasonDeadlineExceeded,
+	domain.ReasonJobFailed,
+	domain.ReasonCronJobFailing,
+}
+
+// AllCollectors are the collectors that run when no policy restricts them.
+var AllCollectors = []observerv1alpha1.Collector{
+	observerv1alpha1.CollectorLogs,
+	observerv1alpha1.CollectorPreviousLogs,
+	observerv1alpha1.CollectorEvents,
+	observerv1alpha1.CollectorManifests,
+}
+
+// Effective holds the settings that apply to one pod.
+type Effective struct {
+	// Policy is the name of the applied ObserverPolicy, empty for the global settings.
+	Policy       string
+	Ignore       bool
+	Reasons      []string
+	LogTailLines int64
+	Collectors   []observerv1alpha1.Collector
+	DebounceTTL  time.Duration
+	// PendingThreshold is how long a pod may stay Pending before it is an incident; zero never.
+	PendingThreshold time.Duration
+	// NotReadyThreshold is how long a running container may stay not ready before it is an
+	// incident; zero never.
+	NotReadyThreshold time.Duration
+	// CronJobFailedRuns is how many runs of a CronJob must fail in a row before it is an incident.
+	CronJobFailedRuns int
+	// Sinks overrides the namespace's routes when not empty.
+	Sinks []string
+	// ExtraContainers are collected alongside failing containers, as set by annotations.
+	ExtraContainers []string
+	// Severity and Team are set by the owning team through annotations.
+	Severity string
+	Team     string
+	// AnnotatedBy lists the objects whose annotations were applied, nearest first.
+	AnnotatedBy []string
+	redaction   []rule
+}
+
+type rule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// Defaults returns the global settings from the Observer's configuration.
+func Defaults(cfg *config.ControllerConfig) *Effective {
+	return &Effective{
+		Reasons:           DefaultReasons,
+		LogTailLines:      domain.DefaultLogTailLines,
+		Collectors:        AllCollectors,
+		DebounceTTL:       cfg.DebounceTTLSeconds,
+		PendingThreshold:  cfg.PendingThreshold,
+		NotReadyThreshold: cfg.NotReadyThreshold,
+		CronJobFailedRuns: cfg.CronJobFailedRuns,
+	}
+}
+
+// IsIncidentReason reports whether a container reason counts as an incident.
+func (e *Effective) IsIncidentReason(reason string) bool {
+	return slices.Contains(e.Reasons, reason)
+}
+
+// Collects reports whether a collector runs.
+func (e *Effective) Collects(collector observerv1alpha1.Collector) bool {
+	return slices.Contains(e.Collectors, collector)
+}
+
+// CollectsContainer reports whether a container's logs are collected although it did not fail.
+func (e *Effective) CollectsContainer(name string) bool {
+	return slices.Contains(e.ExtraContainers, name)
+}
+
+// Redact applies the policy's extra redaction rules.
+func (e *Effective) Redact(value string) string {
+	for _, r := range e.redaction {
+		value = r.re.ReplaceAllString(value, r.replacement)
+	}
+	return value
+}
+
+// Compile applies a policy over the defaults. It fails if a redaction pattern is invalid.
+func Compile(policy *observerv1alpha1.ObserverPolicy, defaults *Effective) (*Effective, error) {
+	effective := *defaults
+	effective.Policy = policy.Name
+	spec := policy.Spec
+
+	effective.Ignore = spec.Ignore
+	if len(spec.Reasons) > 0 {
+		effective.Reasons = spec.Reasons
+	}
+	if spec.LogTailLines != nil {
+		effective.LogTailLines = *spec.LogTailLines
+	}
+	if len(spec.Collectors) > 0 {
+		effective.Collectors = spec.Collectors
+	}
+	if spec.DebounceTTL != nil {
+		effective.DebounceTTL = spec.DebounceTTL.Duration
+	}
+	if len(spec.Sinks) > 0 {
+		effective.Sinks = spec.Sinks
+	}
+	effective.redaction = append([]rule(nil), defaults.redaction...)
+	for i, r := range spec.Redaction {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule %d: %w", i, err)
+		}
+		replacement := r.Replacement
+		if replacement == "" {
+			replacement = defaultReplacement
+		}
+		effective.redaction = append(effective.redaction, rule{re: re, replacement: replacement})
+	}
+	return &effective, nil
+}
+
+// Matches reports whether a policy selects a pod in a namespace with the given labels.
+func Matches(policy *observerv1alpha1.ObserverPolicy, pod *corev1.Pod, namespaceLabels map[string]string) (bool, error) {
+	spec := policy.Spec
+	if len(spec.Namespaces) > 0 && !slices.Contains(spec.Namespaces, pod.Namespace) {
+		return false, nil
+	}
+	for _, sel := range []struct {
+		selector *metav1.LabelSelector
+		labels   map[string]string
+	}{{spec.NamespaceSelector, namespaceLabels}, {spec.Selector, pod.Labels}} {
+		if sel.selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(sel.selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid selector in policy %s: %w", policy.Name, err)
+		}
+		if !selector.Matches(labels.Set(sel.labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Select returns the policy that applies to a pod, or nil if none matches. Policies with
+// invalid selectors or redaction patterns, or that their controller found invalid, are skipped
+// and logged, so that the next matching policy or the defaults apply instead.
+func Select(ctx context.Context, policies []observerv1alpha1.ObserverPolicy, pod *corev1.Pod, namespaceLabels map[string]string) *observerv1alpha1.ObserverPolicy {
+	log := logf.FromContext(ctx)
+
+	var matching []*observerv1alpha1.ObserverPolicy
+	for i := range policies {
+		ok, err := Matches(&policies[i], pod, namespaceLabels)
+		if err == nil && ok {
+			err = usable(&policies[i])
+		}
+		if err != nil {
+			log.Error(err, "skipping invalid observer policy", "policy", policies[i].Name)
+			continue
+		}
+		if ok {
+			matching = append(matching, &policies[i])
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		a, b := matching[i], matching[j]
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority > b.Spec.Priority
+		}
+		if specificity(a) != specificity(b) {
+			return specificity(a) > specificity(b)
+		}
+		return a.Name < b.Name
+	})
+	return matching[0]
+}
+
+// usable returns why a policy cannot be applied, or nil. A Valid condition that is False for the
+// current generation is trusted, as it may involve checks made elsewhere, such as sink names.
+func usable(policy *observerv1alpha1.ObserverPolicy) error {
+	condition := meta.FindStatusCondition(policy.Status.Conditions, observerv1alpha1.ObserverPolicyValid)
+	if condition != nil && condition.Status == metav1.ConditionFalse && condition.ObservedGeneration == policy.Generation {
+		return fmt.Errorf("policy is invalid: %s", condition.Message)
+	}
+	if _, err := Compile(policy, &Effective{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// specificity ranks pod selectors above namespace restrictions above match-all policies.
+func specificity(policy *observerv1alpha1.ObserverPolicy) int {
+	score := 0
+	if policy.Spec.Selector != nil {
+		score += 2
+	}
+	if len(policy.Spec.Namespaces) > 0 || policy.Spec.NamespaceSelector != nil {
+		score++
+	}
+	return score
+}
+
+// Resolver looks up the effective settings of pods from the ObserverPolicies in the cluster.
+type Resolver struct {
+	Client   client.Reader
+	Defaults *Effective
+}
+
+// NewResolver creates a Resolver falling back to the global settings of cfg.
+func NewResolver(c client.Reader, cfg *config.ControllerConfig) *Resolver {
+	return &Resolver{Client: c, Defaults: Defaults(cfg)}
+}
+
+// For returns the effective settings of a pod. Invalid policies are skipped, as in Select.
+func (r *Resolver) For(ctx context.Context, pod *corev1.Pod) (*Effective, error) {
+	var policies observerv1alpha1.ObserverPolicyList
+	if err := r.Client.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list observer policies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return r.Defaults, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: pod.Namespace}, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", pod.Namespace, err)
+	}
+	policy := Select(ctx, policies.Items, pod, namespace.Labels)
+	if policy == nil {
+		return r.Defaults, nil
+	}
+	effective, err := Compile(policy, r.Defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid observer policy %s: %w", policy.Name, err)
+	}
+	return effective, nil
+}
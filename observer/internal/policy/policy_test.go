@@ -0,0 +1,170 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/config"
+	"kube-mind/observer/internal/domain"
+	"kube-mind/observer/internal/policy"
+)
+
+func newPolicy(name string, priority int32, mutate func(*observerv1alpha1.ObserverPolicySpec)) observerv1alpha1.ObserverPolicy {
+	p := observerv1alpha1.ObserverPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       observerv1alpha1.ObserverPolicySpec{Priority: priority},
+	}
+	if mutate != nil {
+		mutate(&p.Spec)
+	}
+	return p
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "payments", Labels: map[string]string{"app": "api"}}}
+	namespaceLabels := map[string]string{"team": "payments"}
+
+	everything := newPolicy("everything", 0, nil)
+	inNamespace := newPolicy("in-namespace", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Namespaces = []string{"payments"}
+	})
+	byTeam := newPolicy("by-team", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+	})
+	byApp := newPolicy("by-app", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	})
+	otherApp := newPolicy("other-app", 10, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	})
+	urgent := newPolicy("urgent", 5, nil)
+	badPattern := newPolicy("bad-pattern", 20, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Redaction = []observerv1alpha1.RedactionRule{{Pattern: "("}}
+	})
+	unknownSink := newPolicy("unknown-sink", 20, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Sinks = []string{"pager"}
+	})
+	unknownSink.Status.Conditions = []metav1.Condition{{Type: observerv1alpha1.ObserverPolicyValid, Status: metav1.ConditionFalse, Message: `unknown sink "pager"`}}
+	staleCondition := *unknownSink.DeepCopy()
+	staleCondition.Name = "fixed-sink"
+	staleCondition.Generation = 2
+
+	testCases := []struct {
+		name     string
+		policies []observerv1alpha1.ObserverPolicy
+		expected string
+	}{
+		{name: "No policies", expected: ""},
+		{name: "Non-matching selector", policies: []observerv1alpha1.ObserverPolicy{otherApp}, expected: ""},
+		{name: "Pod selector beats namespace", policies: []observerv1alpha1.ObserverPolicy{inNamespace, byApp, everything}, expected: "by-app"},
+		{name: "Namespace beats everything", policies: []observerv1alpha1.ObserverPolicy{everything, byTeam}, expected: "by-team"},
+		{name: "Priority beats specificity", policies: []observerv1alpha1.ObserverPolicy{byApp, urgent, otherApp}, expected: "urgent"},
+		{name: "Name breaks ties", policies: []observerv1alpha1.ObserverPolicy{inNamespace, byTeam}, expected: "by-team"},
+		{name: "Invalid pattern skipped", policies: []observerv1alpha1.ObserverPolicy{badPattern, byApp}, expected: "by-app"},
+		{name: "Policy found invalid skipped", policies: []observerv1alpha1.ObserverPolicy{unknownSink}, expected: ""},
+		{name: "Condition of an older generation ignored", policies: []observerv1alpha1.ObserverPolicy{staleCondition, byApp}, expected: "fixed-sink"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected := policy.Select(context.Background(), tc.policies, pod, namespaceLabels)
+
+			if tc.expected == "" {
+				assert.Nil(t, selected)
+				return
+			}
+			require.NotNil(t, selected)
+			assert.Equal(t, tc.expected, selected.Name)
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	defaults := policy.Defaults(&config.ControllerConfig{DebounceTTLSeconds: 5 * time.Minute})
+	tail := int64(50)
+	p := newPolicy("strict", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Reasons = []string{"CreateContainerConfigError"}
+		s.LogTailLines = &tail
+		s.Collectors = []observerv1alpha1.Collector{observerv1alpha1.CollectorLogs}
+		s.DebounceTTL = &metav1.Duration{Duration: time.Hour}
+		s.Sinks = []string{"audit"}
+		s.Redaction = []observerv1alpha1.RedactionRule{
+			{Pattern: `card=\d+`},
+			{Pattern: `(user=)\w+`, Replacement: "${1}***"},
+		}
+	})
+
+	effective, err := policy.Compile(&p, defaults)
+	require.NoError(t, err)
+
+	assert.Equal(t, "strict", effective.Policy)
+	assert.True(t, effective.IsIncidentReason("CreateContainerConfigError"))
+	assert.False(t, effective.IsIncidentReason(domain.ReasonOOMKilled))
+	assert.Equal(t, int64(50), effective.LogTailLines)
+	assert.True(t, effective.Collects(observerv1alpha1.CollectorLogs))
+	assert.False(t, effective.Collects(observerv1alpha1.CollectorEvents))
+	assert.Equal(t, time.Hour, effective.DebounceTTL)
+	assert.Equal(t, []string{"audit"}, effective.Sinks)
+	assert.Equal(t, "paid with [REDACTED] by user=***", effective.Redact("paid with card=4242 by user=alice"))
+
+	assert.True(t, defaults.IsIncidentReason(domain.ReasonOOMKilled), "defaults must not be modified")
+	assert.Equal(t, int64(domain.DefaultLogTailLines), defaults.LogTailLines)
+
+	invalid := newPolicy("invalid", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Redaction = []observerv1alpha1.RedactionRule{{Pattern: "("}}
+	})
+	_, err = policy.Compile(&invalid, defaults)
+	assert.Error(t, err)
+}
+
+func TestResolver_For(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+	ignoreSandbox := newPolicy("ignore-sandbox", 0, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "sandbox"}}
+		s.Ignore = true
+	})
+	badPattern := newPolicy("bad-pattern", 10, func(s *observerv1alpha1.ObserverPolicySpec) {
+		s.Namespaces = []string{"payments"}
+		s.Redaction = []observerv1alpha1.RedactionRule{{Pattern: "("}}
+	})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Labels: map[string]string{"env": "sandbox"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&ignoreSandbox,
+		&badPattern,
+	).Build()
+	resolver := policy.NewResolver(c, &config.ControllerConfig{DebounceTTLSeconds: time.Minute})
+
+	sandboxed, err := resolver.For(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "sandbox"}})
+	require.NoError(t, err)
+	assert.Equal(t, "ignore-sandbox", sandboxed.Policy)
+	assert.True(t, sandboxed.Ignore)
+
+	payments, err := resolver.For(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "payments"}})
+	require.NoError(t, err, "a policy with an invalid pattern falls back to the defaults")
+	assert.Empty(t, payments.Policy)
+	assert.False(t, payments.Ignore)
+	assert.Equal(t, time.Minute, payments.DebounceTTL)
+}
@@ -0,0 +1,22 @@
+package shard
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	shardMembers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubemind_observer_shard_members",
+		Help: "Number of live members of the shard group, as seen by this replica.",
+	}, []string{"group"})
+
+	shardRebalances = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubemind_observer_shard_rebalances_total",
+		Help: "Number of shard membership changes seen by this replica.",
+	}, []string{"group"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(shardMembers, shardRebalances)
+}
@@ -0,0 +1,366 @@
+// Package shard splits the observed pods between active replicas. Each replica renews a Lease
+// of its own; the replicas with a live Lease are the members, and every pod is owned by one of
+// them through rendezvous hashing of its namespace or workload, so that membership changes
+// only move the pods of the members that joined or left.
+package shard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// GroupLabel labels the Leases of the members of a shard group.
+	GroupLabel = "observer.kube-mind.io/shard-group"
+	// DebounceAnnotation carries a member's debounce state for the members taking over its pods.
+	DebounceAnnotation = "observer.kube-mind.io/debounce"
+
+	// DefaultLeaseDuration is how long a member is considered alive after its last renewal.
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewInterval is how often members renew their Lease and refresh the membership.
+	DefaultRenewInterval = 5 * time.Second
+
+	// maxHandoverEntries bounds the debounce entries published in a Lease annotation.
+	maxHandoverEntries = 1000
+	// staleLeaseAge is how long the Lease of a departed member is kept for its handover.
+	staleLeaseAge = time.Hour
+)
+
+// Key selects how pods are split between members.
+type Key string
+
+const (
+	// KeyNamespace keeps every pod of a namespace on one member.
+	KeyNamespace Key = "namespace"
+	// KeyWorkload spreads the workloads of a namespace over the members.
+	KeyWorkload Key = "workload"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// DebounceState is the debounce cache handed over between members.
+type DebounceState interface {
+	Snapshot() map[string]time.Time
+	Restore(entries map[string]time.Time)
+}
+
+// Sharder maintains the membership of this replica in a shard group and decides which pods
+// it owns. It is a manager.Runnable that runs on every replica, without leader election.
+type Sharder struct {
+	// Client writes the Lease of this replica.
+	Client client.Client
+	// Reader lists the Leases of the group. It should bypass the cache, which may not cover
+	// the Lease namespace.
+	Reader    client.Reader
+	Namespace string
+	Group     string
+	Identity  string
+	Key       Key
+	// Debounce, if set, is published in the Lease and restored from the other members' Leases
+	// on membership changes, so that a pod changing owner is not reported twice.
+	Debounce      DebounceState
+	LeaseDuration time.Duration
+	RenewInterval time.Duration
+
+	mu        sync.RWMutex
+	members   []string
+	listeners []func(context.Context)
+}
+
+// NewSharder creates a Sharder for the replica identity in a shard group, with Leases in namespace.
+func NewSharder(c client.Client, reader client.Reader, namespace, group, identity string, key Key) (*Sharder, error) {
+	if key != KeyNamespace && key != KeyWorkload {
+		return nil, fmt.Errorf("unknown shard key %q, expected %q or %q", key, KeyNamespace, KeyWorkload)
+	}
+	if identity == "" {
+		return nil, fmt.Errorf("shard identity must not be empty")
+	}
+	return &Sharder{
+		Client:        c,
+		Reader:        reader,
+		Namespace:     namespace,
+		Group:         group,
+		Identity:      identity,
+		Key:           key,
+		LeaseDuration: DefaultLeaseDuration,
+		RenewInterval: DefaultRenewInterval,
+	}, nil
+}
+
+// OnRebalance registers fn to be called after the membership changed, once this replica may own
+// pods it did not own before.
+func (s *Sharder) OnRebalance(fn func(context.Context)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Members returns the identities of the live members, sorted.
+func (s *Sharder) Members() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.members)
+}
+
+// OwnsPod reports whether this replica observes a pod.
+func (s *Sharder) OwnsPod(pod *corev1.Pod) bool {
+	return s.Owns(PodKey(pod, s.Key))
+}
+
+// Owns reports whether this replica owns a shard key. Nothing is owned until the membership
+// has been read once.
+func (s *Sharder) Owns(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Owner(s.members, key) == s.Identity
+}
+
+// Owner returns the member owning a key by rendezvous hashing, or "" without members.
+func Owner(members []string, key string) string {
+	var owner string
+	var best uint64
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		if score := mix(h.Sum64()); owner == "" || score > best {
+			owner, best = member, score
+		}
+	}
+	return owner
+}
+
+// mix finalizes a hash, as FNV scores of keys differing in a few bytes are close together.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// PodKey returns the shard key of a pod. Workload keys strip the pod-template-hash of
+// ReplicaSets, so that a Deployment keeps its owner across rollouts.
+func PodKey(pod *corev1.Pod, key Key) string {
+	if key == KeyNamespace {
+		return pod.Namespace
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return WorkloadKey(pod.Namespace, "Pod", pod.Name, key)
+	}
+	kind, name := owner.Kind, owner.Name
+	if hash := pod.Labels["pod-template-hash"]; kind == "ReplicaSet" && hash != "" && strings.HasSuffix(name, "-"+hash) {
+		kind, name = "Deployment", strings.TrimSuffix(name, "-"+hash)
+	}
+	return WorkloadKey(pod.Namespace, kind, name, key)
+}
+
+// WorkloadKey returns the shard key of a workload, which its pods share.
+func WorkloadKey(namespace, kind, name string, key Key) string {
+	if key == KeyNamespace {
+		return namespace
+	}
+	return namespace + "/" + kind + "/" + name
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every replica is a member.
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the Lease of this replica and refreshes the membership until ctx is done. The
+// Lease is then expired so that the other members take over without waiting for it to lapse.
+func (s *Sharder) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithValues("shardGroup", s.Group, "identity", s.Identity)
+	ticker := time.NewTicker(s.RenewInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(ctx); err != nil {
+			log.Error(err, "failed to sync shard membership")
+		}
+		select {
+		case <-ctx.Done():
+			release, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.RenewInterval)
+			defer cancel()
+			if err := s.renew(release, time.Now().Add(-s.LeaseDuration)); err != nil {
+				log.Error(err, "failed to release shard lease")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync renews the Lease of this replica and applies the current membership once. Start calls it
+// every RenewInterval.
+func (s *Sharder) Sync(ctx context.Context) error {
+	now := time.Now()
+	if err := s.renew(ctx, now); err != nil {
+		return err
+	}
+
+	var leases coordinationv1.LeaseList
+	if err := s.Reader.List(ctx, &leases, client.InNamespace(s.Namespace), client.MatchingLabels{GroupLabel: s.Group}); err != nil {
+		return fmt.Errorf("failed to list shard leases: %w", err)
+	}
+	var members []string
+	handover := map[string]time.Time{}
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		holder := ptrValue(lease.Spec.HolderIdentity)
+		alive := isAlive(lease, now)
+		if alive {
+			members = append(members, holder)
+		} else if expiredFor(lease, now) > staleLeaseAge {
+			if err := s.Client.Delete(ctx, lease); client.IgnoreNotFound(err) != nil {
+				logf.FromContext(ctx).Error(err, "failed to delete stale shard lease", "lease", lease.Name)
+			}
+			continue
+		}
+		if holder != s.Identity {
+			for key, expiry := range decodeHandover(lease.Annotations[DebounceAnnotation]) {
+				handover[key] = expiry
+			}
+		}
+	}
+	if !slices.Contains(members, s.Identity) {
+		members = append(members, s.Identity)
+	}
+	sort.Strings(members)
+
+	s.mu.Lock()
+	changed := !slices.Equal(members, s.members)
+	s.members = members
+	listeners := slices.Clone(s.listeners)
+	s.mu.Unlock()
+	shardMembers.WithLabelValues(s.Group).Set(float64(len(members)))
+	if !changed {
+		return nil
+	}
+
+	logf.FromContext(ctx).Info("Shard membership changed", "shardGroup", s.Group, "members", members)
+	shardRebalances.WithLabelValues(s.Group).Inc()
+	if s.Debounce != nil {
+		s.Debounce.Restore(handover)
+	}
+	for _, listener := range listeners {
+		listener(ctx)
+	}
+	return nil
+}
+
+// renew creates or updates the Lease of this replica with the given renewal time.
+func (s *Sharder) renew(ctx context.Context, renewTime time.Time) error {
+	key := client.ObjectKey{Namespace: s.Namespace, Name: s.leaseName()}
+	lease := &coordinationv1.Lease{}
+	err := s.Reader.Get(ctx, key, lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get shard lease: %w", err)
+	}
+	create := apierrors.IsNotFound(err)
+	if create {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	}
+
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	lease.Labels[GroupLabel] = s.Group
+	if s.Debounce != nil {
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+		lease.Annotations[DebounceAnnotation] = encodeHandover(s.Debounce.Snapshot())
+	}
+	durationSeconds := int32(s.LeaseDuration / time.Second)
+	renew := metav1.NewMicroTime(renewTime)
+	lease.Spec.HolderIdentity = &s.Identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &renew
+	if lease.Spec.AcquireTime == nil {
+		lease.Spec.AcquireTime = &renew
+	}
+
+	if create {
+		err = s.Client.Create(ctx, lease)
+	} else {
+		err = s.Client.Update(ctx, lease)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to renew shard lease %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Sharder) leaseName() string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(s.Group+"-"+s.Identity), "-")
+	return strings.Trim(name, "-.")
+}
+
+func isAlive(lease *coordinationv1.Lease, now time.Time) bool {
+	return lease.Spec.HolderIdentity != nil && expiredFor(lease, now) < 0
+}
+
+// expiredFor returns how long ago a Lease expired, negative if it has not.
+func expiredFor(lease *coordinationv1.Lease, now time.Time) time.Duration {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return staleLeaseAge + time.Second
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Sub(expiry)
+}
+
+// encodeHandover serializes the debounce entries expiring last, as Unix seconds.
+func encodeHandover(entries map[string]time.Time) string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return entries[keys[i]].After(entries[keys[j]]) })
+	if len(keys) > maxHandoverEntries {
+		keys = keys[:maxHandoverEntries]
+	}
+	encoded := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		encoded[key] = entries[key].Unix()
+	}
+	data, _ := json.Marshal(encoded)
+	return string(data)
+}
+
+func decodeHandover(value string) map[string]time.Time {
+	var encoded map[string]int64
+	if value == "" || json.Unmarshal([]byte(value), &encoded) != nil {
+		return nil
+	}
+	entries := make(map[string]time.Time, len(encoded))
+	for key, expiry := range encoded {
+		entries[key] = time.Unix(expiry, 0)
+	}
+	return entries
+}
+
+func ptrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
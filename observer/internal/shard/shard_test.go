@@ -0,0 +1,222 @@
+package shard_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"kube-mind/observer/internal/shard"
+)
+
+// debounceState is an in-memory shard.DebounceState.
+type debounceState struct {
+	entries map[string]time.Time
+}
+
+func (d *debounceState) Snapshot() map[string]time.Time {
+	return d.entries
+}
+
+func (d *debounceState) Restore(entries map[string]time.Time) {
+	for key, expiry := range entries {
+		if _, ok := d.entries[key]; !ok {
+			d.entries[key] = expiry
+		}
+	}
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = coordinationv1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newSharder(t *testing.T, c client.Client, identity string) *shard.Sharder {
+	t.Helper()
+	s, err := shard.NewSharder(c, c, "observer-system", "observer", identity, shard.KeyNamespace)
+	require.NoError(t, err)
+	return s
+}
+
+func lease(identity string, renewed time.Time, annotations map[string]string) *coordinationv1.Lease {
+	duration := int32(15)
+	renew := metav1.NewMicroTime(renewed)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "observer-system",
+			Name:        "observer-" + identity,
+			Labels:      map[string]string{shard.GroupLabel: "observer"},
+			Annotations: annotations,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &identity,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renew,
+		},
+	}
+}
+
+func TestOwner(t *testing.T) {
+	t.Parallel()
+
+	members := []string{"observer-0", "observer-1", "observer-2"}
+	counts := map[string]int{}
+	moved := 0
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("namespace-%d", i)
+		owner := shard.Owner(members, key)
+		require.Contains(t, members, owner)
+		assert.Equal(t, owner, shard.Owner([]string{"observer-2", "observer-0", "observer-1"}, key), "order of members")
+		counts[owner]++
+
+		// Only the keys of the departed member move.
+		after := shard.Owner(members[:2], key)
+		if after != owner {
+			assert.Equal(t, "observer-2", owner)
+			moved++
+		}
+	}
+	for _, member := range members {
+		assert.InDelta(t, 1000, counts[member], 150, "keys owned by %s", member)
+	}
+	assert.Equal(t, counts["observer-2"], moved)
+	assert.Empty(t, shard.Owner(nil, "namespace-0"))
+}
+
+func TestPodKey(t *testing.T) {
+	t.Parallel()
+
+	controller := true
+	owned := func(kind, name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "payments",
+			Name:      "ledger-7d9f-abcde",
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: kind, Name: name, Controller: &controller},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		name string
+		pod  *corev1.Pod
+		key  shard.Key
+		want string
+	}{
+		{
+			name: "namespace",
+			pod:  owned("ReplicaSet", "ledger-7d9f", map[string]string{"pod-template-hash": "7d9f"}),
+			key:  shard.KeyNamespace,
+			want: "payments",
+		},
+		{
+			name: "Deployment across rollouts",
+			pod:  owned("ReplicaSet", "ledger-7d9f", map[string]string{"pod-template-hash": "7d9f"}),
+			key:  shard.KeyWorkload,
+			want: "payments/Deployment/ledger",
+		},
+		{
+			name: "bare ReplicaSet",
+			pod:  owned("ReplicaSet", "ledger", nil),
+			key:  shard.KeyWorkload,
+			want: "payments/ReplicaSet/ledger",
+		},
+		{
+			name: "StatefulSet",
+			pod:  owned("StatefulSet", "postgres", nil),
+			key:  shard.KeyWorkload,
+			want: "payments/StatefulSet/postgres",
+		},
+		{
+			name: "standalone pod",
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "debug"}},
+			key:  shard.KeyWorkload,
+			want: "payments/Pod/debug",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, shard.PodKey(tc.pod, tc.key))
+		})
+	}
+}
+
+func TestNewSharder_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := shard.NewSharder(newFakeClient(), newFakeClient(), "observer-system", "observer", "observer-0", "pod")
+	assert.ErrorContains(t, err, "unknown shard key")
+}
+
+func TestSharder_Sync(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := newFakeClient(
+		lease("observer-1", now, map[string]string{
+			shard.DebounceAnnotation: fmt.Sprintf(`{"payments/ledger/app":%d}`, now.Add(time.Minute).Unix()),
+		}),
+		lease("observer-2", now.Add(-time.Minute), nil),
+		lease("observer-3", now.Add(-2*time.Hour), nil),
+	)
+	s := newSharder(t, c, "observer-0")
+	debounce := &debounceState{entries: map[string]time.Time{"payments/api/app": now.Add(time.Minute)}}
+	s.Debounce = debounce
+	rebalanced := 0
+	s.OnRebalance(func(context.Context) { rebalanced++ })
+
+	assert.False(t, s.Owns("payments"), "nothing is owned before the first sync")
+	require.NoError(t, s.Sync(context.Background()))
+
+	assert.Equal(t, []string{"observer-0", "observer-1"}, s.Members(), "expired members are dropped")
+	assert.Equal(t, 1, rebalanced)
+	assert.Equal(t, shard.Owner(s.Members(), "payments") == "observer-0", s.Owns("payments"))
+	assert.Contains(t, debounce.entries, "payments/ledger/app", "debounce state handed over")
+
+	own := &coordinationv1.Lease{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "observer-system", Name: "observer-observer-0"}, own))
+	assert.Equal(t, "observer-0", *own.Spec.HolderIdentity)
+	assert.Equal(t, "observer", own.Labels[shard.GroupLabel])
+	assert.Contains(t, own.Annotations[shard.DebounceAnnotation], "payments/api/app")
+
+	stale := &coordinationv1.Lease{}
+	err := c.Get(context.Background(), client.ObjectKey{Namespace: "observer-system", Name: "observer-observer-3"}, stale)
+	assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "long-expired lease is deleted")
+
+	require.NoError(t, s.Sync(context.Background()))
+	assert.Equal(t, 1, rebalanced, "unchanged membership does not rebalance")
+}
+
+func TestSharder_StartReleasesLease(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClient()
+	s := newSharder(t, c, "observer-0")
+	s.RenewInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(s.Members()) == 1 }, time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	other := newSharder(t, c, "observer-1")
+	require.NoError(t, other.Sync(context.Background()))
+	assert.Equal(t, []string{"observer-1"}, other.Members(), "a stopped member leaves immediately")
+	assert.True(t, other.Owns("payments"))
+}
@@ -0,0 +1,16 @@
+package silence
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// incidentsSuppressed counts incidents recorded but not sent because a silence matched them.
+var incidentsSuppressed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kubemind_observer_incidents_suppressed_total",
+	Help: "Number of incidents not sent because an active silence matched them.",
+}, []string{"namespace", "silence", "reason"})
+
+func init() {
+	metrics.Registry.MustRegister(incidentsSuppressed)
+}
@@ -0,0 +1,311 @@
+// Package silence suppresses incidents matched by an active Silence, so that planned work such
+// as chaos tests and migrations does not flood the Brain with expected failures.
+package silence
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so that recurrence time zones resolve in minimal images without zoneinfo.
+	_ "time/tzdata"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	pb "kube-mind/observer/proto"
+)
+
+// lookahead bounds the search for the next recurrence boundary.
+const lookahead = 8 * 24 * time.Hour
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// Validate reports matchers and recurrences that cannot be evaluated.
+func Validate(spec *observerv1alpha1.SilenceSpec) error {
+	for _, m := range spec.Matchers {
+		if _, err := compile(m); err != nil {
+			return err
+		}
+	}
+	if spec.StartsAt != nil && spec.EndsAt != nil && !spec.EndsAt.After(spec.StartsAt.Time) {
+		return fmt.Errorf("endsAt must be after startsAt")
+	}
+	if spec.Recurrence != nil {
+		if _, err := parseRecurrence(spec.Recurrence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// State returns whether a silence is pending, active or expired at now.
+func State(spec *observerv1alpha1.SilenceSpec, now time.Time) (observerv1alpha1.SilenceState, error) {
+	if spec.EndsAt != nil && !now.Before(spec.EndsAt.Time) {
+		return observerv1alpha1.SilenceExpired, nil
+	}
+	if spec.StartsAt != nil && now.Before(spec.StartsAt.Time) {
+		return observerv1alpha1.SilencePending, nil
+	}
+	if spec.Recurrence != nil {
+		rec, err := parseRecurrence(spec.Recurrence)
+		if err != nil {
+			return "", err
+		}
+		if !rec.contains(now) {
+			return observerv1alpha1.SilencePending, nil
+		}
+	}
+	return observerv1alpha1.SilenceActive, nil
+}
+
+// NextTransition returns the next time after now at which the state of a silence may change,
+// or the zero time if it never will.
+func NextTransition(spec *observerv1alpha1.SilenceSpec, now time.Time) time.Time {
+	var candidates []time.Time
+	if spec.StartsAt != nil && spec.StartsAt.After(now) {
+		candidates = append(candidates, spec.StartsAt.Time)
+	}
+	if spec.EndsAt != nil && spec.EndsAt.After(now) {
+		candidates = append(candidates, spec.EndsAt.Time)
+	}
+	if spec.Recurrence != nil {
+		if rec, err := parseRecurrence(spec.Recurrence); err == nil {
+			if next := rec.nextBoundary(now); !next.IsZero() {
+				candidates = append(candidates, next)
+			}
+		}
+	}
+	var next time.Time
+	for _, t := range candidates {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// Matches reports whether every matcher of a silence selects the incident.
+func Matches(spec *observerv1alpha1.SilenceSpec, incident *pb.IncidentContext) (bool, error) {
+	for _, m := range spec.Matchers {
+		re, err := compile(m)
+		if err != nil {
+			return false, err
+		}
+		if re.MatchString(attribute(m.Name, incident)) == m.Negate {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func compile(m observerv1alpha1.Matcher) (*regexp.Regexp, error) {
+	pattern := regexp.QuoteMeta(m.Value)
+	if m.Regex {
+		pattern = m.Value
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("matcher %s: %w", m.Name, err)
+	}
+	return re, nil
+}
+
+func attribute(name observerv1alpha1.MatcherName, incident *pb.IncidentContext) string {
+	switch name {
+	case observerv1alpha1.MatchWorkload:
+		return pb.Workload(incident)
+	case observerv1alpha1.MatchWorkloadKind:
+		kind, _, _ := strings.Cut(pb.Workload(incident), "/")
+		return kind
+	case observerv1alpha1.MatchPod:
+		return incident.PodName
+	case observerv1alpha1.MatchContainer:
+		return incident.GetDetails().GetContainerName()
+	case observerv1alpha1.MatchReason:
+		return incident.FailureReason
+	case observerv1alpha1.MatchCluster:
+		return incident.ClusterId
+	default:
+		return ""
+	}
+}
+
+// recurrence is a parsed daily window.
+type recurrence struct {
+	days       map[time.Weekday]bool
+	start, end time.Duration
+	loc        *time.Location
+}
+
+func parseRecurrence(r *observerv1alpha1.Recurrence) (*recurrence, error) {
+	rec := &recurrence{loc: time.UTC}
+	if r.TimeZone != "" {
+		loc, err := time.LoadLocation(r.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("recurrence time zone: %w", err)
+		}
+		rec.loc = loc
+	}
+	if len(r.Weekdays) > 0 {
+		rec.days = map[time.Weekday]bool{}
+		for _, day := range r.Weekdays {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("recurrence weekday %q is not a day of the week", day)
+			}
+			rec.days[weekday] = true
+		}
+	}
+	var err error
+	if rec.start, err = parseClock(r.StartTime); err != nil {
+		return nil, fmt.Errorf("recurrence startTime: %w", err)
+	}
+	if rec.end, err = parseClock(r.EndTime); err != nil {
+		return nil, fmt.Errorf("recurrence endTime: %w", err)
+	}
+	if rec.end <= rec.start {
+		rec.end += 24 * time.Hour
+	}
+	return rec, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if !ok || errH != nil || errM != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("%q is not a time of day as HH:MM", value)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// window returns the window opening on the day of t, if the recurrence opens on that day. Its
+// bounds are wall-clock times, so that they hold on the days daylight saving time changes.
+func (r *recurrence) window(day time.Time) (time.Time, time.Time, bool) {
+	if r.days != nil && !r.days[day.Weekday()] {
+		return time.Time{}, time.Time{}, false
+	}
+	clock := func(offset time.Duration) time.Time {
+		// time.Date carries hours past 23 over to the next day.
+		return time.Date(day.Year(), day.Month(), day.Day(), int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, r.loc)
+	}
+	return clock(r.start), clock(r.end), true
+}
+
+// contains checks the windows opening today and yesterday, as a window may cross midnight.
+func (r *recurrence) contains(t time.Time) bool {
+	local := t.In(r.loc)
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		if start, end, ok := r.window(day); ok && !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *recurrence) nextBoundary(t time.Time) time.Time {
+	local := t.In(r.loc)
+	var boundaries []time.Time
+	for offset := -1; time.Duration(offset)*24*time.Hour <= lookahead; offset++ {
+		if start, end, ok := r.window(local.AddDate(0, 0, offset)); ok {
+			boundaries = append(boundaries, start, end)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+	for _, b := range boundaries {
+		if b.After(t) {
+			return b
+		}
+	}
+	return time.Time{}
+}
+
+// Checker finds the Silences that apply to incidents.
+type Checker struct {
+	Client client.Client
+	// ClusterNamespace holds the Silences of incidents without a namespace, such as those of
+	// Nodes. They are never silenced if it is empty.
+	ClusterNamespace string
+}
+
+// WatchClusterNamespace also caches the Silences of namespace, the Checker's ClusterNamespace,
+// when the cache is restricted to namespaces that leave it out.
+func WatchClusterNamespace(opts *cache.Options, namespace string) {
+	if len(opts.DefaultNamespaces) == 0 || namespace == "" {
+		return
+	}
+	if _, cached := opts.DefaultNamespaces[namespace]; cached {
+		return
+	}
+	namespaces := make(map[string]cache.Config, len(opts.DefaultNamespaces)+1)
+	for ns, config := range opts.DefaultNamespaces {
+		namespaces[ns] = config
+	}
+	namespaces[namespace] = cache.Config{}
+	if opts.ByObject == nil {
+		opts.ByObject = map[client.Object]cache.ByObject{}
+	}
+	opts.ByObject[&observerv1alpha1.Silence{}] = cache.ByObject{Namespaces: namespaces}
+}
+
+// NewChecker creates a Checker reading Silences through c.
+func NewChecker(c client.Client) *Checker {
+	return &Checker{Client: c}
+}
+
+// Silencing returns the active Silence in the incident's namespace that matches it, the first
+// by name if several do, or nil. Invalid Silences are skipped.
+func (c *Checker) Silencing(ctx context.Context, incident *pb.IncidentContext, now time.Time) (*observerv1alpha1.Silence, error) {
+	namespace := incident.PodNamespace
+	if namespace == "" {
+		namespace = c.ClusterNamespace
+	}
+	if namespace == "" {
+		return nil, nil
+	}
+	var silences observerv1alpha1.SilenceList
+	if err := c.Client.List(ctx, &silences, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list silences in %s: %w", namespace, err)
+	}
+	sort.Slice(silences.Items, func(i, j int) bool { return silences.Items[i].Name < silences.Items[j].Name })
+	for i := range silences.Items {
+		s := &silences.Items[i]
+		if state, err := State(&s.Spec, now); err != nil || state != observerv1alpha1.SilenceActive {
+			continue
+		}
+		if ok, err := Matches(&s.Spec, incident); err == nil && ok {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordSuppression counts an incident suppressed by a silence in its metrics and status.
+func (c *Checker) RecordSuppression(ctx context.Context, s *observerv1alpha1.Silence, incident *pb.IncidentContext) {
+	incidentsSuppressed.WithLabelValues(s.Namespace, s.Name, incident.FailureReason).Inc()
+
+	now := metav1.Now()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &observerv1alpha1.Silence{}
+		if err := c.Client.Get(ctx, client.ObjectKeyFromObject(s), current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		current.Status.Suppressed++
+		current.Status.LastSuppressed = &now
+		return c.Client.Status().Update(ctx, current)
+	})
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to record suppressed incident", "silence", client.ObjectKeyFromObject(s))
+	}
+}
@@ -0,0 +1,319 @@
+package silence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observerv1alpha1 "kube-mind/observer/api/v1alpha1"
+	"kube-mind/observer/internal/silence"
+	pb "kube-mind/observer/proto"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func metaTime(value string) *metav1.Time {
+	t := metav1.NewTime(at(value))
+	return &t
+}
+
+func newIncident() *pb.IncidentContext {
+	return &pb.IncidentContext{
+		IncidentId:    "incident-1",
+		ClusterId:     "prod-eu",
+		PodName:       "ledger-7d9f-abcde",
+		PodNamespace:  "payments",
+		FailureReason: "CrashLoopBackOff",
+		Details: &pb.IncidentDetails{
+			ContainerName: "migrate",
+			OwnerChain: []*pb.OwnerReference{
+				{Kind: "ReplicaSet", Name: "ledger-7d9f"},
+				{Kind: "Deployment", Name: "ledger"},
+			},
+		},
+	}
+}
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	// 2026-10-20 is a Tuesday.
+	nightly := &observerv1alpha1.Recurrence{
+		Weekdays:  []string{"Tuesday"},
+		StartTime: "23:30",
+		EndTime:   "01:00",
+		TimeZone:  "Europe/Berlin",
+	}
+
+	tests := []struct {
+		name string
+		spec observerv1alpha1.SilenceSpec
+		now  string
+		want observerv1alpha1.SilenceState
+	}{
+		{name: "unbounded", now: "2026-10-20T12:00:00Z", want: observerv1alpha1.SilenceActive},
+		{
+			name: "before start",
+			spec: observerv1alpha1.SilenceSpec{StartsAt: metaTime("2026-10-21T00:00:00Z")},
+			now:  "2026-10-20T12:00:00Z",
+			want: observerv1alpha1.SilencePending,
+		},
+		{
+			name: "after end",
+			spec: observerv1alpha1.SilenceSpec{EndsAt: metaTime("2026-10-20T12:00:00Z")},
+			now:  "2026-10-20T12:00:00Z",
+			want: observerv1alpha1.SilenceExpired,
+		},
+		{
+			name: "inside recurrence before midnight",
+			spec: observerv1alpha1.SilenceSpec{Recurrence: nightly},
+			now:  "2026-10-20T21:45:00Z", // 23:45 in Berlin
+			want: observerv1alpha1.SilenceActive,
+		},
+		{
+			name: "inside recurrence after midnight",
+			spec: observerv1alpha1.SilenceSpec{Recurrence: nightly},
+			now:  "2026-10-20T22:30:00Z", // 00:30 on Wednesday in Berlin
+			want: observerv1alpha1.SilenceActive,
+		},
+		{
+			name: "outside recurrence",
+			spec: observerv1alpha1.SilenceSpec{Recurrence: nightly},
+			now:  "2026-10-20T23:30:00Z", // 01:30 on Wednesday in Berlin
+			want: observerv1alpha1.SilencePending,
+		},
+		{
+			name: "inside recurrence on a daylight saving change",
+			spec: observerv1alpha1.SilenceSpec{Recurrence: &observerv1alpha1.Recurrence{
+				StartTime: "02:30", EndTime: "04:00", TimeZone: "Europe/Berlin",
+			}},
+			now:  "2026-10-25T02:30:00Z", // 03:30 in Berlin, after clocks went back from 03:00 to 02:00
+			want: observerv1alpha1.SilenceActive,
+		},
+		{
+			name: "recurrence on another weekday",
+			spec: observerv1alpha1.SilenceSpec{Recurrence: nightly},
+			now:  "2026-10-21T21:45:00Z",
+			want: observerv1alpha1.SilencePending,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			state, err := silence.State(&tc.spec, at(tc.now))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, state)
+		})
+	}
+}
+
+func TestNextTransition(t *testing.T) {
+	t.Parallel()
+
+	spec := &observerv1alpha1.SilenceSpec{
+		EndsAt:     metaTime("2026-10-30T00:00:00Z"),
+		Recurrence: &observerv1alpha1.Recurrence{StartTime: "02:00", EndTime: "04:00"},
+	}
+	assert.Equal(t, at("2026-10-21T02:00:00Z"), silence.NextTransition(spec, at("2026-10-20T12:00:00Z")).UTC())
+	assert.Equal(t, at("2026-10-21T04:00:00Z"), silence.NextTransition(spec, at("2026-10-21T03:00:00Z")).UTC())
+	assert.Equal(t, at("2026-10-30T00:00:00Z"), silence.NextTransition(spec, at("2026-10-29T12:00:00Z")).UTC())
+	assert.True(t, silence.NextTransition(&observerv1alpha1.SilenceSpec{}, at("2026-10-20T12:00:00Z")).IsZero())
+
+	berlin := &observerv1alpha1.SilenceSpec{
+		Recurrence: &observerv1alpha1.Recurrence{StartTime: "02:30", EndTime: "04:00", TimeZone: "Europe/Berlin"},
+	}
+	assert.Equal(t, at("2026-10-25T03:00:00Z"), silence.NextTransition(berlin, at("2026-10-25T02:30:00Z")).UTC(),
+		"the window closes at 04:00 on the wall clock when clocks go back")
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		matchers []observerv1alpha1.Matcher
+		want     bool
+	}{
+		{name: "no matchers", want: true},
+		{
+			name:     "exact workload",
+			matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchWorkload, Value: "Deployment/ledger"}},
+			want:     true,
+		},
+		{
+			name:     "values are literal unless regex",
+			matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchWorkload, Value: "Deployment/.*"}},
+			want:     false,
+		},
+		{
+			name:     "regex is anchored",
+			matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchPod, Value: "ledger", Regex: true}},
+			want:     false,
+		},
+		{
+			name: "all matchers must match",
+			matchers: []observerv1alpha1.Matcher{
+				{Name: observerv1alpha1.MatchWorkloadKind, Value: "Deployment"},
+				{Name: observerv1alpha1.MatchContainer, Value: "migrate"},
+				{Name: observerv1alpha1.MatchCluster, Value: "prod-.*", Regex: true},
+				{Name: observerv1alpha1.MatchReason, Value: "OOMKilled"},
+			},
+			want: false,
+		},
+		{
+			name:     "negated",
+			matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchReason, Value: "OOMKilled", Negate: true}},
+			want:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := silence.Matches(&observerv1alpha1.SilenceSpec{Matchers: tc.matchers}, newIncident())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ok)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, silence.Validate(&observerv1alpha1.SilenceSpec{
+		Matchers:   []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchPod, Value: "api-.*", Regex: true}},
+		Recurrence: &observerv1alpha1.Recurrence{Weekdays: []string{"monday"}, StartTime: "22:00", EndTime: "06:00"},
+	}))
+	assert.ErrorContains(t, silence.Validate(&observerv1alpha1.SilenceSpec{
+		Matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchPod, Value: "(", Regex: true}},
+	}), "matcher pod")
+	assert.ErrorContains(t, silence.Validate(&observerv1alpha1.SilenceSpec{
+		Recurrence: &observerv1alpha1.Recurrence{StartTime: "22:00", EndTime: "06:00", TimeZone: "Mars/Olympus"},
+	}), "time zone")
+	assert.ErrorContains(t, silence.Validate(&observerv1alpha1.SilenceSpec{
+		Recurrence: &observerv1alpha1.Recurrence{Weekdays: []string{"Caturday"}, StartTime: "22:00", EndTime: "06:00"},
+	}), "Caturday")
+	assert.ErrorContains(t, silence.Validate(&observerv1alpha1.SilenceSpec{
+		StartsAt: metaTime("2026-10-21T00:00:00Z"),
+		EndsAt:   metaTime("2026-10-20T00:00:00Z"),
+	}), "endsAt")
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, observerv1alpha1.AddToScheme(scheme))
+
+	newSilence := func(name, namespace string, spec observerv1alpha1.SilenceSpec) *observerv1alpha1.Silence {
+		return &observerv1alpha1.Silence{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	ledger := []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchWorkload, Value: "Deployment/ledger"}}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&observerv1alpha1.Silence{}).
+		WithObjects(
+			newSilence("other-namespace", "billing", observerv1alpha1.SilenceSpec{}),
+			newSilence("expired", "payments", observerv1alpha1.SilenceSpec{EndsAt: metaTime("2026-10-01T00:00:00Z")}),
+			newSilence("invalid", "payments", observerv1alpha1.SilenceSpec{
+				Matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchPod, Value: "(", Regex: true}},
+			}),
+			newSilence("migration", "payments", observerv1alpha1.SilenceSpec{Matchers: ledger}),
+			newSilence("z-migration", "payments", observerv1alpha1.SilenceSpec{Matchers: ledger}),
+			newSilence("node-maintenance", "observer-system", observerv1alpha1.SilenceSpec{
+				Matchers: []observerv1alpha1.Matcher{{Name: observerv1alpha1.MatchWorkloadKind, Value: "Node"}},
+			}),
+		).Build()
+	checker := silence.NewChecker(c)
+
+	silenced, err := checker.Silencing(ctx, newIncident(), at("2026-10-20T12:00:00Z"))
+	require.NoError(t, err)
+	require.NotNil(t, silenced)
+	assert.Equal(t, "migration", silenced.Name, "the first matching silence by name applies")
+
+	checker.RecordSuppression(ctx, silenced, newIncident())
+	checker.RecordSuppression(ctx, silenced, newIncident())
+	obj := &observerv1alpha1.Silence{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(silenced), obj))
+	assert.Equal(t, int64(2), obj.Status.Suppressed)
+	assert.NotNil(t, obj.Status.LastSuppressed)
+
+	other := newIncident()
+	other.Details.OwnerChain = nil
+	silenced, err = checker.Silencing(ctx, other, at("2026-10-20T12:00:00Z"))
+	require.NoError(t, err)
+	assert.Nil(t, silenced)
+
+	node := &pb.IncidentContext{FailureReason: "MemoryPressure", Node: &pb.NodeDetails{Name: "node-1"}}
+	silenced, err = checker.Silencing(ctx, node, at("2026-10-20T12:00:00Z"))
+	require.NoError(t, err)
+	assert.Nil(t, silenced, "node incidents are not silenced without a cluster namespace")
+	checker.ClusterNamespace = "observer-system"
+	silenced, err = checker.Silencing(ctx, node, at("2026-10-20T12:00:00Z"))
+	require.NoError(t, err)
+	require.NotNil(t, silenced)
+	assert.Equal(t, "node-maintenance", silenced.Name)
+}
+
+func TestWatchClusterNamespace(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		watched  []string
+		expected []string
+	}{
+		{name: "Every namespace cached"},
+		{name: "Cluster namespace watched", watched: []string{"payments", "observer-system"}},
+		{name: "Cluster namespace left out", watched: []string{"payments"}, expected: []string{"observer-system", "payments"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var opts cache.Options
+			if len(tc.watched) > 0 {
+				opts.DefaultNamespaces = map[string]cache.Config{}
+				for _, ns := range tc.watched {
+					opts.DefaultNamespaces[ns] = cache.Config{}
+				}
+			}
+
+			silence.WatchClusterNamespace(&opts, "observer-system")
+
+			if tc.expected == nil {
+				assert.Empty(t, opts.ByObject)
+				return
+			}
+			require.Len(t, opts.ByObject, 1)
+			for object, byObject := range opts.ByObject {
+				assert.IsType(t, &observerv1alpha1.Silence{}, object)
+				namespaces := make([]string, 0, len(byObject.Namespaces))
+				for ns := range byObject.Namespaces {
+					namespaces = append(namespaces, ns)
+				}
+				assert.ElementsMatch(t, tc.expected, namespaces)
+			}
+			assert.Len(t, opts.DefaultNamespaces, len(tc.watched), "other objects stay in the watched namespaces")
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package sink
+
+import (
+	"context"
+	"errors"
+
+	"kube-mind/observer/internal/comms"
+	pb "kube-mind/observer/proto"
+)
+
+// BrainSink streams incidents to the Brain over gRPC.
+type BrainSink struct {
+	name   string
+	client comms.GrpcClient
+}
+
+// queuingClient is a gRPC client that queues incidents while the Brain is unavailable and
+// reports their outcome later, such as comms.IncidentQueue.
+type queuingClient interface {
+	StreamIncidentSettled(ctx context.Context, incident *pb.IncidentContext, settled func(ctx context.Context, err error)) error
+}
+
+// NewBrainSink wraps a gRPC client. The client is expected to queue incidents itself while
+// the Brain is unavailable, so any other error it returns is treated as permanent.
+func NewBrainSink(name string, client comms.GrpcClient) *BrainSink {
+	return &BrainSink{name: name, client: client}
+}
+
+// Name implements Sink.
+func (s *BrainSink) Name() string {
+	return s.name
+}
+
+// Send implements Sink.
+func (s *BrainSink) Send(ctx context.Context, incident *pb.IncidentContext) error {
+	return s.SendDeferred(ctx, incident, nil)
+}
+
+// SendDeferred implements DeferringSink: incidents queued by the client until the Brain is back
+// return ErrDeferred, and finished is called once the client delivers or drops them.
+func (s *BrainSink) SendDeferred(ctx context.Context, incident *pb.IncidentContext, finished func(ctx context.Context, err error)) error {
+	var err error
+	if queue, ok := s.client.(queuingClient); ok {
+		err = queue.StreamIncidentSettled(ctx, incident, finished)
+	} else {
+		err = s.client.StreamIncident(ctx, incident)
+	}
+	if errors.Is(err, comms.ErrQueued) {
+		return ErrDeferred
+	}
+	return Permanent(err)
+}
@@ -0,0 +1,217 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	pb "kube-mind/observer/proto"
+)
+
+// Chat message formats accepted by ChatSink.
+const (
+	FormatSlack = "slack"
+	FormatTeams = "teams"
+)
+
+const (
+	defaultChatLogLines = 5
+	// fingerprintTTL is how long a fingerprint is remembered for chat threads and CloudEvents updates.
+	fingerprintTTL = 24 * time.Hour
+)
+
+// ChatSinkOptions configures a ChatSink.
+type ChatSinkOptions struct {
+	// Format is FormatSlack or FormatTeams.
+	Format string
+	// URL is the incoming webhook used for namespaces without an entry in Channels.
+	URL string
+	// Channels maps namespaces to the incoming webhook of their team's channel.
+	Channels map[string]string
+	// BrainURL links each message to the Brain UI; {incident_id} and {fingerprint} are substituted.
+	BrainURL string
+	// LogLines is the number of trailing log lines quoted in the message.
+	LogLines int
+	Headers  map[string]string
+	Timeout  time.Duration
+}
+
+// ChatSink posts a short incident summary to Slack- or Teams-compatible incoming webhooks so
+// on-call engineers see incidents before the Brain has finished its analysis. Later incidents
+// with the same fingerprint reply in the thread of the first one when the endpoint reports the
+// message timestamp, as Slack's chat.postMessage does.
+type ChatSink struct {
+	name    string
+	opts    ChatSinkOptions
+	client  *http.Client
+	threads *cache.Cache
+}
+
+// NewChatSink creates a chat-ops sink.
+func NewChatSink(name string, opts ChatSinkOptions) (*ChatSink, error) {
+	if opts.Format != FormatSlack && opts.Format != FormatTeams {
+		return nil, fmt.Errorf("unknown chat format %q, expected %q or %q", opts.Format, FormatSlack, FormatTeams)
+	}
+	if opts.URL == "" && len(opts.Channels) == 0 {
+		return nil, fmt.Errorf("url or channels is required for chat sinks")
+	}
+	if opts.LogLines <= 0 {
+		opts.LogLines = defaultChatLogLines
+	}
+	return &ChatSink{
+		name:    name,
+		opts:    opts,
+		client:  newHTTPClient(opts.Timeout),
+		threads: cache.New(fingerprintTTL, fingerprintTTL/2),
+	}, nil
+}
+
+// Name implements Sink.
+func (s *ChatSink) Name() string {
+	return s.name
+}
+
+// Send implements Sink. Namespaces routed to neither a channel nor a default URL are skipped.
+func (s *ChatSink) Send(ctx context.Context, incident *pb.IncidentContext) error {
+	url, ok := s.opts.Channels[incident.PodNamespace]
+	if !ok {
+		url = s.opts.URL
+	}
+	if url == "" {
+		return nil
+	}
+
+	fingerprint := pb.Fingerprint(incident)
+	threadKey := url + "/" + fingerprint
+	threadTS, _ := s.threads.Get(threadKey)
+
+	var payload any
+	if s.opts.Format == FormatTeams {
+		payload = s.teamsMessage(incident, fingerprint)
+	} else {
+		ts, _ := threadTS.(string)
+		payload = s.slackMessage(incident, fingerprint, ts)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to encode chat message for incident %s: %w", incident.IncidentId, err))
+	}
+
+	respBody, err := post(ctx, s.client, url, body, withHeaders(s.opts.Headers, map[string]string{"Content-Type": "application/json"}))
+	if err != nil {
+		return err
+	}
+	if threadTS == nil {
+		var resp struct {
+			TS string `json:"ts"`
+		}
+		if json.Unmarshal(respBody, &resp) == nil && resp.TS != "" {
+			s.threads.Set(threadKey, resp.TS, cache.DefaultExpiration)
+		}
+	}
+	return nil
+}
+
+// slackMessage is an incoming-webhook payload in Slack mrkdwn.
+type slackMessage struct {
+	Text     string `json:"text"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+func (s *ChatSink) slackMessage(incident *pb.IncidentContext, fingerprint, threadTS string) slackMessage {
+	var b strings.Builder
+	fmt.Fprintf(&b, ":rotating_light: *%s* in `%s`", incident.FailureReason, pb.Subject(incident))
+	if container := incident.GetDetails().GetContainerName(); container != "" {
+		fmt.Fprintf(&b, " (container `%s`)", container)
+	}
+	fmt.Fprintf(&b, "\nPod: `%s` · Restarts: %d", incident.PodName, restartCount(incident))
+	if incident.ClusterId != "" {
+		fmt.Fprintf(&b, " · Cluster: `%s`", incident.ClusterId)
+	}
+	if logs := lastLines(incident.Logs, s.opts.LogLines); logs != "" {
+		fmt.Fprintf(&b, "\n```%s```", logs)
+	}
+	if link := s.brainLink(incident, fingerprint); link != "" {
+		fmt.Fprintf(&b, "\n<%s|Open in Kube-Mind>", link)
+	}
+	return slackMessage{Text: b.String(), ThreadTS: threadTS}
+}
+
+// teamsMessage is an Office 365 connector card, which Teams incoming webhooks accept.
+type teamsMessage struct {
+	Type            string        `json:"@type"`
+	Context         string        `json:"@context"`
+	Summary         string        `json:"summary"`
+	Title           string        `json:"title"`
+	Text            string        `json:"text"`
+	PotentialAction []teamsAction `json:"potentialAction,omitempty"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func (s *ChatSink) teamsMessage(incident *pb.IncidentContext, fingerprint string) teamsMessage {
+	title := fmt.Sprintf("%s in %s", incident.FailureReason, pb.Subject(incident))
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pod: %s<br>Container: %s<br>Restarts: %d<br>Fingerprint: %s",
+		incident.PodName, incident.GetDetails().GetContainerName(), restartCount(incident), fingerprint)
+	if logs := lastLines(incident.Logs, s.opts.LogLines); logs != "" {
+		fmt.Fprintf(&b, "<pre>%s</pre>", logs)
+	}
+
+	msg := teamsMessage{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: title,
+		Title:   title,
+		Text:    b.String(),
+	}
+	if link := s.brainLink(incident, fingerprint); link != "" {
+		msg.PotentialAction = []teamsAction{{
+			Type:    "OpenUri",
+			Name:    "Open in Kube-Mind",
+			Targets: []teamsTarget{{OS: "default", URI: link}},
+		}}
+	}
+	return msg
+}
+
+func (s *ChatSink) brainLink(incident *pb.IncidentContext, fingerprint string) string {
+	if s.opts.BrainURL == "" {
+		return ""
+	}
+	return strings.NewReplacer("{incident_id}", incident.IncidentId, "{fingerprint}", fingerprint).Replace(s.opts.BrainURL)
+}
+
+// restartCount returns the restart count of the container that triggered the incident.
+func restartCount(incident *pb.IncidentContext) int32 {
+	details := incident.GetDetails()
+	for _, container := range details.GetContainers() {
+		if container.GetName() == details.GetContainerName() {
+			return container.GetRestartCount()
+		}
+	}
+	return 0
+}
+
+// lastLines returns at most n trailing non-empty lines of logs.
+func lastLines(logs string, n int) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,124 @@
+package sink_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+// chatStub records chat messages per path and answers like Slack's chat.postMessage.
+type chatStub struct {
+	mu       sync.Mutex
+	messages map[string][]map[string]any
+}
+
+func newChatStub(t *testing.T) (*chatStub, *httptest.Server) {
+	t.Helper()
+
+	stub := &chatStub{messages: map[string][]map[string]any{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		stub.mu.Lock()
+		stub.messages[r.URL.Path] = append(stub.messages[r.URL.Path], msg)
+		stub.mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"ts":"1700000000.000100"}`))
+	}))
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func newChatIncident(id, namespace, reason string) *pb.IncidentContext {
+	return &pb.IncidentContext{
+		IncidentId:    id,
+		PodName:       "api-7d9f-abcde",
+		PodNamespace:  namespace,
+		FailureReason: reason,
+		Logs:          "line 1\nline 2\nline 3\npanic: boom\n",
+		Details: &pb.IncidentDetails{
+			ContainerName: "app",
+			Containers:    []*pb.ContainerInfo{{Name: "app", RestartCount: 4}},
+			OwnerChain:    []*pb.OwnerReference{{Kind: "ReplicaSet", Name: "api-7d9f"}, {Kind: "Deployment", Name: "api"}},
+		},
+	}
+}
+
+func TestChatSink_Slack(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	stub, server := newChatStub(t)
+	s, err := sink.NewChatSink("oncall", sink.ChatSinkOptions{
+		Format:   sink.FormatSlack,
+		URL:      server.URL + "/default",
+		Channels: map[string]string{"payments": server.URL + "/payments"},
+		BrainURL: "https://kubemind.example.com/incidents/{incident_id}",
+		LogLines: 2,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(ctx, newChatIncident("first", "payments", "OOMKilled")))
+	require.NoError(t, s.Send(ctx, newChatIncident("update", "payments", "OOMKilled")))
+	require.NoError(t, s.Send(ctx, newChatIncident("other", "payments", "CrashLoopBackOff")))
+	require.NoError(t, s.Send(ctx, newChatIncident("unrouted", "default", "OOMKilled")))
+
+	payments := stub.messages["/payments"]
+	require.Len(t, payments, 3)
+	text := payments[0]["text"].(string)
+	assert.Contains(t, text, "*OOMKilled* in `payments/Deployment/api`")
+	assert.Contains(t, text, "Restarts: 4")
+	assert.Contains(t, text, "```line 3\npanic: boom```")
+	assert.NotContains(t, text, "line 2")
+	assert.Contains(t, text, "<https://kubemind.example.com/incidents/first|Open in Kube-Mind>")
+	assert.NotContains(t, payments[0], "thread_ts")
+	assert.Equal(t, "1700000000.000100", payments[1]["thread_ts"], "updates of the same fingerprint must reply in the thread")
+	assert.NotContains(t, payments[2], "thread_ts", "a different failure starts its own thread")
+	assert.Len(t, stub.messages["/default"], 1)
+}
+
+func TestChatSink_Teams(t *testing.T) {
+	t.Parallel()
+
+	stub, server := newChatStub(t)
+	s, err := sink.NewChatSink("oncall", sink.ChatSinkOptions{
+		Format:   sink.FormatTeams,
+		URL:      server.URL + "/teams",
+		BrainURL: "https://kubemind.example.com/fingerprints/{fingerprint}",
+	})
+	require.NoError(t, err)
+
+	incident := newChatIncident("first", "payments", "OOMKilled")
+	require.NoError(t, s.Send(context.Background(), incident))
+
+	require.Len(t, stub.messages["/teams"], 1)
+	card := stub.messages["/teams"][0]
+	assert.Equal(t, "MessageCard", card["@type"])
+	assert.Equal(t, "OOMKilled in payments/Deployment/api", card["title"])
+	assert.Contains(t, card["text"], "Restarts: 4")
+	actions := card["potentialAction"].([]any)
+	require.Len(t, actions, 1)
+	target := actions[0].(map[string]any)["targets"].([]any)[0].(map[string]any)
+	assert.Equal(t, "https://kubemind.example.com/fingerprints/"+pb.Fingerprint(incident), target["uri"])
+}
+
+func TestNewChatSink_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := sink.NewChatSink("oncall", sink.ChatSinkOptions{Format: "irc", URL: "http://localhost"})
+	assert.Error(t, err)
+
+	_, err = sink.NewChatSink("oncall", sink.ChatSinkOptions{Format: sink.FormatSlack})
+	assert.Error(t, err)
+}
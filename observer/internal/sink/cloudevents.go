@@ -0,0 +1,84 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"kube-mind/observer/internal/cloudevents"
+	pb "kube-mind/observer/proto"
+)
+
+// CloudEventsSink posts every incident as a CloudEvent, e.g. to a Knative broker. The first
+// incident of a fingerprint is sent as detected and later ones as updated.
+type CloudEventsSink struct {
+	name    string
+	url     string
+	mode    cloudevents.Mode
+	format  cloudevents.DataFormat
+	headers map[string]string
+	client  *http.Client
+	seen    *cache.Cache
+}
+
+// NewCloudEventsSink creates a sink posting CloudEvents to url. Empty mode and format
+// default to structured mode with JSON data.
+func NewCloudEventsSink(name, url string, mode cloudevents.Mode, format cloudevents.DataFormat, headers map[string]string, timeout time.Duration) (*CloudEventsSink, error) {
+	if mode == "" {
+		mode = cloudevents.ModeStructured
+	}
+	if format == "" {
+		format = cloudevents.DataJSON
+	}
+	if mode != cloudevents.ModeStructured && mode != cloudevents.ModeBinary {
+		return nil, fmt.Errorf("unknown CloudEvents mode %q", mode)
+	}
+	if format != cloudevents.DataJSON && format != cloudevents.DataProtobuf {
+		return nil, fmt.Errorf("unknown CloudEvents data format %q", format)
+	}
+	return &CloudEventsSink{
+		name:    name,
+		url:     url,
+		mode:    mode,
+		format:  format,
+		headers: headers,
+		client:  newHTTPClient(timeout),
+		seen:    cache.New(fingerprintTTL, fingerprintTTL/2),
+	}, nil
+}
+
+// Name implements Sink.
+func (s *CloudEventsSink) Name() string {
+	return s.name
+}
+
+// Send implements Sink.
+func (s *CloudEventsSink) Send(ctx context.Context, incident *pb.IncidentContext) error {
+	fingerprint := pb.Fingerprint(incident)
+	eventType := cloudevents.TypeIncidentDetected
+	if _, seen := s.seen.Get(fingerprint); seen {
+		eventType = cloudevents.TypeIncidentUpdated
+	}
+
+	event, err := cloudevents.NewIncidentEvent(incident, eventType, s.format, time.Now())
+	if err != nil {
+		return Permanent(err)
+	}
+	header, body, err := event.Encode(s.mode)
+	if err != nil {
+		return Permanent(err)
+	}
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+
+	if _, err := post(ctx, s.client, s.url, body, withHeaders(s.headers, headers)); err != nil {
+		return err
+	}
+	s.seen.Set(fingerprint, struct{}{}, cache.DefaultExpiration)
+	return nil
+}
@@ -0,0 +1,145 @@
+package sink
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"kube-mind/observer/internal/cloudevents"
+	"kube-mind/observer/internal/comms"
+)
+
+// Sink types accepted in the sinks configuration file.
+const (
+	TypeGrpc        = "grpc"
+	TypeWebhook     = "webhook"
+	TypeCloudEvents = "cloudevents"
+	TypeFile        = "file"
+	TypeStdout      = "stdout"
+	TypeSlack       = FormatSlack
+	TypeTeams       = FormatTeams
+)
+
+// DefaultBrainSinkName is the name of the gRPC sink used when no configuration file is given.
+const DefaultBrainSinkName = "brain"
+
+// Config is the sinks configuration file: the available sinks and which namespaces use them.
+type Config struct {
+	Sinks  []Spec `json:"sinks"`
+	Routes Routes `json:"routes"`
+}
+
+// Spec configures one sink.
+type Spec struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// URL is the endpoint of webhook and cloudevents sinks, and the default incoming webhook
+	// of slack and teams sinks.
+	URL string `json:"url,omitempty"`
+	// Channels maps namespaces to the incoming webhook of their channel for slack and teams sinks.
+	Channels map[string]string `json:"channels,omitempty"`
+	// BrainURL links chat messages to the Brain UI; {incident_id} and {fingerprint} are substituted.
+	BrainURL string `json:"brainURL,omitempty"`
+	// Mode is structured or binary for cloudevents sinks.
+	Mode cloudevents.Mode `json:"mode,omitempty"`
+	// DataFormat is json or protobuf for cloudevents sinks.
+	DataFormat cloudevents.DataFormat `json:"dataFormat,omitempty"`
+	// LogLines is the number of trailing log lines quoted in chat messages.
+	LogLines int `json:"logLines,omitempty"`
+	// Path is the JSON-lines file of file sinks.
+	Path string `json:"path,omitempty"`
+	// Headers are added to HTTP requests; ${VAR} references are expanded from the environment
+	// so that secrets can be injected from a Secret.
+	Headers   map[string]string `json:"headers,omitempty"`
+	Timeout   metav1.Duration   `json:"timeout,omitempty"`
+	QueueSize int               `json:"queueSize,omitempty"`
+	Retry     RetryPolicy       `json:"retry,omitempty"`
+}
+
+// DefaultConfig sends every incident to the Brain only.
+func DefaultConfig() *Config {
+	return &Config{
+		Sinks:  []Spec{{Name: DefaultBrainSinkName, Type: TypeGrpc}},
+		Routes: Routes{Default: []string{DefaultBrainSinkName}},
+	}
+}
+
+// LoadConfig reads a YAML or JSON sinks configuration file, or returns DefaultConfig for an empty path.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sinks config %s: %w", path, err)
+	}
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse sinks config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Build creates every configured sink and a Router over them. Incidents for gRPC sinks are
+// handed to brain.
+func (c *Config) Build(brain comms.GrpcClient) (*Router, error) {
+	routes := make([]Route, 0, len(c.Sinks))
+	for _, spec := range c.Sinks {
+		s, err := spec.build(brain)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", spec.Name, err)
+		}
+		routes = append(routes, Route{Sink: s, QueueSize: spec.QueueSize, Retry: spec.Retry})
+	}
+	return NewRouter(routes, c.Routes)
+}
+
+func (s Spec) build(brain comms.GrpcClient) (Sink, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	headers := make(map[string]string, len(s.Headers))
+	for k, v := range s.Headers {
+		headers[k] = os.ExpandEnv(v)
+	}
+
+	switch s.Type {
+	case TypeGrpc:
+		return NewBrainSink(s.Name, brain), nil
+	case TypeWebhook:
+		if s.URL == "" {
+			return nil, fmt.Errorf("url is required for %s sinks", s.Type)
+		}
+		return NewWebhookSink(s.Name, s.URL, headers, s.Timeout.Duration), nil
+	case TypeCloudEvents:
+		if s.URL == "" {
+			return nil, fmt.Errorf("url is required for %s sinks", s.Type)
+		}
+		return NewCloudEventsSink(s.Name, s.URL, s.Mode, s.DataFormat, headers, s.Timeout.Duration)
+	case TypeFile:
+		if s.Path == "" {
+			return nil, fmt.Errorf("path is required for %s sinks", s.Type)
+		}
+		return NewFileSink(s.Name, s.Path)
+	case TypeStdout:
+		return NewStdoutSink(s.Name), nil
+	case TypeSlack, TypeTeams:
+		channels := make(map[string]string, len(s.Channels))
+		for namespace, url := range s.Channels {
+			channels[namespace] = os.ExpandEnv(url)
+		}
+		return NewChatSink(s.Name, ChatSinkOptions{
+			Format:   s.Type,
+			URL:      os.ExpandEnv(s.URL),
+			Channels: channels,
+			BrainURL: s.BrainURL,
+			LogLines: s.LogLines,
+			Headers:  headers,
+			Timeout:  s.Timeout.Duration,
+		})
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", s.Type)
+	}
+}
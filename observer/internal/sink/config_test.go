@@ -0,0 +1,107 @@
+package sink_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sinks.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+sinks:
+  - name: brain
+    type: grpc
+  - name: audit
+    type: file
+    path: `+filepath.Join(dir, "incidents.jsonl")+`
+  - name: ops
+    type: webhook
+    url: https://hooks.example.com/incidents
+    timeout: 5s
+    retry:
+      maxAttempts: 10
+      initialBackoff: 2s
+routes:
+  default: [brain]
+  namespaces:
+    payments: [brain, audit, ops]
+`), 0o600))
+
+	cfg, err := sink.LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Sinks, 3)
+	assert.Equal(t, 10, cfg.Sinks[2].Retry.MaxAttempts)
+	assert.Equal(t, "2s", cfg.Sinks[2].Retry.InitialBackoff.Duration.String())
+	assert.Equal(t, []string{"brain", "audit", "ops"}, cfg.Routes.SinksFor("payments"))
+	assert.Equal(t, []string{"brain"}, cfg.Routes.SinksFor("default"))
+
+	router, err := cfg.Build(nil)
+	require.NoError(t, err)
+	require.NoError(t, router.Close())
+}
+
+func TestLoadConfig_Default(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := sink.LoadConfig("")
+
+	require.NoError(t, err)
+	assert.Equal(t, sink.DefaultConfig(), cfg)
+}
+
+func TestConfig_BuildErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		spec sink.Spec
+	}{
+		{name: "Missing name", spec: sink.Spec{Type: sink.TypeStdout}},
+		{name: "Unknown type", spec: sink.Spec{Name: "pager", Type: "pager"}},
+		{name: "Webhook without URL", spec: sink.Spec{Name: "ops", Type: sink.TypeWebhook}},
+		{name: "File without path", spec: sink.Spec{Name: "audit", Type: sink.TypeFile}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &sink.Config{Sinks: []sink.Spec{tc.spec}}
+			_, err := cfg.Build(nil)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFileSink_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "incidents.jsonl")
+	s, err := sink.NewFileSink("audit", path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), &pb.IncidentContext{IncidentId: "first"}))
+	require.NoError(t, s.Send(context.Background(), &pb.IncidentContext{IncidentId: "second"}))
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"incidentId":"first"}`, lines[0])
+	assert.JSONEq(t, `{"incidentId":"second"}`, lines[1])
+}
@@ -0,0 +1,68 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "kube-mind/observer/proto"
+)
+
+// WriterSink writes every incident as one JSON line, for local files and stdout.
+type WriterSink struct {
+	name string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileSink appends incidents to the JSON-lines file at path, creating it if needed.
+func NewFileSink(name, path string) (*WriterSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open incident file %s: %w", path, err)
+	}
+	return NewWriterSink(name, f), nil
+}
+
+// NewStdoutSink writes incidents to the Observer's standard output.
+func NewStdoutSink(name string) *WriterSink {
+	return NewWriterSink(name, os.Stdout)
+}
+
+// NewWriterSink writes incidents to w.
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{name: name, w: w}
+}
+
+// Name implements Sink.
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+// Send implements Sink.
+func (s *WriterSink) Send(_ context.Context, incident *pb.IncidentContext) error {
+	line, err := protojson.MarshalOptions{Multiline: false}.Marshal(incident)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal incident %s: %w", incident.IncidentId, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write incident %s: %w", incident.IncidentId, err)
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is a file other than stdout.
+func (s *WriterSink) Close() error {
+	if closer, ok := s.w.(io.Closer); ok && s.w != os.Stdout {
+		return closer.Close()
+	}
+	return nil
+}
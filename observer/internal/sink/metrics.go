@@ -0,0 +1,29 @@
+package sink
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resultSuccess  = "success"
+	resultRetry    = "retry"
+	resultFailed   = "failed"
+	resultDropped  = "dropped"
+	resultDeferred = "deferred"
+)
+
+var (
+	sinkDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubemind_observer_sink_deliveries_total",
+		Help: "Number of incident delivery attempts per sink, by result.",
+	}, []string{"sink", "result"})
+	sinkQueueLength = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubemind_observer_sink_queue_length",
+		Help: "Number of incidents waiting to be delivered per sink.",
+	}, []string{"sink"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(sinkDeliveries, sinkQueueLength)
+}
@@ -0,0 +1,275 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "kube-mind/observer/proto"
+)
+
+const (
+	defaultQueueSize      = 1000
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// RetryPolicy controls how often and how fast a failed delivery is retried.
+type RetryPolicy struct {
+	MaxAttempts    int             `json:"maxAttempts,omitempty"`
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff.Duration <= 0 {
+		p.InitialBackoff.Duration = defaultInitialBackoff
+	}
+	if p.MaxBackoff.Duration <= 0 {
+		p.MaxBackoff.Duration = defaultMaxBackoff
+	}
+	return p
+}
+
+// Routes maps namespaces to the names of the sinks that receive their incidents.
+type Routes struct {
+	// Default applies to namespaces without an entry in Namespaces.
+	Default    []string            `json:"default,omitempty"`
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+}
+
+// SinksFor returns the sink names configured for a namespace.
+func (r Routes) SinksFor(namespace string) []string {
+	if names, ok := r.Namespaces[namespace]; ok {
+		return names
+	}
+	return r.Default
+}
+
+// Route is a sink together with the queue settings it is delivered with.
+type Route struct {
+	Sink      Sink
+	QueueSize int
+	Retry     RetryPolicy
+}
+
+// Router fans incidents out to the sinks routed for their namespace. Every sink has its own
+// queue and retries, so a slow or failing destination never holds up the others.
+// It is a manager.Runnable and must be added to the manager to start delivering.
+type Router struct {
+	// Reporter, if set before Start, is told about every delivery.
+	Reporter DeliveryReporter
+
+	routes  Routes
+	workers map[string]*worker
+}
+
+// NewRouter validates that every route refers to a known sink.
+func NewRouter(sinks []Route, routes Routes) (*Router, error) {
+	r := &Router{routes: routes, workers: make(map[string]*worker, len(sinks))}
+	for _, route := range sinks {
+		name := route.Sink.Name()
+		if _, exists := r.workers[name]; exists {
+			return nil, fmt.Errorf("duplicate sink %q", name)
+		}
+		r.workers[name] = newWorker(route)
+	}
+
+	check := func(names []string) error {
+		for _, name := range names {
+			if _, ok := r.workers[name]; !ok {
+				return fmt.Errorf("route refers to unknown sink %q", name)
+			}
+		}
+		return nil
+	}
+	if err := check(routes.Default); err != nil {
+		return nil, err
+	}
+	for namespace, names := range routes.Namespaces {
+		if err := check(names); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+	}
+	return r, nil
+}
+
+// Name implements Sink.
+func (r *Router) Name() string {
+	return "router"
+}
+
+// Send queues the incident for every sink routed for its namespace. It only fails when
+// a sink's queue is full, in which case the incident is still handed to the other sinks.
+func (r *Router) Send(ctx context.Context, incident *pb.IncidentContext) error {
+	return r.enqueue(ctx, incident, r.routes.SinksFor(incident.PodNamespace))
+}
+
+// SendTo queues the incident for the named sinks instead of its namespace's routes. Unknown
+// names are logged and dropped; if none is known, the namespace's routes are used.
+func (r *Router) SendTo(ctx context.Context, incident *pb.IncidentContext, names []string) error {
+	known := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := r.workers[name]; !ok {
+			logf.FromContext(ctx).Info("Ignoring unknown sink", "sink", name, "incidentID", incident.IncidentId)
+			continue
+		}
+		known = append(known, name)
+	}
+	if len(known) == 0 {
+		known = r.routes.SinksFor(incident.PodNamespace)
+	}
+	return r.enqueue(ctx, incident, known)
+}
+
+// Has reports whether a sink with this name exists.
+func (r *Router) Has(name string) bool {
+	_, ok := r.workers[name]
+	return ok
+}
+
+func (r *Router) enqueue(ctx context.Context, incident *pb.IncidentContext, names []string) error {
+	var errs []error
+	for _, name := range names {
+		if r.Reporter != nil {
+			r.Reporter.DeliveryQueued(ctx, name, incident)
+		}
+		if !r.workers[name].enqueue(incident) {
+			err := fmt.Errorf("sink %s queue is full, incident %s dropped", name, incident.IncidentId)
+			if r.Reporter != nil {
+				r.Reporter.DeliveryFinished(ctx, name, incident, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start runs one delivery loop per sink until ctx is cancelled.
+func (r *Router) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, w := range r.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.run(ctx, r.Reporter)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Close closes every sink that holds resources, such as open files.
+func (r *Router) Close() error {
+	var errs []error
+	for _, w := range r.workers {
+		if closer, ok := w.sink.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NeedLeaderElection reports that a replica delivers what it queued even after losing leadership.
+func (r *Router) NeedLeaderElection() bool {
+	return false
+}
+
+// worker delivers incidents to one sink in order.
+type worker struct {
+	sink  Sink
+	retry RetryPolicy
+	queue chan *pb.IncidentContext
+}
+
+func newWorker(route Route) *worker {
+	queueSize := route.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	return &worker{
+		sink:  route.Sink,
+		retry: route.Retry.withDefaults(),
+		queue: make(chan *pb.IncidentContext, queueSize),
+	}
+}
+
+func (w *worker) enqueue(incident *pb.IncidentContext) bool {
+	select {
+	case w.queue <- incident:
+		sinkQueueLength.WithLabelValues(w.sink.Name()).Set(float64(len(w.queue)))
+		return true
+	default:
+		sinkDeliveries.WithLabelValues(w.sink.Name(), resultDropped).Inc()
+		return false
+	}
+}
+
+func (w *worker) run(ctx context.Context, reporter DeliveryReporter) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case incident := <-w.queue:
+			sinkQueueLength.WithLabelValues(w.sink.Name()).Set(float64(len(w.queue)))
+			finished := func(ctx context.Context, err error) {
+				if reporter != nil && ctx.Err() == nil {
+					reporter.DeliveryFinished(ctx, w.sink.Name(), incident, err)
+				}
+			}
+			if err := w.deliver(ctx, incident, finished); !errors.Is(err, ErrDeferred) {
+				finished(ctx, err)
+			}
+		}
+	}
+}
+
+// deliver sends one incident, backing off exponentially between attempts, and returns the
+// last error if it was given up. It returns ErrDeferred for incidents a DeferringSink holds back,
+// whose outcome it passes to finished later.
+func (w *worker) deliver(ctx context.Context, incident *pb.IncidentContext, finished func(ctx context.Context, err error)) error {
+	log := logf.FromContext(ctx).WithValues("sink", w.sink.Name(), "incidentID", incident.IncidentId)
+
+	send := w.sink.Send
+	if deferring, ok := w.sink.(DeferringSink); ok {
+		send = func(ctx context.Context, incident *pb.IncidentContext) error {
+			return deferring.SendDeferred(ctx, incident, finished)
+		}
+	}
+	backoff := w.retry.InitialBackoff.Duration
+	for attempt := 1; ; attempt++ {
+		err := send(ctx, incident)
+		if err == nil {
+			sinkDeliveries.WithLabelValues(w.sink.Name(), resultSuccess).Inc()
+			return nil
+		}
+		if errors.Is(err, ErrDeferred) {
+			sinkDeliveries.WithLabelValues(w.sink.Name(), resultDeferred).Inc()
+			return err
+		}
+		if IsPermanent(err) || attempt >= w.retry.MaxAttempts {
+			sinkDeliveries.WithLabelValues(w.sink.Name(), resultFailed).Inc()
+			log.Error(err, "failed to deliver incident", "attempts", attempt)
+			return err
+		}
+
+		sinkDeliveries.WithLabelValues(w.sink.Name(), resultRetry).Inc()
+		log.V(1).Info("Retrying incident delivery", "attempt", attempt, "backoff", backoff, "reason", err.Error())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, w.retry.MaxBackoff.Duration)
+	}
+}
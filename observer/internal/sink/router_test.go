@@ -0,0 +1,303 @@
+package sink_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+// mockSink is a mock implementation of sink.Sink for testing.
+type mockSink struct {
+	name     string
+	sendFunc func(attempt int) error
+
+	mu        sync.Mutex
+	attempts  int
+	delivered []string
+}
+
+func (m *mockSink) Name() string {
+	return m.name
+}
+
+func (m *mockSink) Send(_ context.Context, incident *pb.IncidentContext) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts++
+	if m.sendFunc != nil {
+		if err := m.sendFunc(m.attempts); err != nil {
+			return err
+		}
+	}
+	m.delivered = append(m.delivered, incident.IncidentId)
+	return nil
+}
+
+func (m *mockSink) state() (int, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.attempts, append([]string(nil), m.delivered...)
+}
+
+var fastRetry = sink.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: metav1.Duration{Duration: time.Millisecond},
+	MaxBackoff:     metav1.Duration{Duration: time.Millisecond},
+}
+
+func TestRouter_FanOutWithIndependentRetries(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	brain := &mockSink{name: "brain"}
+	flaky := &mockSink{name: "flaky", sendFunc: func(attempt int) error {
+		if attempt < 3 {
+			return errors.New("503 Service Unavailable")
+		}
+		return nil
+	}}
+	rejecting := &mockSink{name: "rejecting", sendFunc: func(int) error {
+		return sink.Permanent(errors.New("400 Bad Request"))
+	}}
+	audit := &mockSink{name: "audit"}
+
+	router, err := sink.NewRouter(
+		[]sink.Route{{Sink: brain}, {Sink: flaky, Retry: fastRetry}, {Sink: rejecting, Retry: fastRetry}, {Sink: audit}},
+		sink.Routes{
+			Default:    []string{"brain"},
+			Namespaces: map[string][]string{"payments": {"brain", "flaky", "rejecting"}},
+		},
+	)
+	require.NoError(t, err)
+	go func() {
+		_ = router.Start(ctx)
+	}()
+
+	require.NoError(t, router.Send(ctx, &pb.IncidentContext{IncidentId: "payments-incident", PodNamespace: "payments"}))
+	require.NoError(t, router.Send(ctx, &pb.IncidentContext{IncidentId: "default-incident", PodNamespace: "default"}))
+
+	require.Eventually(t, func() bool {
+		_, delivered := flaky.state()
+		return len(delivered) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, delivered := brain.state()
+		return len(delivered) == 2
+	}, 5*time.Second, time.Millisecond)
+
+	_, brainDelivered := brain.state()
+	assert.ElementsMatch(t, []string{"payments-incident", "default-incident"}, brainDelivered)
+	flakyAttempts, _ := flaky.state()
+	assert.Equal(t, 3, flakyAttempts)
+	rejectingAttempts, rejectingDelivered := rejecting.state()
+	assert.Equal(t, 1, rejectingAttempts, "permanent errors must not be retried")
+	assert.Empty(t, rejectingDelivered)
+	auditAttempts, _ := audit.state()
+	assert.Zero(t, auditAttempts, "unrouted sinks must not receive incidents")
+}
+
+func TestRouter_QueueFull(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	slow := &mockSink{name: "slow"}
+	other := &mockSink{name: "other"}
+	router, err := sink.NewRouter(
+		[]sink.Route{{Sink: slow, QueueSize: 1}, {Sink: other}},
+		sink.Routes{Default: []string{"slow", "other"}},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, router.Send(ctx, &pb.IncidentContext{IncidentId: "first"}))
+	err = router.Send(ctx, &pb.IncidentContext{IncidentId: "second"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sink slow queue is full")
+}
+
+func TestRouter_SendTo(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	brain := &mockSink{name: "brain"}
+	audit := &mockSink{name: "audit"}
+	router, err := sink.NewRouter([]sink.Route{{Sink: brain}, {Sink: audit}}, sink.Routes{Default: []string{"brain"}})
+	require.NoError(t, err)
+	go func() {
+		_ = router.Start(ctx)
+	}()
+
+	require.NoError(t, router.SendTo(ctx, &pb.IncidentContext{IncidentId: "incident"}, []string{"audit", "missing"}))
+
+	require.Eventually(t, func() bool {
+		_, delivered := audit.state()
+		return len(delivered) == 1
+	}, 5*time.Second, time.Millisecond)
+	brainAttempts, _ := brain.state()
+	assert.Zero(t, brainAttempts, "targeted incidents bypass the namespace's routes")
+
+	require.NoError(t, router.SendTo(ctx, &pb.IncidentContext{IncidentId: "fallback"}, []string{"missing"}))
+	require.Eventually(t, func() bool {
+		_, delivered := brain.state()
+		return len(delivered) == 1
+	}, 5*time.Second, time.Millisecond, "unknown sinks fall back to the namespace's routes")
+	assert.True(t, router.Has("audit"))
+	assert.False(t, router.Has("missing"))
+}
+
+// mockReporter records delivery progress as "sink/incident/state" entries.
+type mockReporter struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (m *mockReporter) DeliveryQueued(_ context.Context, sink string, incident *pb.IncidentContext) {
+	m.record(sink, incident, "queued")
+}
+
+func (m *mockReporter) DeliveryFinished(_ context.Context, sink string, incident *pb.IncidentContext, err error) {
+	state := "delivered"
+	if err != nil {
+		state = "failed"
+	}
+	m.record(sink, incident, state)
+}
+
+func (m *mockReporter) record(sink string, incident *pb.IncidentContext, state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, sink+"/"+incident.IncidentId+"/"+state)
+}
+
+func (m *mockReporter) snapshot() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.entries...)
+}
+
+func TestRouter_Reporter(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rejecting := &mockSink{name: "rejecting", sendFunc: func(int) error {
+		return sink.Permanent(errors.New("400 Bad Request"))
+	}}
+	router, err := sink.NewRouter(
+		[]sink.Route{{Sink: &mockSink{name: "brain"}}, {Sink: rejecting}},
+		sink.Routes{Default: []string{"brain", "rejecting"}},
+	)
+	require.NoError(t, err)
+	reporter := &mockReporter{}
+	router.Reporter = reporter
+	go func() {
+		_ = router.Start(ctx)
+	}()
+
+	require.NoError(t, router.Send(ctx, &pb.IncidentContext{IncidentId: "incident"}))
+
+	require.Eventually(t, func() bool {
+		return len(reporter.snapshot()) == 4
+	}, 5*time.Second, time.Millisecond)
+	entries := reporter.snapshot()
+	assert.Equal(t, []string{"brain/incident/queued", "rejecting/incident/queued"}, entries[:2])
+	assert.ElementsMatch(t, []string{"brain/incident/delivered", "rejecting/incident/failed"}, entries[2:])
+}
+
+// deferringSink is a sink.DeferringSink that holds every incident back until finish is called.
+type deferringSink struct {
+	mu       sync.Mutex
+	finished []func(ctx context.Context, err error)
+}
+
+func (d *deferringSink) Name() string {
+	return "brain"
+}
+
+func (d *deferringSink) Send(context.Context, *pb.IncidentContext) error {
+	return errors.New("Send must not be called on a deferring sink")
+}
+
+func (d *deferringSink) SendDeferred(_ context.Context, _ *pb.IncidentContext, finished func(ctx context.Context, err error)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.finished = append(d.finished, finished)
+	return sink.ErrDeferred
+}
+
+func (d *deferringSink) held() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.finished)
+}
+
+func TestRouter_ReporterDeferred(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deferring := &deferringSink{}
+	router, err := sink.NewRouter([]sink.Route{{Sink: deferring, Retry: fastRetry}}, sink.Routes{Default: []string{"brain"}})
+	require.NoError(t, err)
+	reporter := &mockReporter{}
+	router.Reporter = reporter
+	go func() {
+		_ = router.Start(ctx)
+	}()
+
+	require.NoError(t, router.Send(ctx, &pb.IncidentContext{IncidentId: "incident"}))
+	require.Eventually(t, func() bool { return deferring.held() == 1 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, []string{"brain/incident/queued"}, reporter.snapshot(), "a deferred incident stays pending")
+
+	deferring.finished[0](ctx, nil)
+	assert.Equal(t, []string{"brain/incident/queued", "brain/incident/delivered"}, reporter.snapshot())
+	assert.Equal(t, 1, deferring.held(), "a deferred incident is not retried")
+}
+
+func TestNewRouter_Validation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		sinks  []sink.Route
+		routes sink.Routes
+	}{
+		{
+			name:   "Duplicate sink",
+			sinks:  []sink.Route{{Sink: &mockSink{name: "brain"}}, {Sink: &mockSink{name: "brain"}}},
+			routes: sink.Routes{Default: []string{"brain"}},
+		},
+		{
+			name:   "Default route to an unknown sink",
+			sinks:  []sink.Route{{Sink: &mockSink{name: "brain"}}},
+			routes: sink.Routes{Default: []string{"webhook"}},
+		},
+		{
+			name:   "Namespace route to an unknown sink",
+			sinks:  []sink.Route{{Sink: &mockSink{name: "brain"}}},
+			routes: sink.Routes{Namespaces: map[string][]string{"payments": {"webhook"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := sink.NewRouter(tc.sinks, tc.routes)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package sink delivers incidents to the Brain and to other destinations such as webhooks,
+// CloudEvents brokers and local files.
+package sink
+
+import (
+	"context"
+	"errors"
+
+	pb "kube-mind/observer/proto"
+)
+
+// Sink delivers incidents to a single destination.
+type Sink interface {
+	// Name identifies the sink in configuration, logs and metrics.
+	Name() string
+	Send(ctx context.Context, incident *pb.IncidentContext) error
+}
+
+// TargetedSink is a Sink that can also deliver to an explicit set of sinks by name.
+type TargetedSink interface {
+	Sink
+	SendTo(ctx context.Context, incident *pb.IncidentContext, names []string) error
+}
+
+// ErrDeferred is returned by a DeferringSink that accepted an incident for later delivery.
+var ErrDeferred = errors.New("delivery deferred")
+
+// DeferringSink is a Sink that may hold an incident back for later delivery, like the Brain sink
+// while the Brain is down. When SendDeferred returns ErrDeferred, finished is called with the
+// outcome of the delivery once it is known.
+type DeferringSink interface {
+	Sink
+	SendDeferred(ctx context.Context, incident *pb.IncidentContext, finished func(ctx context.Context, err error)) error
+}
+
+// DeliveryReporter follows the progress of deliveries, for example to record them on the
+// Incident resource. Calls are made from the goroutine delivering to the sink.
+type DeliveryReporter interface {
+	// DeliveryQueued is called before an incident is queued for a sink.
+	DeliveryQueued(ctx context.Context, sink string, incident *pb.IncidentContext)
+	// DeliveryFinished is called once the incident was delivered, when err is nil, or given up.
+	// Deliveries deferred by a DeferringSink finish when the sink settles them.
+	DeliveryFinished(ctx context.Context, sink string, incident *pb.IncidentContext, err error)
+}
+
+// PermanentError marks a delivery failure that retrying will not fix, such as a rejected payload.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err so that it is not retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err, or any error it wraps, is a PermanentError.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
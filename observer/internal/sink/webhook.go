@@ -0,0 +1,94 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "kube-mind/observer/proto"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// WebhookSink posts every incident as JSON to an HTTP(S) endpoint.
+type WebhookSink struct {
+	name    string
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookSink creates a sink posting to url with the given extra headers.
+// A zero timeout means defaultHTTPTimeout.
+func NewWebhookSink(name, url string, headers map[string]string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{name: name, url: url, headers: headers, client: newHTTPClient(timeout)}
+}
+
+// Name implements Sink.
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send implements Sink.
+func (s *WebhookSink) Send(ctx context.Context, incident *pb.IncidentContext) error {
+	body, err := protojson.Marshal(incident)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal incident %s: %w", incident.IncidentId, err))
+	}
+	_, err = post(ctx, s.client, s.url, body, withHeaders(s.headers, map[string]string{"Content-Type": "application/json"}))
+	return err
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// withHeaders merges configured headers over the defaults of a sink.
+func withHeaders(configured, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(configured)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range configured {
+		merged[k] = v
+	}
+	return merged
+}
+
+// post sends body to url and returns the response body. Client errors other than timeouts
+// and rate limiting are permanent, everything else may be retried.
+func post(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("failed to create request for %s: %w", url, err))
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return respBody, nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%s responded %s", url, resp.Status)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return nil, Permanent(fmt.Errorf("%s responded %s", url, resp.Status))
+	default:
+		return nil, fmt.Errorf("%s responded %s", url, resp.Status)
+	}
+}
@@ -0,0 +1,100 @@
+package sink_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kube-mind/observer/internal/cloudevents"
+	"kube-mind/observer/internal/sink"
+	pb "kube-mind/observer/proto"
+)
+
+func TestWebhookSink_Send(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testCases := []struct {
+		name            string
+		status          int
+		expectErr       bool
+		expectPermanent bool
+	}{
+		{name: "Accepted", status: http.StatusAccepted},
+		{name: "Server error is retried", status: http.StatusBadGateway, expectErr: true},
+		{name: "Rate limiting is retried", status: http.StatusTooManyRequests, expectErr: true},
+		{name: "Client error is permanent", status: http.StatusBadRequest, expectErr: true, expectPermanent: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			s := sink.NewWebhookSink("webhook", server.URL, map[string]string{"Authorization": "Bearer token"}, 0)
+			err := s.Send(ctx, &pb.IncidentContext{IncidentId: "test-incident", PodName: "test-pod"})
+
+			if tc.expectErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectPermanent, sink.IsPermanent(err))
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, received)
+			assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", received.Header.Get("Authorization"))
+			assert.JSONEq(t, `{"incidentId":"test-incident","podName":"test-pod"}`, string(body))
+		})
+	}
+}
+
+func TestCloudEventsSink_Send(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var received []*cloudevents.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		event, err := cloudevents.Decode(r.Header, body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, event)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	s, err := sink.NewCloudEventsSink("events", server.URL, cloudevents.ModeBinary, cloudevents.DataProtobuf, nil, 0)
+	require.NoError(t, err)
+	incident := &pb.IncidentContext{IncidentId: "test-incident", PodName: "test-pod", PodNamespace: "payments", ClusterId: "prod-eu"}
+
+	require.NoError(t, s.Send(ctx, incident))
+	require.NoError(t, s.Send(ctx, incident))
+
+	require.Len(t, received, 2)
+	assert.Equal(t, cloudevents.TypeIncidentDetected, received[0].Type)
+	assert.Equal(t, cloudevents.TypeIncidentUpdated, received[1].Type)
+	assert.Equal(t, "/kube-mind/observer/clusters/prod-eu", received[0].Source)
+	assert.Equal(t, "payments/Pod/test-pod", received[0].Subject)
+	decoded, err := received[0].Incident()
+	require.NoError(t, err)
+	assert.Equal(t, "test-incident", decoded.IncidentId)
+
+	_, err = sink.NewCloudEventsSink("events", server.URL, "batched", "", nil, 0)
+	assert.Error(t, err)
+}
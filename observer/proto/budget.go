@@ -0,0 +1,242 @@
+package proto
+
+import (
+	"strings"
+
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// Names recorded in IncidentContext.trimmed_fields, in the order they are trimmed.
+const (
+	TrimmedLogs               = "logs"
+	TrimmedEvents             = "details.events"
+	TrimmedNodeEvents         = "node.events"
+	TrimmedSchedulingNodes    = "details.scheduling.nodes"
+	TrimmedDeploymentManifest = "deployment_manifest_json"
+	TrimmedPodManifest        = "pod_manifest_json"
+	TrimmedProbeFailures      = "details.probes.failures"
+	TrimmedEvictedPods        = "details.eviction.evicted_pods"
+	TrimmedNodeAffectedPods   = "node.affected_pods"
+	TrimmedRolloutFailingPods = "details.rollout.failing_pods"
+	TrimmedTemplateChanges    = "details.rollout.template_changes"
+	TrimmedCronJobRuns        = "details.job.cron_job.recent_runs"
+	TrimmedJobAttempts        = "details.job.attempts"
+	TrimmedContainers         = "details.containers"
+	// TrimmedDetails and TrimmedNode are the last resort: the typed details and node details are
+	// reduced to the names identifying the incident.
+	TrimmedDetails = "details"
+	TrimmedNode    = "node"
+)
+
+// budgetStep trims one part of an incident by roughly the given number of bytes
+// and reports whether anything was removed.
+type budgetStep struct {
+	field string
+	trim  func(incident *IncidentContext, excess int) bool
+}
+
+var budgetSteps = []budgetStep{
+	{field: TrimmedLogs, trim: trimLogs},
+	repeatedStep(TrimmedEvents, dropHead[*Event], func(incident *IncidentContext) *[]*Event {
+		if details := incident.GetDetails(); details != nil {
+			return &details.Events
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedNodeEvents, dropHead[*Event], func(incident *IncidentContext) *[]*Event {
+		if node := incident.GetNode(); node != nil {
+			return &node.Events
+		}
+		return nil
+	}),
+	// Nodes are listed best candidate first, so the tail goes first.
+	repeatedStep(TrimmedSchedulingNodes, dropTail[*NodeSummary], func(incident *IncidentContext) *[]*NodeSummary {
+		if scheduling := incident.GetDetails().GetScheduling(); scheduling != nil {
+			return &scheduling.Nodes
+		}
+		return nil
+	}),
+	{field: TrimmedDeploymentManifest, trim: func(incident *IncidentContext, _ int) bool {
+		removed := incident.DeploymentManifestJson != ""
+		incident.DeploymentManifestJson = ""
+		return removed
+	}},
+	{field: TrimmedPodManifest, trim: func(incident *IncidentContext, _ int) bool {
+		removed := incident.PodManifestJson != ""
+		incident.PodManifestJson = ""
+		return removed
+	}},
+	repeatedStep(TrimmedProbeFailures, dropHead[*ProbeFailure], func(incident *IncidentContext) *[]*ProbeFailure {
+		if probes := incident.GetDetails().GetProbes(); probes != nil {
+			return &probes.Failures
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedEvictedPods, dropHead[*AffectedPod], func(incident *IncidentContext) *[]*AffectedPod {
+		if eviction := incident.GetDetails().GetEviction(); eviction != nil {
+			return &eviction.EvictedPods
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedNodeAffectedPods, dropTail[*AffectedPod], func(incident *IncidentContext) *[]*AffectedPod {
+		if node := incident.GetNode(); node != nil {
+			return &node.AffectedPods
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedRolloutFailingPods, dropTail[*AffectedPod], func(incident *IncidentContext) *[]*AffectedPod {
+		if rollout := incident.GetDetails().GetRollout(); rollout != nil {
+			return &rollout.FailingPods
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedTemplateChanges, dropTail[*TemplateChange], func(incident *IncidentContext) *[]*TemplateChange {
+		if rollout := incident.GetDetails().GetRollout(); rollout != nil {
+			return &rollout.TemplateChanges
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedCronJobRuns, dropHead[*CronJobRun], func(incident *IncidentContext) *[]*CronJobRun {
+		if cronJob := incident.GetDetails().GetJob().GetCronJob(); cronJob != nil {
+			return &cronJob.RecentRuns
+		}
+		return nil
+	}),
+	repeatedStep(TrimmedJobAttempts, dropHead[*JobAttempt], func(incident *IncidentContext) *[]*JobAttempt {
+		if job := incident.GetDetails().GetJob(); job != nil {
+			return &job.Attempts
+		}
+		return nil
+	}),
+	{field: TrimmedContainers, trim: trimContainers},
+	{field: TrimmedDetails, trim: func(incident *IncidentContext, _ int) bool {
+		details := incident.GetDetails()
+		if details == nil {
+			return false
+		}
+		incident.Details = &IncidentDetails{ContainerName: details.ContainerName, NodeName: details.NodeName, Phase: details.Phase}
+		return true
+	}},
+	{field: TrimmedNode, trim: func(incident *IncidentContext, _ int) bool {
+		node := incident.GetNode()
+		if node == nil {
+			return false
+		}
+		incident.Node = &NodeDetails{Name: node.Name}
+		return true
+	}},
+}
+
+// repeatedStep trims a repeated field of an incident with drop. list returns the field, or nil
+// if the incident does not have its parent message.
+func repeatedStep[T gproto.Message](field string, drop func(items []T, excess int) []T, list func(incident *IncidentContext) *[]T) budgetStep {
+	return budgetStep{field: field, trim: func(incident *IncidentContext, excess int) bool {
+		items := list(incident)
+		if items == nil || len(*items) == 0 {
+			return false
+		}
+		*items = drop(*items, excess)
+		return true
+	}}
+}
+
+// TrimToBudget returns the incident unchanged if its serialized size fits in maxBytes,
+// or a trimmed copy otherwise. Logs are cut first, keeping the most recent lines, then
+// the oldest pod and node events, then the scheduling candidates of pending pods, then the
+// deployment and pod manifests, then the other repeated details, oldest or least relevant
+// first. As a last resort the typed details are reduced to the names identifying the incident,
+// so that only an incident whose identifying fields alone exceed maxBytes is left over budget.
+// A maxBytes of zero or less disables the budget.
+func TrimToBudget(incident *IncidentContext, maxBytes int) *IncidentContext {
+	if maxBytes <= 0 || gproto.Size(incident) <= maxBytes {
+		return incident
+	}
+
+	trimmed := gproto.Clone(incident).(*IncidentContext)
+	for _, step := range budgetSteps {
+		excess := gproto.Size(trimmed) - maxBytes
+		if excess <= 0 {
+			break
+		}
+		if step.trim(trimmed, excess) {
+			trimmed.TrimmedFields = append(trimmed.TrimmedFields, step.field)
+		}
+	}
+	return trimmed
+}
+
+// trimLogs shortens every log blob by the same proportion, cutting from the head so
+// the lines closest to the failure survive.
+func trimLogs(incident *IncidentContext, excess int) bool {
+	sections := incident.GetDetails().GetLogSections()
+	total := len(incident.Logs)
+	for _, section := range sections {
+		total += len(section.Content)
+	}
+	if total == 0 {
+		return false
+	}
+
+	keep := max(total-excess, 0)
+	incident.Logs, _ = keepTail(incident.Logs, len(incident.Logs)*keep/total)
+	for _, section := range sections {
+		var cut bool
+		section.Content, cut = keepTail(section.Content, len(section.Content)*keep/total)
+		section.Truncated = section.Truncated || cut
+	}
+	return true
+}
+
+// keepTail returns at most limit trailing bytes of logs, starting on a line boundary.
+func keepTail(logs string, limit int) (string, bool) {
+	if len(logs) <= limit {
+		return logs, false
+	}
+	tail := logs[len(logs)-limit:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	} else {
+		tail = ""
+	}
+	return tail, true
+}
+
+// trimContainers drops the details of the containers other than the one that triggered the
+// incident, then those of every container, last first, until roughly excess bytes are freed.
+func trimContainers(incident *IncidentContext, excess int) bool {
+	details := incident.GetDetails()
+	if len(details.GetContainers()) == 0 {
+		return false
+	}
+	freed := 0
+	kept := details.Containers[:0]
+	for _, container := range details.Containers {
+		if container.Name == details.ContainerName || freed >= excess {
+			kept = append(kept, container)
+			continue
+		}
+		freed += gproto.Size(container)
+	}
+	details.Containers = dropTail(kept, excess-freed)
+	return true
+}
+
+// dropHead drops items from the head of an oldest-first list until roughly excess bytes are freed.
+func dropHead[T gproto.Message](items []T, excess int) []T {
+	freed, dropped := 0, 0
+	for dropped < len(items) && freed < excess {
+		freed += gproto.Size(items[dropped])
+		dropped++
+	}
+	return items[dropped:]
+}
+
+// dropTail drops items from the tail of a best-first list until roughly excess bytes are freed.
+func dropTail[T gproto.Message](items []T, excess int) []T {
+	freed, keep := 0, len(items)
+	for keep > 0 && freed < excess {
+		keep--
+		freed += gproto.Size(items[keep])
+	}
+	return items[:keep]
+}
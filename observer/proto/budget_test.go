@@ -0,0 +1,244 @@
+package proto_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gproto "google.golang.org/protobuf/proto"
+
+	pb "kube-mind/observer/proto"
+)
+
+func newLargeIncident() *pb.IncidentContext {
+	events := make([]*pb.Event, 0, 50)
+	for i := 0; i < 50; i++ {
+		events = append(events, &pb.Event{Reason: "BackOff", Message: strings.Repeat("e", 200)})
+	}
+	return &pb.IncidentContext{
+		IncidentId:             "test-incident",
+		Logs:                   strings.Repeat("log line\n", 2000),
+		PodManifestJson:        strings.Repeat("p", 4000),
+		DeploymentManifestJson: strings.Repeat("d", 4000),
+		Details: &pb.IncidentDetails{
+			Events:      events,
+			LogSections: []*pb.LogSection{{Container: "app", Content: strings.Repeat("section line\n", 1000)}},
+		},
+	}
+}
+
+func TestTrimToBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		maxBytes        int
+		expectedTrimmed []string
+	}{
+		{
+			name:     "Budget disabled",
+			maxBytes: 0,
+		},
+		{
+			name:     "Incident within budget",
+			maxBytes: 1 << 20,
+		},
+		{
+			name:            "Only logs need trimming",
+			maxBytes:        20000,
+			expectedTrimmed: []string{pb.TrimmedLogs},
+		},
+		{
+			name:            "Logs and events need trimming",
+			maxBytes:        9000,
+			expectedTrimmed: []string{pb.TrimmedLogs, pb.TrimmedEvents},
+		},
+		{
+			name:            "Everything trimmable is trimmed",
+			maxBytes:        100,
+			expectedTrimmed: []string{pb.TrimmedLogs, pb.TrimmedEvents, pb.TrimmedDeploymentManifest, pb.TrimmedPodManifest},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			incident := newLargeIncident()
+			originalSize := gproto.Size(incident)
+
+			trimmed := pb.TrimToBudget(incident, tc.maxBytes)
+
+			assert.Equal(t, tc.expectedTrimmed, trimmed.TrimmedFields)
+			assert.Equal(t, originalSize, gproto.Size(incident), "the original incident must not be modified")
+			if len(tc.expectedTrimmed) == 0 {
+				assert.Same(t, incident, trimmed)
+				return
+			}
+			assert.LessOrEqual(t, gproto.Size(trimmed), tc.maxBytes)
+			assert.True(t, trimmed.Details.LogSections[0].Truncated)
+			assert.True(t, strings.HasSuffix(incident.Logs, trimmed.Logs), "the most recent log lines must be kept")
+		})
+	}
+}
+
+func TestTrimToBudget_NodeEvents(t *testing.T) {
+	t.Parallel()
+
+	incident := &pb.IncidentContext{FailureReason: "MemoryPressure", Node: &pb.NodeDetails{Name: "node-1"}}
+	for i := 0; i < 100; i++ {
+		incident.Node.Events = append(incident.Node.Events, &pb.Event{Reason: "EvictionThresholdMet", Message: strings.Repeat("x", 100)})
+	}
+	incident.Node.Events[99].Reason = "NodeHasInsufficientMemory"
+
+	trimmed := pb.TrimToBudget(incident, 2000)
+
+	assert.Equal(t, []string{pb.TrimmedNodeEvents}, trimmed.TrimmedFields)
+	assert.LessOrEqual(t, gproto.Size(trimmed), 2000)
+	assert.Equal(t, "NodeHasInsufficientMemory", trimmed.Node.Events[len(trimmed.Node.Events)-1].Reason, "the newest events must be kept")
+	assert.Len(t, incident.Node.Events, 100)
+}
+
+func TestTrimToBudget_SchedulingNodes(t *testing.T) {
+	t.Parallel()
+
+	scheduling := &pb.SchedulingDetails{ConditionReason: "Unschedulable"}
+	for i := 0; i < 50; i++ {
+		scheduling.Nodes = append(scheduling.Nodes, &pb.NodeSummary{Name: fmt.Sprintf("node-%02d", i), Allocatable: map[string]string{"cpu": "4", "memory": "16Gi"}})
+	}
+	incident := &pb.IncidentContext{FailureReason: "Unschedulable", Details: &pb.IncidentDetails{Scheduling: scheduling}}
+
+	trimmed := pb.TrimToBudget(incident, 1000)
+
+	assert.Equal(t, []string{pb.TrimmedSchedulingNodes}, trimmed.TrimmedFields)
+	assert.LessOrEqual(t, gproto.Size(trimmed), 1000)
+	assert.Equal(t, "node-00", trimmed.Details.Scheduling.Nodes[0].Name, "the best candidates must be kept")
+	assert.Len(t, incident.Details.Scheduling.Nodes, 50)
+}
+
+func TestTrimToBudget_Details(t *testing.T) {
+	t.Parallel()
+	message := strings.Repeat("m", 100)
+
+	testCases := []struct {
+		name            string
+		incident        func() *pb.IncidentContext
+		maxBytes        int
+		expectedTrimmed []string
+		check           func(t *testing.T, trimmed *pb.IncidentContext)
+	}{
+		{
+			name: "Oldest Job attempts",
+			incident: func() *pb.IncidentContext {
+				job := &pb.JobDetails{Name: "export"}
+				for i := 0; i < 50; i++ {
+					job.Attempts = append(job.Attempts, &pb.JobAttempt{PodName: fmt.Sprintf("export-%02d", i), Reason: message})
+				}
+				return &pb.IncidentContext{Details: &pb.IncidentDetails{Job: job}}
+			},
+			maxBytes:        1000,
+			expectedTrimmed: []string{pb.TrimmedJobAttempts},
+			check: func(t *testing.T, trimmed *pb.IncidentContext) {
+				attempts := trimmed.Details.Job.Attempts
+				assert.Equal(t, "export-49", attempts[len(attempts)-1].PodName, "the latest attempts must be kept")
+			},
+		},
+		{
+			name: "Last template changes",
+			incident: func() *pb.IncidentContext {
+				rollout := &pb.RolloutDetails{}
+				for i := 0; i < 50; i++ {
+					rollout.TemplateChanges = append(rollout.TemplateChanges, &pb.TemplateChange{Path: fmt.Sprintf("spec.containers[app].env[V%02d].value", i), NewValue: message})
+				}
+				return &pb.IncidentContext{Details: &pb.IncidentDetails{Rollout: rollout}}
+			},
+			maxBytes:        1000,
+			expectedTrimmed: []string{pb.TrimmedTemplateChanges},
+			check: func(t *testing.T, trimmed *pb.IncidentContext) {
+				assert.Equal(t, "spec.containers[app].env[V00].value", trimmed.Details.Rollout.TemplateChanges[0].Path)
+			},
+		},
+		{
+			name: "Oldest evicted pods",
+			incident: func() *pb.IncidentContext {
+				eviction := &pb.EvictionDetails{}
+				for i := 0; i < 50; i++ {
+					eviction.EvictedPods = append(eviction.EvictedPods, &pb.AffectedPod{Name: fmt.Sprintf("api-%02d", i), Reason: message})
+				}
+				return &pb.IncidentContext{Details: &pb.IncidentDetails{Eviction: eviction}}
+			},
+			maxBytes:        1000,
+			expectedTrimmed: []string{pb.TrimmedEvictedPods},
+		},
+		{
+			name: "Node affected pods",
+			incident: func() *pb.IncidentContext {
+				node := &pb.NodeDetails{Name: "node-1"}
+				for i := 0; i < 50; i++ {
+					node.AffectedPods = append(node.AffectedPods, &pb.AffectedPod{Name: fmt.Sprintf("api-%02d", i), Reason: message})
+				}
+				return &pb.IncidentContext{Node: node}
+			},
+			maxBytes:        1000,
+			expectedTrimmed: []string{pb.TrimmedNodeAffectedPods},
+		},
+		{
+			name: "Containers other than the failing one",
+			incident: func() *pb.IncidentContext {
+				details := &pb.IncidentDetails{ContainerName: "app"}
+				for _, name := range []string{"init", "app", "istio-proxy"} {
+					details.Containers = append(details.Containers, &pb.ContainerInfo{Name: name, State: &pb.ContainerState{Message: strings.Repeat("m", 400)}})
+				}
+				return &pb.IncidentContext{Details: details}
+			},
+			maxBytes:        1000,
+			expectedTrimmed: []string{pb.TrimmedContainers},
+			check: func(t *testing.T, trimmed *pb.IncidentContext) {
+				var names []string
+				for _, container := range trimmed.Details.Containers {
+					names = append(names, container.Name)
+				}
+				assert.Equal(t, []string{"app", "istio-proxy"}, names, "the failing container must be kept")
+			},
+		},
+		{
+			name: "Details reduced to their names as a last resort",
+			incident: func() *pb.IncidentContext {
+				return &pb.IncidentContext{
+					IncidentId: "test-incident",
+					Details: &pb.IncidentDetails{
+						ContainerName: "app",
+						Job:           &pb.JobDetails{Name: "export", ConditionMessage: strings.Repeat("m", 2000)},
+					},
+				}
+			},
+			maxBytes:        100,
+			expectedTrimmed: []string{pb.TrimmedDetails},
+			check: func(t *testing.T, trimmed *pb.IncidentContext) {
+				assert.Equal(t, "app", trimmed.Details.ContainerName)
+				assert.Nil(t, trimmed.Details.Job)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			incident := tc.incident()
+			originalSize := gproto.Size(incident)
+
+			trimmed := pb.TrimToBudget(incident, tc.maxBytes)
+
+			assert.Equal(t, tc.expectedTrimmed, trimmed.TrimmedFields)
+			assert.LessOrEqual(t, gproto.Size(trimmed), tc.maxBytes)
+			assert.Equal(t, originalSize, gproto.Size(incident), "the original incident must not be modified")
+			if tc.check != nil {
+				tc.check(t, trimmed)
+			}
+		})
+	}
+}
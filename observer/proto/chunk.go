@@ -0,0 +1,80 @@
+package proto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// ErrChunkMismatch is returned when chunks cannot be reassembled into the original incident.
+var ErrChunkMismatch = errors.New("incident chunks do not reassemble")
+
+// SplitIncident serializes an incident and splits it into ordered chunks of at most chunkSize bytes of data.
+func SplitIncident(incident *IncidentContext, chunkSize int) ([]*IncidentChunk, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	data, err := gproto.Marshal(incident)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal incident %s: %w", incident.GetIncidentId(), err)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	total := max((len(data)+chunkSize-1)/chunkSize, 1)
+	chunks := make([]*IncidentChunk, 0, total)
+	for i := 0; i < total; i++ {
+		end := min((i+1)*chunkSize, len(data))
+		chunks = append(chunks, &IncidentChunk{
+			IncidentId: incident.GetIncidentId(),
+			Index:      uint32(i),
+			Total:      uint32(total),
+			Data:       data[i*chunkSize : end],
+			Checksum:   checksum,
+			TotalSize:  uint64(len(data)),
+		})
+	}
+	return chunks, nil
+}
+
+// AssembleIncident reorders and concatenates the chunks of one incident, verifies the
+// size and checksum, and decodes the result.
+func AssembleIncident(chunks []*IncidentChunk) (*IncidentContext, error) {
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("%w: no chunks", ErrChunkMismatch)
+	}
+	first := chunks[0]
+	if int(first.GetTotal()) != len(chunks) {
+		return nil, fmt.Errorf("%w: got %d of %d chunks", ErrChunkMismatch, len(chunks), first.GetTotal())
+	}
+
+	ordered := make([][]byte, len(chunks))
+	for _, chunk := range chunks {
+		if chunk.GetIncidentId() != first.GetIncidentId() || chunk.GetChecksum() != first.GetChecksum() || chunk.GetTotal() != first.GetTotal() {
+			return nil, fmt.Errorf("%w: chunk %d belongs to another incident", ErrChunkMismatch, chunk.GetIndex())
+		}
+		if int(chunk.GetIndex()) >= len(ordered) || ordered[chunk.GetIndex()] != nil {
+			return nil, fmt.Errorf("%w: duplicate or out of range chunk %d", ErrChunkMismatch, chunk.GetIndex())
+		}
+		ordered[chunk.GetIndex()] = chunk.GetData()
+	}
+
+	data := bytes.Join(ordered, nil)
+	if uint64(len(data)) != first.GetTotalSize() {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrChunkMismatch, first.GetTotalSize(), len(data))
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != first.GetChecksum() {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrChunkMismatch)
+	}
+
+	incident := &IncidentContext{}
+	if err := gproto.Unmarshal(data, incident); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident %s: %w", first.GetIncidentId(), err)
+	}
+	return incident, nil
+}
@@ -0,0 +1,80 @@
+package proto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gproto "google.golang.org/protobuf/proto"
+
+	pb "kube-mind/observer/proto"
+)
+
+func TestSplitAndAssembleIncident(t *testing.T) {
+	t.Parallel()
+
+	incident := &pb.IncidentContext{IncidentId: "test-incident", Logs: strings.Repeat("log line\n", 500)}
+
+	chunks, err := pb.SplitIncident(incident, 1000)
+	require.NoError(t, err)
+	require.Len(t, chunks, (gproto.Size(incident)+999)/1000)
+
+	// Chunks may arrive in any order.
+	chunks[0], chunks[len(chunks)-1] = chunks[len(chunks)-1], chunks[0]
+
+	assembled, err := pb.AssembleIncident(chunks)
+	require.NoError(t, err)
+	assert.True(t, gproto.Equal(incident, assembled))
+}
+
+func TestAssembleIncident_Errors(t *testing.T) {
+	t.Parallel()
+
+	incident := &pb.IncidentContext{IncidentId: "test-incident", Logs: strings.Repeat("log line\n", 100)}
+
+	testCases := []struct {
+		name   string
+		mutate func(chunks []*pb.IncidentChunk) []*pb.IncidentChunk
+	}{
+		{
+			name:   "Missing chunk",
+			mutate: func(chunks []*pb.IncidentChunk) []*pb.IncidentChunk { return chunks[1:] },
+		},
+		{
+			name: "Duplicate chunk",
+			mutate: func(chunks []*pb.IncidentChunk) []*pb.IncidentChunk {
+				chunks[1] = chunks[0]
+				return chunks
+			},
+		},
+		{
+			name: "Chunk from another incident",
+			mutate: func(chunks []*pb.IncidentChunk) []*pb.IncidentChunk {
+				chunks[1].IncidentId = "other-incident"
+				return chunks
+			},
+		},
+		{
+			name: "Corrupted data",
+			mutate: func(chunks []*pb.IncidentChunk) []*pb.IncidentChunk {
+				chunks[0].Data = append([]byte("x"), chunks[0].Data[1:]...)
+				return chunks
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			chunks, err := pb.SplitIncident(incident, 200)
+			require.NoError(t, err)
+
+			_, err = pb.AssembleIncident(tc.mutate(chunks))
+
+			assert.ErrorIs(t, err, pb.ErrChunkMismatch)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package proto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Workload returns the top-most controller of the incident's pod as "Kind/name",
+// "Pod/name" for pods without a controller, or "Node/name" for node incidents.
+func Workload(incident *IncidentContext) string {
+	if node := incident.GetNode(); node != nil {
+		return "Node/" + node.GetName()
+	}
+	chain := incident.GetDetails().GetOwnerChain()
+	if len(chain) == 0 {
+		return "Pod/" + incident.GetPodName()
+	}
+	top := chain[len(chain)-1]
+	return top.GetKind() + "/" + top.GetName()
+}
+
+// Subject names what the incident is about: "namespace/Kind/name" for workloads, or
+// "Kind/name" for cluster-scoped objects such as Nodes.
+func Subject(incident *IncidentContext) string {
+	if incident.GetPodNamespace() == "" {
+		return Workload(incident)
+	}
+	return incident.GetPodNamespace() + "/" + Workload(incident)
+}
+
+// Fingerprint identifies recurring incidents: the same failure of the same container of the
+// same workload yields the same fingerprint across pod restarts and rollouts.
+func Fingerprint(incident *IncidentContext) string {
+	key := strings.Join([]string{
+		incident.GetClusterId(),
+		incident.GetPodNamespace(),
+		Workload(incident),
+		incident.GetDetails().GetContainerName(),
+		incident.GetFailureReason(),
+	}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,42 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "kube-mind/observer/proto"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	newIncident := func(podName, reason string) *pb.IncidentContext {
+		return &pb.IncidentContext{
+			PodName:       podName,
+			PodNamespace:  "payments",
+			FailureReason: reason,
+			Details: &pb.IncidentDetails{
+				ContainerName: "app",
+				OwnerChain: []*pb.OwnerReference{
+					{Kind: "ReplicaSet", Name: "api-7d9f"},
+					{Kind: "Deployment", Name: "api"},
+				},
+			},
+		}
+	}
+
+	first := newIncident("api-7d9f-abcde", "OOMKilled")
+	restarted := newIncident("api-7d9f-fghij", "OOMKilled")
+	otherReason := newIncident("api-7d9f-abcde", "CrashLoopBackOff")
+
+	assert.Equal(t, "Deployment/api", pb.Workload(first))
+	assert.Equal(t, "Pod/bare", pb.Workload(&pb.IncidentContext{PodName: "bare"}))
+	assert.Equal(t, "payments/Deployment/api", pb.Subject(first))
+	node := &pb.IncidentContext{FailureReason: "MemoryPressure", Node: &pb.NodeDetails{Name: "node-1"}}
+	assert.Equal(t, "Node/node-1", pb.Workload(node))
+	assert.Equal(t, "Node/node-1", pb.Subject(node))
+	assert.Len(t, pb.Fingerprint(first), 16)
+	assert.Equal(t, pb.Fingerprint(first), pb.Fingerprint(restarted))
+	assert.NotEqual(t, pb.Fingerprint(first), pb.Fingerprint(otherReason))
+}
@@ -1,15 +1,15 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
+// 	protoc-gen-go v1.36.10
 // 	protoc        v3.12.4
 // source: incident.proto
 
 package proto
 
 import (
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -29,12 +29,27 @@ type IncidentContext struct {
 	PodName                string                 `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
 	PodNamespace           string                 `protobuf:"bytes,3,opt,name=pod_namespace,json=podNamespace,proto3" json:"pod_namespace,omitempty"`
 	FailureReason          string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`                              // e.g., "OOMKilled", "CrashLoopBackOff"
-	Logs                   string                 `protobuf:"bytes,5,opt,name=logs,proto3" json:"logs,omitempty"`                                                                     // Last N lines of logs
-	PodManifestJson        string                 `protobuf:"bytes,6,opt,name=pod_manifest_json,json=podManifestJson,proto3" json:"pod_manifest_json,omitempty"`                      // Redacted Pod manifest
-	DeploymentManifestJson string                 `protobuf:"bytes,7,opt,name=deployment_manifest_json,json=deploymentManifestJson,proto3" json:"deployment_manifest_json,omitempty"` // Redacted Deployment manifest
-	Timestamp              *timestamp.Timestamp   `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	Logs                   string                 `protobuf:"bytes,5,opt,name=logs,proto3" json:"logs,omitempty"`                                                                     // Last N lines of container logs
+	PodManifestJson        string                 `protobuf:"bytes,6,opt,name=pod_manifest_json,json=podManifestJson,proto3" json:"pod_manifest_json,omitempty"`                      // Redacted Pod manifest (JSON)
+	DeploymentManifestJson string                 `protobuf:"bytes,7,opt,name=deployment_manifest_json,json=deploymentManifestJson,proto3" json:"deployment_manifest_json,omitempty"` // Redacted Deployment manifest (JSON)
+	Timestamp              *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// cluster_id is optional — empty string means Brain uses DEFAULT_CLUSTER_ID setting.
+	// Added in schema version 2.
+	ClusterId string `protobuf:"bytes,9,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	// details is the typed view of the incident. The string fields above stay
+	// populated so that Brains on schema version 2 or older keep working.
+	// Added in schema version 3.
+	Details *IncidentDetails `protobuf:"bytes,10,opt,name=details,proto3" json:"details,omitempty"`
+	// trimmed_fields lists what was cut to fit the per-incident size budget,
+	// in the order it was trimmed, e.g. ["logs", "details.events"].
+	// Added in schema version 4.
+	TrimmedFields []string `protobuf:"bytes,11,rep,name=trimmed_fields,json=trimmedFields,proto3" json:"trimmed_fields,omitempty"`
+	// node is set for incidents of a Node rather than a pod; the pod fields are
+	// then empty. Pod incidents name their node in details.node_name, so Brains
+	// can correlate them. Added in schema version 6.
+	Node          *NodeDetails `protobuf:"bytes,12,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IncidentContext) Reset() {
@@ -116,35 +131,87 @@ func (x *IncidentContext) GetDeploymentManifestJson() string {
 	return ""
 }
 
-func (x *IncidentContext) GetTimestamp() *timestamp.Timestamp {
+func (x *IncidentContext) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Timestamp
 	}
 	return nil
 }
 
-// The response message for the StreamIncident RPC.
-type StreamIncidentResponse struct {
+func (x *IncidentContext) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
+func (x *IncidentContext) GetDetails() *IncidentDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *IncidentContext) GetTrimmedFields() []string {
+	if x != nil {
+		return x.TrimmedFields
+	}
+	return nil
+}
+
+func (x *IncidentContext) GetNode() *NodeDetails {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// IncidentDetails is the typed view of a failing pod, so Brains do not have to
+// re-parse the manifest and log blobs.
+type IncidentDetails struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ContainerName string                 `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"` // Container that triggered the incident
+	NodeName      string                 `protobuf:"bytes,2,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Phase         string                 `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"` // Pod phase, e.g. "Running", "Pending"
+	QosClass      string                 `protobuf:"bytes,4,opt,name=qos_class,json=qosClass,proto3" json:"qos_class,omitempty"`
+	Containers    []*ContainerInfo       `protobuf:"bytes,5,rep,name=containers,proto3" json:"containers,omitempty"`                   // Init containers first, then app containers
+	OwnerChain    []*OwnerReference      `protobuf:"bytes,6,rep,name=owner_chain,json=ownerChain,proto3" json:"owner_chain,omitempty"` // Immediate owner first, e.g. ReplicaSet then Deployment
+	Events        []*Event               `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`                           // Events involving the pod, oldest first
+	LogSections   []*LogSection          `protobuf:"bytes,8,rep,name=log_sections,json=logSections,proto3" json:"log_sections,omitempty"`
+	// settings are the effective settings the incident was harvested with.
+	// Added in schema version 5.
+	Settings *ObservationSettings `protobuf:"bytes,9,opt,name=settings,proto3" json:"settings,omitempty"`
+	// scheduling is set for pods stuck in Pending. Added in schema version 7.
+	Scheduling *SchedulingDetails `protobuf:"bytes,10,opt,name=scheduling,proto3" json:"scheduling,omitempty"`
+	// eviction is set for pods evicted or preempted. Added in schema version 8.
+	Eviction *EvictionDetails `protobuf:"bytes,11,opt,name=eviction,proto3" json:"eviction,omitempty"`
+	// probes is set when the container failed its probes. Added in schema version 9.
+	Probes *ProbeDetails `protobuf:"bytes,12,opt,name=probes,proto3" json:"probes,omitempty"`
+	// rollout is set for incidents of a Deployment rollout, which name the
+	// Deployment as the only entry of owner_chain. Added in schema version 10.
+	Rollout *RolloutDetails `protobuf:"bytes,13,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	// job is set for incidents of a failed Job or of a CronJob whose runs fail.
+	// owner_chain names the Job and its CronJob, and log_sections hold the logs
+	// of the failed attempts, naming their pod. Added in schema version 11.
+	Job           *JobDetails `protobuf:"bytes,14,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamIncidentResponse) Reset() {
-	*x = StreamIncidentResponse{}
+func (x *IncidentDetails) Reset() {
+	*x = IncidentDetails{}
 	mi := &file_incident_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamIncidentResponse) String() string {
+func (x *IncidentDetails) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamIncidentResponse) ProtoMessage() {}
+func (*IncidentDetails) ProtoMessage() {}
 
-func (x *StreamIncidentResponse) ProtoReflect() protoreflect.Message {
+func (x *IncidentDetails) ProtoReflect() protoreflect.Message {
 	mi := &file_incident_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -156,37 +223,2936 @@ func (x *StreamIncidentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamIncidentResponse.ProtoReflect.Descriptor instead.
-func (*StreamIncidentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use IncidentDetails.ProtoReflect.Descriptor instead.
+func (*IncidentDetails) Descriptor() ([]byte, []int) {
 	return file_incident_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *StreamIncidentResponse) GetStatus() string {
+func (x *IncidentDetails) GetContainerName() string {
 	if x != nil {
-		return x.Status
+		return x.ContainerName
 	}
 	return ""
 }
 
-var File_incident_proto protoreflect.FileDescriptor
+func (x *IncidentDetails) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
 
-const file_incident_proto_rawDesc = "" +
-	"\n" +
-	"\x0eincident.proto\x12\bkubemind\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x02\n" +
-	"\x0fIncidentContext\x12\x1f\n" +
-	"\vincident_id\x18\x01 \x01(\tR\n" +
-	"incidentId\x12\x19\n" +
-	"\bpod_name\x18\x02 \x01(\tR\apodName\x12#\n" +
-	"\rpod_namespace\x18\x03 \x01(\tR\fpodNamespace\x12%\n" +
-	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12\x12\n" +
-	"\x04logs\x18\x05 \x01(\tR\x04logs\x12*\n" +
-	"\x11pod_manifest_json\x18\x06 \x01(\tR\x0fpodManifestJson\x128\n" +
-	"\x18deployment_manifest_json\x18\a \x01(\tR\x16deploymentManifestJson\x128\n" +
-	"\ttimestamp\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"0\n" +
+func (x *IncidentDetails) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *IncidentDetails) GetQosClass() string {
+	if x != nil {
+		return x.QosClass
+	}
+	return ""
+}
+
+func (x *IncidentDetails) GetContainers() []*ContainerInfo {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetOwnerChain() []*OwnerReference {
+	if x != nil {
+		return x.OwnerChain
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetLogSections() []*LogSection {
+	if x != nil {
+		return x.LogSections
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetSettings() *ObservationSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetScheduling() *SchedulingDetails {
+	if x != nil {
+		return x.Scheduling
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetEviction() *EvictionDetails {
+	if x != nil {
+		return x.Eviction
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetProbes() *ProbeDetails {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetRollout() *RolloutDetails {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *IncidentDetails) GetJob() *JobDetails {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// JobDetails describes a failed Job and, for the runs of a CronJob, the CronJob.
+type JobDetails struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Name                  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ConditionReason       string                 `protobuf:"bytes,2,opt,name=condition_reason,json=conditionReason,proto3" json:"condition_reason,omitempty"` // Reason of the Failed condition, e.g. "BackoffLimitExceeded"
+	ConditionMessage      string                 `protobuf:"bytes,3,opt,name=condition_message,json=conditionMessage,proto3" json:"condition_message,omitempty"`
+	StartTime             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	FailedAt              *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	BackoffLimit          int32                  `protobuf:"varint,6,opt,name=backoff_limit,json=backoffLimit,proto3" json:"backoff_limit,omitempty"`
+	ActiveDeadlineSeconds int64                  `protobuf:"varint,7,opt,name=active_deadline_seconds,json=activeDeadlineSeconds,proto3" json:"active_deadline_seconds,omitempty"` // 0 when unset
+	Completions           int32                  `protobuf:"varint,8,opt,name=completions,proto3" json:"completions,omitempty"`
+	Parallelism           int32                  `protobuf:"varint,9,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	Succeeded             int32                  `protobuf:"varint,10,opt,name=succeeded,proto3" json:"succeeded,omitempty"`           // Pods that succeeded
+	Failed                int32                  `protobuf:"varint,11,opt,name=failed,proto3" json:"failed,omitempty"`                 // Pods that failed
+	Attempts              []*JobAttempt          `protobuf:"bytes,12,rep,name=attempts,proto3" json:"attempts,omitempty"`              // Failed attempts, oldest first, including those of deleted pods
+	CronJob               *CronJobDetails        `protobuf:"bytes,13,opt,name=cron_job,json=cronJob,proto3" json:"cron_job,omitempty"` // Set for the runs of a CronJob
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *JobDetails) Reset() {
+	*x = JobDetails{}
+	mi := &file_incident_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobDetails) ProtoMessage() {}
+
+func (x *JobDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobDetails.ProtoReflect.Descriptor instead.
+func (*JobDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JobDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobDetails) GetConditionReason() string {
+	if x != nil {
+		return x.ConditionReason
+	}
+	return ""
+}
+
+func (x *JobDetails) GetConditionMessage() string {
+	if x != nil {
+		return x.ConditionMessage
+	}
+	return ""
+}
+
+func (x *JobDetails) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *JobDetails) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *JobDetails) GetBackoffLimit() int32 {
+	if x != nil {
+		return x.BackoffLimit
+	}
+	return 0
+}
+
+func (x *JobDetails) GetActiveDeadlineSeconds() int64 {
+	if x != nil {
+		return x.ActiveDeadlineSeconds
+	}
+	return 0
+}
+
+func (x *JobDetails) GetCompletions() int32 {
+	if x != nil {
+		return x.Completions
+	}
+	return 0
+}
+
+func (x *JobDetails) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *JobDetails) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *JobDetails) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *JobDetails) GetAttempts() []*JobAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *JobDetails) GetCronJob() *CronJobDetails {
+	if x != nil {
+		return x.CronJob
+	}
+	return nil
+}
+
+// JobAttempt is a failed run of a Job's container.
+type JobAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PodName       string                 `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	NodeName      string                 `protobuf:"bytes,2,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Container     string                 `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Termination reason, e.g. "Error", "OOMKilled"
+	ExitCode      int32                  `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobAttempt) Reset() {
+	*x = JobAttempt{}
+	mi := &file_incident_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobAttempt) ProtoMessage() {}
+
+func (x *JobAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobAttempt.ProtoReflect.Descriptor instead.
+func (*JobAttempt) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JobAttempt) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *JobAttempt) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobAttempt) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// CronJobDetails describes a CronJob and its recent runs.
+type CronJobDetails struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule            string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`                                            // e.g. "*/15 * * * *"
+	TimeZone            string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                            // Empty for the time zone of the controller manager
+	ConcurrencyPolicy   string                 `protobuf:"bytes,4,opt,name=concurrency_policy,json=concurrencyPolicy,proto3" json:"concurrency_policy,omitempty"` // "Allow", "Forbid" or "Replace"
+	Suspended           bool                   `protobuf:"varint,5,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	LastScheduleTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_schedule_time,json=lastScheduleTime,proto3" json:"last_schedule_time,omitempty"`
+	LastSuccessfulTime  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_successful_time,json=lastSuccessfulTime,proto3" json:"last_successful_time,omitempty"`
+	ConsecutiveFailures int32                  `protobuf:"varint,8,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"` // Runs that failed since the last successful run
+	RecentRuns          []*CronJobRun          `protobuf:"bytes,9,rep,name=recent_runs,json=recentRuns,proto3" json:"recent_runs,omitempty"`                             // Oldest first, including failed runs already deleted
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CronJobDetails) Reset() {
+	*x = CronJobDetails{}
+	mi := &file_incident_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronJobDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronJobDetails) ProtoMessage() {}
+
+func (x *CronJobDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronJobDetails.ProtoReflect.Descriptor instead.
+func (*CronJobDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CronJobDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronJobDetails) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *CronJobDetails) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CronJobDetails) GetConcurrencyPolicy() string {
+	if x != nil {
+		return x.ConcurrencyPolicy
+	}
+	return ""
+}
+
+func (x *CronJobDetails) GetSuspended() bool {
+	if x != nil {
+		return x.Suspended
+	}
+	return false
+}
+
+func (x *CronJobDetails) GetLastScheduleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastScheduleTime
+	}
+	return nil
+}
+
+func (x *CronJobDetails) GetLastSuccessfulTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccessfulTime
+	}
+	return nil
+}
+
+func (x *CronJobDetails) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *CronJobDetails) GetRecentRuns() []*CronJobRun {
+	if x != nil {
+		return x.RecentRuns
+	}
+	return nil
+}
+
+// CronJobRun is a Job created by a CronJob.
+type CronJobRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobName       string                 `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "Succeeded", "Failed" or "Active"
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Reason of the Failed condition
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronJobRun) Reset() {
+	*x = CronJobRun{}
+	mi := &file_incident_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronJobRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronJobRun) ProtoMessage() {}
+
+func (x *CronJobRun) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronJobRun.ProtoReflect.Descriptor instead.
+func (*CronJobRun) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CronJobRun) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *CronJobRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CronJobRun) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CronJobRun) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CronJobRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// RolloutDetails describes a Deployment rollout that exceeded its progress
+// deadline or stalled with the pods of its new ReplicaSet failing.
+type RolloutDetails struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Revision                int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"` // Revision of the new ReplicaSet
+	NewReplicaSet           string                 `protobuf:"bytes,2,opt,name=new_replica_set,json=newReplicaSet,proto3" json:"new_replica_set,omitempty"`
+	OldReplicaSet           string                 `protobuf:"bytes,3,opt,name=old_replica_set,json=oldReplicaSet,proto3" json:"old_replica_set,omitempty"` // Previous revision the template changes are computed from
+	OldRevision             int64                  `protobuf:"varint,4,opt,name=old_revision,json=oldRevision,proto3" json:"old_revision,omitempty"`
+	Replicas                int32                  `protobuf:"varint,5,opt,name=replicas,proto3" json:"replicas,omitempty"` // Desired replicas of the Deployment
+	UpdatedReplicas         int32                  `protobuf:"varint,6,opt,name=updated_replicas,json=updatedReplicas,proto3" json:"updated_replicas,omitempty"`
+	ReadyReplicas           int32                  `protobuf:"varint,7,opt,name=ready_replicas,json=readyReplicas,proto3" json:"ready_replicas,omitempty"`
+	AvailableReplicas       int32                  `protobuf:"varint,8,opt,name=available_replicas,json=availableReplicas,proto3" json:"available_replicas,omitempty"`
+	UnavailableReplicas     int32                  `protobuf:"varint,9,opt,name=unavailable_replicas,json=unavailableReplicas,proto3" json:"unavailable_replicas,omitempty"`
+	OldAvailableReplicas    int32                  `protobuf:"varint,10,opt,name=old_available_replicas,json=oldAvailableReplicas,proto3" json:"old_available_replicas,omitempty"` // Available replicas of the old ReplicaSets, still serving
+	ConditionReason         string                 `protobuf:"bytes,11,opt,name=condition_reason,json=conditionReason,proto3" json:"condition_reason,omitempty"`                   // Reason of the Progressing condition, e.g. "ProgressDeadlineExceeded"
+	ConditionMessage        string                 `protobuf:"bytes,12,opt,name=condition_message,json=conditionMessage,proto3" json:"condition_message,omitempty"`
+	LastUpdate              *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"` // Last update of the Progressing condition
+	ProgressDeadlineSeconds int32                  `protobuf:"varint,14,opt,name=progress_deadline_seconds,json=progressDeadlineSeconds,proto3" json:"progress_deadline_seconds,omitempty"`
+	Strategy                string                 `protobuf:"bytes,15,opt,name=strategy,proto3" json:"strategy,omitempty"`                                      // e.g. "RollingUpdate maxSurge=25% maxUnavailable=25%"
+	TemplateChanges         []*TemplateChange      `protobuf:"bytes,16,rep,name=template_changes,json=templateChanges,proto3" json:"template_changes,omitempty"` // Pod template changes from the old ReplicaSet to the new one
+	FailingPods             []*AffectedPod         `protobuf:"bytes,17,rep,name=failing_pods,json=failingPods,proto3" json:"failing_pods,omitempty"`             // Pods of the new ReplicaSet in a failure state
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RolloutDetails) Reset() {
+	*x = RolloutDetails{}
+	mi := &file_incident_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolloutDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutDetails) ProtoMessage() {}
+
+func (x *RolloutDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutDetails.ProtoReflect.Descriptor instead.
+func (*RolloutDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RolloutDetails) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetNewReplicaSet() string {
+	if x != nil {
+		return x.NewReplicaSet
+	}
+	return ""
+}
+
+func (x *RolloutDetails) GetOldReplicaSet() string {
+	if x != nil {
+		return x.OldReplicaSet
+	}
+	return ""
+}
+
+func (x *RolloutDetails) GetOldRevision() int64 {
+	if x != nil {
+		return x.OldRevision
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetUpdatedReplicas() int32 {
+	if x != nil {
+		return x.UpdatedReplicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetReadyReplicas() int32 {
+	if x != nil {
+		return x.ReadyReplicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetAvailableReplicas() int32 {
+	if x != nil {
+		return x.AvailableReplicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetUnavailableReplicas() int32 {
+	if x != nil {
+		return x.UnavailableReplicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetOldAvailableReplicas() int32 {
+	if x != nil {
+		return x.OldAvailableReplicas
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetConditionReason() string {
+	if x != nil {
+		return x.ConditionReason
+	}
+	return ""
+}
+
+func (x *RolloutDetails) GetConditionMessage() string {
+	if x != nil {
+		return x.ConditionMessage
+	}
+	return ""
+}
+
+func (x *RolloutDetails) GetLastUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *RolloutDetails) GetProgressDeadlineSeconds() int32 {
+	if x != nil {
+		return x.ProgressDeadlineSeconds
+	}
+	return 0
+}
+
+func (x *RolloutDetails) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *RolloutDetails) GetTemplateChanges() []*TemplateChange {
+	if x != nil {
+		return x.TemplateChanges
+	}
+	return nil
+}
+
+func (x *RolloutDetails) GetFailingPods() []*AffectedPod {
+	if x != nil {
+		return x.FailingPods
+	}
+	return nil
+}
+
+// TemplateChange is a value of the pod template that differs between two
+// revisions, with redacted values.
+type TemplateChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                         // e.g. "spec.containers[app].image", "spec.containers[app].env[LOG_LEVEL].value"
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"` // Empty when added
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"` // Empty when removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateChange) Reset() {
+	*x = TemplateChange{}
+	mi := &file_incident_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateChange) ProtoMessage() {}
+
+func (x *TemplateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateChange.ProtoReflect.Descriptor instead.
+func (*TemplateChange) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TemplateChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TemplateChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *TemplateChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// ProbeDetails describe the probes of the container that triggered the incident
+// and their recent failures.
+type ProbeDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Readiness     *Probe                 `protobuf:"bytes,1,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	Liveness      *Probe                 `protobuf:"bytes,2,opt,name=liveness,proto3" json:"liveness,omitempty"`
+	Startup       *Probe                 `protobuf:"bytes,3,opt,name=startup,proto3" json:"startup,omitempty"`
+	NotReadySince *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_ready_since,json=notReadySince,proto3" json:"not_ready_since,omitempty"` // Set while the container is not ready
+	Failures      []*ProbeFailure        `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`                                  // Most recent Unhealthy events of the container, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeDetails) Reset() {
+	*x = ProbeDetails{}
+	mi := &file_incident_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeDetails) ProtoMessage() {}
+
+func (x *ProbeDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeDetails.ProtoReflect.Descriptor instead.
+func (*ProbeDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProbeDetails) GetReadiness() *Probe {
+	if x != nil {
+		return x.Readiness
+	}
+	return nil
+}
+
+func (x *ProbeDetails) GetLiveness() *Probe {
+	if x != nil {
+		return x.Liveness
+	}
+	return nil
+}
+
+func (x *ProbeDetails) GetStartup() *Probe {
+	if x != nil {
+		return x.Startup
+	}
+	return nil
+}
+
+func (x *ProbeDetails) GetNotReadySince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotReadySince
+	}
+	return nil
+}
+
+func (x *ProbeDetails) GetFailures() []*ProbeFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// Probe is a corev1.Probe with its handler flattened.
+type Probe struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Handler             string                 `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"` // e.g. "httpGet http :8080/healthz", "tcpSocket :5432", "exec cat /tmp/ready"
+	InitialDelaySeconds int32                  `protobuf:"varint,2,opt,name=initial_delay_seconds,json=initialDelaySeconds,proto3" json:"initial_delay_seconds,omitempty"`
+	PeriodSeconds       int32                  `protobuf:"varint,3,opt,name=period_seconds,json=periodSeconds,proto3" json:"period_seconds,omitempty"`
+	TimeoutSeconds      int32                  `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	SuccessThreshold    int32                  `protobuf:"varint,5,opt,name=success_threshold,json=successThreshold,proto3" json:"success_threshold,omitempty"`
+	FailureThreshold    int32                  `protobuf:"varint,6,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Probe) Reset() {
+	*x = Probe{}
+	mi := &file_incident_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Probe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probe) ProtoMessage() {}
+
+func (x *Probe) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probe.ProtoReflect.Descriptor instead.
+func (*Probe) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Probe) GetHandler() string {
+	if x != nil {
+		return x.Handler
+	}
+	return ""
+}
+
+func (x *Probe) GetInitialDelaySeconds() int32 {
+	if x != nil {
+		return x.InitialDelaySeconds
+	}
+	return 0
+}
+
+func (x *Probe) GetPeriodSeconds() int32 {
+	if x != nil {
+		return x.PeriodSeconds
+	}
+	return 0
+}
+
+func (x *Probe) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *Probe) GetSuccessThreshold() int32 {
+	if x != nil {
+		return x.SuccessThreshold
+	}
+	return 0
+}
+
+func (x *Probe) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+// ProbeFailure is an Unhealthy event of a container.
+type ProbeFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Probe         string                 `protobuf:"bytes,1,opt,name=probe,proto3" json:"probe,omitempty"`     // "Readiness", "Liveness" or "Startup"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // e.g. "HTTP probe failed with statuscode: 503"
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeFailure) Reset() {
+	*x = ProbeFailure{}
+	mi := &file_incident_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeFailure) ProtoMessage() {}
+
+func (x *ProbeFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeFailure.ProtoReflect.Descriptor instead.
+func (*ProbeFailure) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProbeFailure) GetProbe() string {
+	if x != nil {
+		return x.Probe
+	}
+	return ""
+}
+
+func (x *ProbeFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProbeFailure) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ProbeFailure) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// EvictionDetails describe why a pod was evicted or preempted, and which other
+// pods left the same node with it.
+type EvictionDetails struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Reason            string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`   // Pod status or DisruptionTarget reason, e.g. "Evicted", "PreemptionByScheduler"
+	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // e.g. "The node was low on resource: memory."
+	EvictedAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=evicted_at,json=evictedAt,proto3" json:"evicted_at,omitempty"`
+	Priority          int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	PriorityClassName string                 `protobuf:"bytes,5,opt,name=priority_class_name,json=priorityClassName,proto3" json:"priority_class_name,omitempty"`
+	QosClass          string                 `protobuf:"bytes,6,opt,name=qos_class,json=qosClass,proto3" json:"qos_class,omitempty"`
+	NodeConditions    []*NodeCondition       `protobuf:"bytes,7,rep,name=node_conditions,json=nodeConditions,proto3" json:"node_conditions,omitempty"` // Readiness and pressure conditions of the node when reported
+	EvictedPods       []*AffectedPod         `protobuf:"bytes,8,rep,name=evicted_pods,json=evictedPods,proto3" json:"evicted_pods,omitempty"`          // Pods evicted from the node in the same window, this one included, oldest first
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EvictionDetails) Reset() {
+	*x = EvictionDetails{}
+	mi := &file_incident_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvictionDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvictionDetails) ProtoMessage() {}
+
+func (x *EvictionDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvictionDetails.ProtoReflect.Descriptor instead.
+func (*EvictionDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EvictionDetails) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *EvictionDetails) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EvictionDetails) GetEvictedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvictedAt
+	}
+	return nil
+}
+
+func (x *EvictionDetails) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *EvictionDetails) GetPriorityClassName() string {
+	if x != nil {
+		return x.PriorityClassName
+	}
+	return ""
+}
+
+func (x *EvictionDetails) GetQosClass() string {
+	if x != nil {
+		return x.QosClass
+	}
+	return ""
+}
+
+func (x *EvictionDetails) GetNodeConditions() []*NodeCondition {
+	if x != nil {
+		return x.NodeConditions
+	}
+	return nil
+}
+
+func (x *EvictionDetails) GetEvictedPods() []*AffectedPod {
+	if x != nil {
+		return x.EvictedPods
+	}
+	return nil
+}
+
+// SchedulingDetails describe why a pod has stayed Pending, as seen by the scheduler.
+type SchedulingDetails struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PendingSince      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=pending_since,json=pendingSince,proto3" json:"pending_since,omitempty"`
+	Scheduled         bool                   `protobuf:"varint,2,opt,name=scheduled,proto3" json:"scheduled,omitempty"`                                                                        // Bound to a node but its containers did not start
+	ConditionReason   string                 `protobuf:"bytes,3,opt,name=condition_reason,json=conditionReason,proto3" json:"condition_reason,omitempty"`                                      // Reason of the PodScheduled condition, e.g. "Unschedulable"
+	ConditionMessage  string                 `protobuf:"bytes,4,opt,name=condition_message,json=conditionMessage,proto3" json:"condition_message,omitempty"`                                   // e.g. "0/5 nodes are available: 3 Insufficient cpu."
+	FailedScheduling  []string               `protobuf:"bytes,5,rep,name=failed_scheduling,json=failedScheduling,proto3" json:"failed_scheduling,omitempty"`                                   // Messages of the FailedScheduling events, oldest first
+	Requests          map[string]string      `protobuf:"bytes,6,rep,name=requests,proto3" json:"requests,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Resources the scheduler accounts the pod for
+	NodeSelector      map[string]string      `protobuf:"bytes,7,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AffinityJson      string                 `protobuf:"bytes,8,opt,name=affinity_json,json=affinityJson,proto3" json:"affinity_json,omitempty"` // Pod spec affinity, as JSON
+	Tolerations       []*Toleration          `protobuf:"bytes,9,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	PriorityClassName string                 `protobuf:"bytes,10,opt,name=priority_class_name,json=priorityClassName,proto3" json:"priority_class_name,omitempty"`
+	Nodes             []*NodeSummary         `protobuf:"bytes,11,rep,name=nodes,proto3" json:"nodes,omitempty"` // Nodes the pod could run on, best fit first
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SchedulingDetails) Reset() {
+	*x = SchedulingDetails{}
+	mi := &file_incident_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulingDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulingDetails) ProtoMessage() {}
+
+func (x *SchedulingDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulingDetails.ProtoReflect.Descriptor instead.
+func (*SchedulingDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SchedulingDetails) GetPendingSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PendingSince
+	}
+	return nil
+}
+
+func (x *SchedulingDetails) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *SchedulingDetails) GetConditionReason() string {
+	if x != nil {
+		return x.ConditionReason
+	}
+	return ""
+}
+
+func (x *SchedulingDetails) GetConditionMessage() string {
+	if x != nil {
+		return x.ConditionMessage
+	}
+	return ""
+}
+
+func (x *SchedulingDetails) GetFailedScheduling() []string {
+	if x != nil {
+		return x.FailedScheduling
+	}
+	return nil
+}
+
+func (x *SchedulingDetails) GetRequests() map[string]string {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *SchedulingDetails) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *SchedulingDetails) GetAffinityJson() string {
+	if x != nil {
+		return x.AffinityJson
+	}
+	return ""
+}
+
+func (x *SchedulingDetails) GetTolerations() []*Toleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *SchedulingDetails) GetPriorityClassName() string {
+	if x != nil {
+		return x.PriorityClassName
+	}
+	return ""
+}
+
+func (x *SchedulingDetails) GetNodes() []*NodeSummary {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// Toleration is a corev1.Toleration.
+type Toleration struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Key               string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator          string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "Exists" or "Equal"
+	Value             string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Effect            string                 `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	TolerationSeconds int64                  `protobuf:"varint,5,opt,name=toleration_seconds,json=tolerationSeconds,proto3" json:"toleration_seconds,omitempty"` // 0 when unset
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Toleration) Reset() {
+	*x = Toleration{}
+	mi := &file_incident_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Toleration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Toleration) ProtoMessage() {}
+
+func (x *Toleration) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Toleration.ProtoReflect.Descriptor instead.
+func (*Toleration) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Toleration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Toleration) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Toleration) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Toleration) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *Toleration) GetTolerationSeconds() int64 {
+	if x != nil {
+		return x.TolerationSeconds
+	}
+	return 0
+}
+
+// NodeSummary is the capacity of a node a pending pod could be scheduled to.
+type NodeSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ready         bool                   `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	Unschedulable bool                   `protobuf:"varint,3,opt,name=unschedulable,proto3" json:"unschedulable,omitempty"`
+	Allocatable   map[string]string      `protobuf:"bytes,4,rep,name=allocatable,proto3" json:"allocatable,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Requested     map[string]string      `protobuf:"bytes,5,rep,name=requested,proto3" json:"requested,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Taints        []*Taint               `protobuf:"bytes,6,rep,name=taints,proto3" json:"taints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSummary) Reset() {
+	*x = NodeSummary{}
+	mi := &file_incident_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSummary) ProtoMessage() {}
+
+func (x *NodeSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSummary.ProtoReflect.Descriptor instead.
+func (*NodeSummary) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NodeSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeSummary) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *NodeSummary) GetUnschedulable() bool {
+	if x != nil {
+		return x.Unschedulable
+	}
+	return false
+}
+
+func (x *NodeSummary) GetAllocatable() map[string]string {
+	if x != nil {
+		return x.Allocatable
+	}
+	return nil
+}
+
+func (x *NodeSummary) GetRequested() map[string]string {
+	if x != nil {
+		return x.Requested
+	}
+	return nil
+}
+
+func (x *NodeSummary) GetTaints() []*Taint {
+	if x != nil {
+		return x.Taints
+	}
+	return nil
+}
+
+// NodeDetails describe a node in trouble and the pods it runs.
+type NodeDetails struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Conditions     []*NodeCondition       `protobuf:"bytes,2,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	Capacity       map[string]string      `protobuf:"bytes,3,rep,name=capacity,proto3" json:"capacity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Allocatable    map[string]string      `protobuf:"bytes,4,rep,name=allocatable,proto3" json:"allocatable,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Requested      map[string]string      `protobuf:"bytes,5,rep,name=requested,proto3" json:"requested,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Sum of the requests of the pods running on the node
+	Taints         []*Taint               `protobuf:"bytes,6,rep,name=taints,proto3" json:"taints,omitempty"`
+	Unschedulable  bool                   `protobuf:"varint,7,opt,name=unschedulable,proto3" json:"unschedulable,omitempty"` // Cordoned
+	KubeletVersion string                 `protobuf:"bytes,8,opt,name=kubelet_version,json=kubeletVersion,proto3" json:"kubelet_version,omitempty"`
+	BootId         string                 `protobuf:"bytes,9,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"`
+	AffectedPods   []*AffectedPod         `protobuf:"bytes,10,rep,name=affected_pods,json=affectedPods,proto3" json:"affected_pods,omitempty"`
+	Events         []*Event               `protobuf:"bytes,11,rep,name=events,proto3" json:"events,omitempty"` // Events involving the node, oldest first
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NodeDetails) Reset() {
+	*x = NodeDetails{}
+	mi := &file_incident_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeDetails) ProtoMessage() {}
+
+func (x *NodeDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeDetails.ProtoReflect.Descriptor instead.
+func (*NodeDetails) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NodeDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetConditions() []*NodeCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetCapacity() map[string]string {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetAllocatable() map[string]string {
+	if x != nil {
+		return x.Allocatable
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetRequested() map[string]string {
+	if x != nil {
+		return x.Requested
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetTaints() []*Taint {
+	if x != nil {
+		return x.Taints
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetUnschedulable() bool {
+	if x != nil {
+		return x.Unschedulable
+	}
+	return false
+}
+
+func (x *NodeDetails) GetKubeletVersion() string {
+	if x != nil {
+		return x.KubeletVersion
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetBootId() string {
+	if x != nil {
+		return x.BootId
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetAffectedPods() []*AffectedPod {
+	if x != nil {
+		return x.AffectedPods
+	}
+	return nil
+}
+
+func (x *NodeDetails) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// NodeCondition is a corev1.NodeCondition.
+type NodeCondition struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // e.g. "Ready", "MemoryPressure"
+	Status             string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "True", "False" or "Unknown"
+	Reason             string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message            string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	LastTransitionTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_transition_time,json=lastTransitionTime,proto3" json:"last_transition_time,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *NodeCondition) Reset() {
+	*x = NodeCondition{}
+	mi := &file_incident_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeCondition) ProtoMessage() {}
+
+func (x *NodeCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeCondition.ProtoReflect.Descriptor instead.
+func (*NodeCondition) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NodeCondition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NodeCondition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NodeCondition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *NodeCondition) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NodeCondition) GetLastTransitionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTransitionTime
+	}
+	return nil
+}
+
+// Taint is a corev1.Taint.
+type Taint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,3,opt,name=effect,proto3" json:"effect,omitempty"` // "NoSchedule", "PreferNoSchedule" or "NoExecute"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Taint) Reset() {
+	*x = Taint{}
+	mi := &file_incident_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Taint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Taint) ProtoMessage() {}
+
+func (x *Taint) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Taint.ProtoReflect.Descriptor instead.
+func (*Taint) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Taint) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Taint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Taint) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+// AffectedPod is a pod involved in an incident: running on a node with an
+// incident, evicted along with the pod, or failing in a rollout.
+type AffectedPod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Phase         string                 `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	Ready         bool                   `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+	Workload      string                 `protobuf:"bytes,5,opt,name=workload,proto3" json:"workload,omitempty"` // Top-most controller as Kind/name
+	QosClass      string                 `protobuf:"bytes,6,opt,name=qos_class,json=qosClass,proto3" json:"qos_class,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"` // Failure reason, where the pod is listed for failing (schema version 10)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AffectedPod) Reset() {
+	*x = AffectedPod{}
+	mi := &file_incident_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AffectedPod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AffectedPod) ProtoMessage() {}
+
+func (x *AffectedPod) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AffectedPod.ProtoReflect.Descriptor instead.
+func (*AffectedPod) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AffectedPod) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AffectedPod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AffectedPod) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *AffectedPod) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *AffectedPod) GetWorkload() string {
+	if x != nil {
+		return x.Workload
+	}
+	return ""
+}
+
+func (x *AffectedPod) GetQosClass() string {
+	if x != nil {
+		return x.QosClass
+	}
+	return ""
+}
+
+func (x *AffectedPod) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ObservationSettings are the Observer's settings for a workload once ObserverPolicies
+// and the annotations of the pod, its owners and its namespace are applied.
+type ObservationSettings struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Policy          string                 `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"` // ObserverPolicy applied; empty for the global settings
+	LogTailLines    int64                  `protobuf:"varint,2,opt,name=log_tail_lines,json=logTailLines,proto3" json:"log_tail_lines,omitempty"`
+	Collectors      []string               `protobuf:"bytes,3,rep,name=collectors,proto3" json:"collectors,omitempty"`                                  // e.g. "logs", "previousLogs", "events", "manifests"
+	ExtraContainers []string               `protobuf:"bytes,4,rep,name=extra_containers,json=extraContainers,proto3" json:"extra_containers,omitempty"` // Containers whose logs are collected although they did not fail
+	Severity        string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`                                      // Severity requested by the owning team, e.g. "critical"
+	Team            string                 `protobuf:"bytes,6,opt,name=team,proto3" json:"team,omitempty"`                                              // Team owning the workload
+	AnnotatedBy     []string               `protobuf:"bytes,7,rep,name=annotated_by,json=annotatedBy,proto3" json:"annotated_by,omitempty"`             // Objects whose annotations were applied, nearest first, as Kind/name
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ObservationSettings) Reset() {
+	*x = ObservationSettings{}
+	mi := &file_incident_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObservationSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservationSettings) ProtoMessage() {}
+
+func (x *ObservationSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservationSettings.ProtoReflect.Descriptor instead.
+func (*ObservationSettings) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ObservationSettings) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *ObservationSettings) GetLogTailLines() int64 {
+	if x != nil {
+		return x.LogTailLines
+	}
+	return 0
+}
+
+func (x *ObservationSettings) GetCollectors() []string {
+	if x != nil {
+		return x.Collectors
+	}
+	return nil
+}
+
+func (x *ObservationSettings) GetExtraContainers() []string {
+	if x != nil {
+		return x.ExtraContainers
+	}
+	return nil
+}
+
+func (x *ObservationSettings) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ObservationSettings) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *ObservationSettings) GetAnnotatedBy() []string {
+	if x != nil {
+		return x.AnnotatedBy
+	}
+	return nil
+}
+
+// ContainerInfo combines a container's spec with its last reported status.
+type ContainerInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	InitContainer   bool                   `protobuf:"varint,2,opt,name=init_container,json=initContainer,proto3" json:"init_container,omitempty"`
+	Image           string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`                                // Image reference from the pod spec
+	ImageDigest     string                 `protobuf:"bytes,4,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"` // Resolved image ID reported by the kubelet
+	Resources       *ResourceRequirements  `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	Ready           bool                   `protobuf:"varint,6,opt,name=ready,proto3" json:"ready,omitempty"`
+	RestartCount    int32                  `protobuf:"varint,7,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	State           *ContainerState        `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	LastTermination *ContainerState        `protobuf:"bytes,9,opt,name=last_termination,json=lastTermination,proto3" json:"last_termination,omitempty"` // Previous termination, set once the container restarted
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContainerInfo) Reset() {
+	*x = ContainerInfo{}
+	mi := &file_incident_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerInfo) ProtoMessage() {}
+
+func (x *ContainerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerInfo.ProtoReflect.Descriptor instead.
+func (*ContainerInfo) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ContainerInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetInitContainer() bool {
+	if x != nil {
+		return x.InitContainer
+	}
+	return false
+}
+
+func (x *ContainerInfo) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetResources() *ResourceRequirements {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ContainerInfo) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *ContainerInfo) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *ContainerInfo) GetState() *ContainerState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ContainerInfo) GetLastTermination() *ContainerState {
+	if x != nil {
+		return x.LastTermination
+	}
+	return nil
+}
+
+// ContainerState is a flattened corev1.ContainerState.
+type ContainerState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"` // "waiting", "running" or "terminated"
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Signal        int32                  `protobuf:"varint,5,opt,name=signal,proto3" json:"signal,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerState) Reset() {
+	*x = ContainerState{}
+	mi := &file_incident_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerState) ProtoMessage() {}
+
+func (x *ContainerState) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerState.ProtoReflect.Descriptor instead.
+func (*ContainerState) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ContainerState) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ContainerState) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ContainerState) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ContainerState) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ContainerState) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *ContainerState) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ContainerState) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// ResourceRequirements maps resource names to quantities, e.g. "memory" to "128Mi".
+type ResourceRequirements struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      map[string]string      `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Limits        map[string]string      `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceRequirements) Reset() {
+	*x = ResourceRequirements{}
+	mi := &file_incident_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRequirements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRequirements) ProtoMessage() {}
+
+func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
+func (*ResourceRequirements) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResourceRequirements) GetRequests() map[string]string {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *ResourceRequirements) GetLimits() map[string]string {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// OwnerReference identifies one link of a pod's controller chain.
+type OwnerReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersion    string                 `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Uid           string                 `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OwnerReference) Reset() {
+	*x = OwnerReference{}
+	mi := &file_incident_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnerReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnerReference) ProtoMessage() {}
+
+func (x *OwnerReference) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnerReference.ProtoReflect.Descriptor instead.
+func (*OwnerReference) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OwnerReference) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *OwnerReference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *OwnerReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OwnerReference) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+// Event is a Kubernetes event involving the failing pod.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "Normal" or "Warning"
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"` // Reporting component, e.g. "kubelet"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_incident_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Event) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *Event) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Event) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// LogSection holds the log tail of one container instance.
+type LogSection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Container     string                 `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Previous      bool                   `protobuf:"varint,2,opt,name=previous,proto3" json:"previous,omitempty"` // True for the logs of the previous, crashed instance
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"` // True when the head of the tail was cut by the size budget (schema version 4)
+	Pod           string                 `protobuf:"bytes,5,opt,name=pod,proto3" json:"pod,omitempty"`              // Pod of a Job attempt, when not the incident's pod (schema version 11)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogSection) Reset() {
+	*x = LogSection{}
+	mi := &file_incident_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSection) ProtoMessage() {}
+
+func (x *LogSection) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSection.ProtoReflect.Descriptor instead.
+func (*LogSection) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LogSection) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *LogSection) GetPrevious() bool {
+	if x != nil {
+		return x.Previous
+	}
+	return false
+}
+
+func (x *LogSection) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *LogSection) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *LogSection) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+// IncidentChunk is one ordered part of a serialized IncidentContext that is too
+// large for a single gRPC message. Added in schema version 4.
+type IncidentChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId    string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	Index         uint32                 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                          // 0-based position of this chunk
+	Total         uint32                 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                          // Number of chunks of the incident
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                             // Slice of the serialized IncidentContext
+	Checksum      string                 `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`                     // Hex SHA-256 of the whole serialized IncidentContext
+	TotalSize     uint64                 `protobuf:"varint,6,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"` // Length of the whole serialized IncidentContext
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncidentChunk) Reset() {
+	*x = IncidentChunk{}
+	mi := &file_incident_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncidentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidentChunk) ProtoMessage() {}
+
+func (x *IncidentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidentChunk.ProtoReflect.Descriptor instead.
+func (*IncidentChunk) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *IncidentChunk) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+func (x *IncidentChunk) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IncidentChunk) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *IncidentChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *IncidentChunk) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *IncidentChunk) GetTotalSize() uint64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// StreamIncidentResponse is returned once the client stream closes.
+type StreamIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamIncidentResponse) Reset() {
+	*x = StreamIncidentResponse{}
+	mi := &file_incident_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamIncidentResponse) ProtoMessage() {}
+
+func (x *StreamIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamIncidentResponse.ProtoReflect.Descriptor instead.
+func (*StreamIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StreamIncidentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// NegotiateRequest advertises the schema versions the Observer can produce.
+type NegotiateRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion    uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`            // Newest schema version the Observer speaks
+	MinSchemaVersion uint32                 `protobuf:"varint,2,opt,name=min_schema_version,json=minSchemaVersion,proto3" json:"min_schema_version,omitempty"` // Oldest schema version the Observer can downgrade to
+	ObserverVersion  string                 `protobuf:"bytes,3,opt,name=observer_version,json=observerVersion,proto3" json:"observer_version,omitempty"`       // Build version of the Observer, for Brain-side logging
+	Compressions     []string               `protobuf:"bytes,4,rep,name=compressions,proto3" json:"compressions,omitempty"`                                    // gRPC compressors the Observer can use, preferred first (schema version 4)
+	ChunkedTransfer  bool                   `protobuf:"varint,5,opt,name=chunked_transfer,json=chunkedTransfer,proto3" json:"chunked_transfer,omitempty"`      // Observer can split oversized incidents (schema version 4)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NegotiateRequest) Reset() {
+	*x = NegotiateRequest{}
+	mi := &file_incident_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateRequest) ProtoMessage() {}
+
+func (x *NegotiateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateRequest.ProtoReflect.Descriptor instead.
+func (*NegotiateRequest) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *NegotiateRequest) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *NegotiateRequest) GetMinSchemaVersion() uint32 {
+	if x != nil {
+		return x.MinSchemaVersion
+	}
+	return 0
+}
+
+func (x *NegotiateRequest) GetObserverVersion() string {
+	if x != nil {
+		return x.ObserverVersion
+	}
+	return ""
+}
+
+func (x *NegotiateRequest) GetCompressions() []string {
+	if x != nil {
+		return x.Compressions
+	}
+	return nil
+}
+
+func (x *NegotiateRequest) GetChunkedTransfer() bool {
+	if x != nil {
+		return x.ChunkedTransfer
+	}
+	return false
+}
+
+// NegotiateResponse advertises the schema versions the Brain can consume.
+type NegotiateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion    uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`            // Newest schema version the Brain understands
+	MinSchemaVersion uint32                 `protobuf:"varint,2,opt,name=min_schema_version,json=minSchemaVersion,proto3" json:"min_schema_version,omitempty"` // Oldest schema version the Brain still accepts
+	BrainVersion     string                 `protobuf:"bytes,3,opt,name=brain_version,json=brainVersion,proto3" json:"brain_version,omitempty"`                // Build version of the Brain
+	Compressions     []string               `protobuf:"bytes,4,rep,name=compressions,proto3" json:"compressions,omitempty"`                                    // gRPC compressors the Brain can decode (schema version 4)
+	ChunkedTransfer  bool                   `protobuf:"varint,5,opt,name=chunked_transfer,json=chunkedTransfer,proto3" json:"chunked_transfer,omitempty"`      // Brain implements StreamIncidentChunks (schema version 4)
+	MaxMessageBytes  uint64                 `protobuf:"varint,6,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`    // Largest message the Brain accepts; 0 means the gRPC default of 4 MiB
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NegotiateResponse) Reset() {
+	*x = NegotiateResponse{}
+	mi := &file_incident_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateResponse) ProtoMessage() {}
+
+func (x *NegotiateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateResponse.ProtoReflect.Descriptor instead.
+func (*NegotiateResponse) Descriptor() ([]byte, []int) {
+	return file_incident_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *NegotiateResponse) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *NegotiateResponse) GetMinSchemaVersion() uint32 {
+	if x != nil {
+		return x.MinSchemaVersion
+	}
+	return 0
+}
+
+func (x *NegotiateResponse) GetBrainVersion() string {
+	if x != nil {
+		return x.BrainVersion
+	}
+	return ""
+}
+
+func (x *NegotiateResponse) GetCompressions() []string {
+	if x != nil {
+		return x.Compressions
+	}
+	return nil
+}
+
+func (x *NegotiateResponse) GetChunkedTransfer() bool {
+	if x != nil {
+		return x.ChunkedTransfer
+	}
+	return false
+}
+
+func (x *NegotiateResponse) GetMaxMessageBytes() uint64 {
+	if x != nil {
+		return x.MaxMessageBytes
+	}
+	return 0
+}
+
+var File_incident_proto protoreflect.FileDescriptor
+
+const file_incident_proto_rawDesc = "" +
+	"\n" +
+	"\x0eincident.proto\x12\bkubemind\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf3\x03\n" +
+	"\x0fIncidentContext\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x19\n" +
+	"\bpod_name\x18\x02 \x01(\tR\apodName\x12#\n" +
+	"\rpod_namespace\x18\x03 \x01(\tR\fpodNamespace\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12\x12\n" +
+	"\x04logs\x18\x05 \x01(\tR\x04logs\x12*\n" +
+	"\x11pod_manifest_json\x18\x06 \x01(\tR\x0fpodManifestJson\x128\n" +
+	"\x18deployment_manifest_json\x18\a \x01(\tR\x16deploymentManifestJson\x128\n" +
+	"\ttimestamp\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\t \x01(\tR\tclusterId\x123\n" +
+	"\adetails\x18\n" +
+	" \x01(\v2\x19.kubemind.IncidentDetailsR\adetails\x12%\n" +
+	"\x0etrimmed_fields\x18\v \x03(\tR\rtrimmedFields\x12)\n" +
+	"\x04node\x18\f \x01(\v2\x15.kubemind.NodeDetailsR\x04node\"\x99\x05\n" +
+	"\x0fIncidentDetails\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1b\n" +
+	"\tnode_name\x18\x02 \x01(\tR\bnodeName\x12\x14\n" +
+	"\x05phase\x18\x03 \x01(\tR\x05phase\x12\x1b\n" +
+	"\tqos_class\x18\x04 \x01(\tR\bqosClass\x127\n" +
+	"\n" +
+	"containers\x18\x05 \x03(\v2\x17.kubemind.ContainerInfoR\n" +
+	"containers\x129\n" +
+	"\vowner_chain\x18\x06 \x03(\v2\x18.kubemind.OwnerReferenceR\n" +
+	"ownerChain\x12'\n" +
+	"\x06events\x18\a \x03(\v2\x0f.kubemind.EventR\x06events\x127\n" +
+	"\flog_sections\x18\b \x03(\v2\x14.kubemind.LogSectionR\vlogSections\x129\n" +
+	"\bsettings\x18\t \x01(\v2\x1d.kubemind.ObservationSettingsR\bsettings\x12;\n" +
+	"\n" +
+	"scheduling\x18\n" +
+	" \x01(\v2\x1b.kubemind.SchedulingDetailsR\n" +
+	"scheduling\x125\n" +
+	"\beviction\x18\v \x01(\v2\x19.kubemind.EvictionDetailsR\beviction\x12.\n" +
+	"\x06probes\x18\f \x01(\v2\x16.kubemind.ProbeDetailsR\x06probes\x122\n" +
+	"\arollout\x18\r \x01(\v2\x18.kubemind.RolloutDetailsR\arollout\x12&\n" +
+	"\x03job\x18\x0e \x01(\v2\x14.kubemind.JobDetailsR\x03job\"\xaa\x04\n" +
+	"\n" +
+	"JobDetails\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x10condition_reason\x18\x02 \x01(\tR\x0fconditionReason\x12+\n" +
+	"\x11condition_message\x18\x03 \x01(\tR\x10conditionMessage\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x127\n" +
+	"\tfailed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12#\n" +
+	"\rbackoff_limit\x18\x06 \x01(\x05R\fbackoffLimit\x126\n" +
+	"\x17active_deadline_seconds\x18\a \x01(\x03R\x15activeDeadlineSeconds\x12 \n" +
+	"\vcompletions\x18\b \x01(\x05R\vcompletions\x12 \n" +
+	"\vparallelism\x18\t \x01(\x05R\vparallelism\x12\x1c\n" +
+	"\tsucceeded\x18\n" +
+	" \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\v \x01(\x05R\x06failed\x120\n" +
+	"\battempts\x18\f \x03(\v2\x14.kubemind.JobAttemptR\battempts\x123\n" +
+	"\bcron_job\x18\r \x01(\v2\x18.kubemind.CronJobDetailsR\acronJob\"\x8f\x02\n" +
+	"\n" +
+	"JobAttempt\x12\x19\n" +
+	"\bpod_name\x18\x01 \x01(\tR\apodName\x12\x1b\n" +
+	"\tnode_name\x18\x02 \x01(\tR\bnodeName\x12\x1c\n" +
+	"\tcontainer\x18\x03 \x01(\tR\tcontainer\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1b\n" +
+	"\texit_code\x18\x05 \x01(\x05R\bexitCode\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\xac\x03\n" +
+	"\x0eCronJobDetails\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12-\n" +
+	"\x12concurrency_policy\x18\x04 \x01(\tR\x11concurrencyPolicy\x12\x1c\n" +
+	"\tsuspended\x18\x05 \x01(\bR\tsuspended\x12H\n" +
+	"\x12last_schedule_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10lastScheduleTime\x12L\n" +
+	"\x14last_successful_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x12lastSuccessfulTime\x121\n" +
+	"\x14consecutive_failures\x18\b \x01(\x05R\x13consecutiveFailures\x125\n" +
+	"\vrecent_runs\x18\t \x03(\v2\x14.kubemind.CronJobRunR\n" +
+	"recentRuns\"\xcf\x01\n" +
+	"\n" +
+	"CronJobRun\x12\x19\n" +
+	"\bjob_name\x18\x01 \x01(\tR\ajobName\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12;\n" +
+	"\vfinished_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x91\x06\n" +
+	"\x0eRolloutDetails\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12&\n" +
+	"\x0fnew_replica_set\x18\x02 \x01(\tR\rnewReplicaSet\x12&\n" +
+	"\x0fold_replica_set\x18\x03 \x01(\tR\roldReplicaSet\x12!\n" +
+	"\fold_revision\x18\x04 \x01(\x03R\voldRevision\x12\x1a\n" +
+	"\breplicas\x18\x05 \x01(\x05R\breplicas\x12)\n" +
+	"\x10updated_replicas\x18\x06 \x01(\x05R\x0fupdatedReplicas\x12%\n" +
+	"\x0eready_replicas\x18\a \x01(\x05R\rreadyReplicas\x12-\n" +
+	"\x12available_replicas\x18\b \x01(\x05R\x11availableReplicas\x121\n" +
+	"\x14unavailable_replicas\x18\t \x01(\x05R\x13unavailableReplicas\x124\n" +
+	"\x16old_available_replicas\x18\n" +
+	" \x01(\x05R\x14oldAvailableReplicas\x12)\n" +
+	"\x10condition_reason\x18\v \x01(\tR\x0fconditionReason\x12+\n" +
+	"\x11condition_message\x18\f \x01(\tR\x10conditionMessage\x12;\n" +
+	"\vlast_update\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUpdate\x12:\n" +
+	"\x19progress_deadline_seconds\x18\x0e \x01(\x05R\x17progressDeadlineSeconds\x12\x1a\n" +
+	"\bstrategy\x18\x0f \x01(\tR\bstrategy\x12C\n" +
+	"\x10template_changes\x18\x10 \x03(\v2\x18.kubemind.TemplateChangeR\x0ftemplateChanges\x128\n" +
+	"\ffailing_pods\x18\x11 \x03(\v2\x15.kubemind.AffectedPodR\vfailingPods\"^\n" +
+	"\x0eTemplateChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\x8d\x02\n" +
+	"\fProbeDetails\x12-\n" +
+	"\treadiness\x18\x01 \x01(\v2\x0f.kubemind.ProbeR\treadiness\x12+\n" +
+	"\bliveness\x18\x02 \x01(\v2\x0f.kubemind.ProbeR\bliveness\x12)\n" +
+	"\astartup\x18\x03 \x01(\v2\x0f.kubemind.ProbeR\astartup\x12B\n" +
+	"\x0fnot_ready_since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rnotReadySince\x122\n" +
+	"\bfailures\x18\x05 \x03(\v2\x16.kubemind.ProbeFailureR\bfailures\"\xff\x01\n" +
+	"\x05Probe\x12\x18\n" +
+	"\ahandler\x18\x01 \x01(\tR\ahandler\x122\n" +
+	"\x15initial_delay_seconds\x18\x02 \x01(\x05R\x13initialDelaySeconds\x12%\n" +
+	"\x0eperiod_seconds\x18\x03 \x01(\x05R\rperiodSeconds\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x12+\n" +
+	"\x11success_threshold\x18\x05 \x01(\x05R\x10successThreshold\x12+\n" +
+	"\x11failure_threshold\x18\x06 \x01(\x05R\x10failureThreshold\"\x8d\x01\n" +
+	"\fProbeFailure\x12\x14\n" +
+	"\x05probe\x18\x01 \x01(\tR\x05probe\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x127\n" +
+	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\xe3\x02\n" +
+	"\x0fEvictionDetails\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"evicted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tevictedAt\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12.\n" +
+	"\x13priority_class_name\x18\x05 \x01(\tR\x11priorityClassName\x12\x1b\n" +
+	"\tqos_class\x18\x06 \x01(\tR\bqosClass\x12@\n" +
+	"\x0fnode_conditions\x18\a \x03(\v2\x17.kubemind.NodeConditionR\x0enodeConditions\x128\n" +
+	"\fevicted_pods\x18\b \x03(\v2\x15.kubemind.AffectedPodR\vevictedPods\"\xca\x05\n" +
+	"\x11SchedulingDetails\x12?\n" +
+	"\rpending_since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fpendingSince\x12\x1c\n" +
+	"\tscheduled\x18\x02 \x01(\bR\tscheduled\x12)\n" +
+	"\x10condition_reason\x18\x03 \x01(\tR\x0fconditionReason\x12+\n" +
+	"\x11condition_message\x18\x04 \x01(\tR\x10conditionMessage\x12+\n" +
+	"\x11failed_scheduling\x18\x05 \x03(\tR\x10failedScheduling\x12E\n" +
+	"\brequests\x18\x06 \x03(\v2).kubemind.SchedulingDetails.RequestsEntryR\brequests\x12R\n" +
+	"\rnode_selector\x18\a \x03(\v2-.kubemind.SchedulingDetails.NodeSelectorEntryR\fnodeSelector\x12#\n" +
+	"\raffinity_json\x18\b \x01(\tR\faffinityJson\x126\n" +
+	"\vtolerations\x18\t \x03(\v2\x14.kubemind.TolerationR\vtolerations\x12.\n" +
+	"\x13priority_class_name\x18\n" +
+	" \x01(\tR\x11priorityClassName\x12+\n" +
+	"\x05nodes\x18\v \x03(\v2\x15.kubemind.NodeSummaryR\x05nodes\x1a;\n" +
+	"\rRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x01\n" +
+	"\n" +
+	"Toleration\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x04 \x01(\tR\x06effect\x12-\n" +
+	"\x12toleration_seconds\x18\x05 \x01(\x03R\x11tolerationSeconds\"\x92\x03\n" +
+	"\vNodeSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05ready\x18\x02 \x01(\bR\x05ready\x12$\n" +
+	"\runschedulable\x18\x03 \x01(\bR\runschedulable\x12H\n" +
+	"\vallocatable\x18\x04 \x03(\v2&.kubemind.NodeSummary.AllocatableEntryR\vallocatable\x12B\n" +
+	"\trequested\x18\x05 \x03(\v2$.kubemind.NodeSummary.RequestedEntryR\trequested\x12'\n" +
+	"\x06taints\x18\x06 \x03(\v2\x0f.kubemind.TaintR\x06taints\x1a>\n" +
+	"\x10AllocatableEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eRequestedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xda\x05\n" +
+	"\vNodeDetails\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\n" +
+	"conditions\x18\x02 \x03(\v2\x17.kubemind.NodeConditionR\n" +
+	"conditions\x12?\n" +
+	"\bcapacity\x18\x03 \x03(\v2#.kubemind.NodeDetails.CapacityEntryR\bcapacity\x12H\n" +
+	"\vallocatable\x18\x04 \x03(\v2&.kubemind.NodeDetails.AllocatableEntryR\vallocatable\x12B\n" +
+	"\trequested\x18\x05 \x03(\v2$.kubemind.NodeDetails.RequestedEntryR\trequested\x12'\n" +
+	"\x06taints\x18\x06 \x03(\v2\x0f.kubemind.TaintR\x06taints\x12$\n" +
+	"\runschedulable\x18\a \x01(\bR\runschedulable\x12'\n" +
+	"\x0fkubelet_version\x18\b \x01(\tR\x0ekubeletVersion\x12\x17\n" +
+	"\aboot_id\x18\t \x01(\tR\x06bootId\x12:\n" +
+	"\raffected_pods\x18\n" +
+	" \x03(\v2\x15.kubemind.AffectedPodR\faffectedPods\x12'\n" +
+	"\x06events\x18\v \x03(\v2\x0f.kubemind.EventR\x06events\x1a;\n" +
+	"\rCapacityEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AllocatableEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eRequestedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbb\x01\n" +
+	"\rNodeCondition\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12L\n" +
+	"\x14last_transition_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x12lastTransitionTime\"G\n" +
+	"\x05Taint\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x03 \x01(\tR\x06effect\"\xbc\x01\n" +
+	"\vAffectedPod\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phase\x18\x03 \x01(\tR\x05phase\x12\x14\n" +
+	"\x05ready\x18\x04 \x01(\bR\x05ready\x12\x1a\n" +
+	"\bworkload\x18\x05 \x01(\tR\bworkload\x12\x1b\n" +
+	"\tqos_class\x18\x06 \x01(\tR\bqosClass\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"\xf1\x01\n" +
+	"\x13ObservationSettings\x12\x16\n" +
+	"\x06policy\x18\x01 \x01(\tR\x06policy\x12$\n" +
+	"\x0elog_tail_lines\x18\x02 \x01(\x03R\flogTailLines\x12\x1e\n" +
+	"\n" +
+	"collectors\x18\x03 \x03(\tR\n" +
+	"collectors\x12)\n" +
+	"\x10extra_containers\x18\x04 \x03(\tR\x0fextraContainers\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x12\n" +
+	"\x04team\x18\x06 \x01(\tR\x04team\x12!\n" +
+	"\fannotated_by\x18\a \x03(\tR\vannotatedBy\"\xf1\x02\n" +
+	"\rContainerInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0einit_container\x18\x02 \x01(\bR\rinitContainer\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12!\n" +
+	"\fimage_digest\x18\x04 \x01(\tR\vimageDigest\x12<\n" +
+	"\tresources\x18\x05 \x01(\v2\x1e.kubemind.ResourceRequirementsR\tresources\x12\x14\n" +
+	"\x05ready\x18\x06 \x01(\bR\x05ready\x12#\n" +
+	"\rrestart_count\x18\a \x01(\x05R\frestartCount\x12.\n" +
+	"\x05state\x18\b \x01(\v2\x18.kubemind.ContainerStateR\x05state\x12C\n" +
+	"\x10last_termination\x18\t \x01(\v2\x18.kubemind.ContainerStateR\x0flastTermination\"\x85\x02\n" +
+	"\x0eContainerState\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06signal\x18\x05 \x01(\x05R\x06signal\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x9c\x02\n" +
+	"\x14ResourceRequirements\x12H\n" +
+	"\brequests\x18\x01 \x03(\v2,.kubemind.ResourceRequirements.RequestsEntryR\brequests\x12B\n" +
+	"\x06limits\x18\x02 \x03(\v2*.kubemind.ResourceRequirements.LimitsEntryR\x06limits\x1a;\n" +
+	"\rRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
+	"\x0eOwnerReference\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x04 \x01(\tR\x03uid\"\xef\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x129\n" +
+	"\n" +
+	"first_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\"\x90\x01\n" +
+	"\n" +
+	"LogSection\x12\x1c\n" +
+	"\tcontainer\x18\x01 \x01(\tR\tcontainer\x12\x1a\n" +
+	"\bprevious\x18\x02 \x01(\bR\bprevious\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\x12\x10\n" +
+	"\x03pod\x18\x05 \x01(\tR\x03pod\"\xab\x01\n" +
+	"\rIncidentChunk\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\rR\x05total\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x06 \x01(\x04R\ttotalSize\"0\n" +
 	"\x16StreamIncidentResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status2b\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xe1\x01\n" +
+	"\x10NegotiateRequest\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12,\n" +
+	"\x12min_schema_version\x18\x02 \x01(\rR\x10minSchemaVersion\x12)\n" +
+	"\x10observer_version\x18\x03 \x01(\tR\x0fobserverVersion\x12\"\n" +
+	"\fcompressions\x18\x04 \x03(\tR\fcompressions\x12)\n" +
+	"\x10chunked_transfer\x18\x05 \x01(\bR\x0fchunkedTransfer\"\x88\x02\n" +
+	"\x11NegotiateResponse\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12,\n" +
+	"\x12min_schema_version\x18\x02 \x01(\rR\x10minSchemaVersion\x12#\n" +
+	"\rbrain_version\x18\x03 \x01(\tR\fbrainVersion\x12\"\n" +
+	"\fcompressions\x18\x04 \x03(\tR\fcompressions\x12)\n" +
+	"\x10chunked_transfer\x18\x05 \x01(\bR\x0fchunkedTransfer\x12*\n" +
+	"\x11max_message_bytes\x18\x06 \x01(\x04R\x0fmaxMessageBytes2\xfd\x01\n" +
 	"\x0fIncidentService\x12O\n" +
-	"\x0eStreamIncident\x12\x19.kubemind.IncidentContext\x1a .kubemind.StreamIncidentResponse(\x01B\"Z\x0fkube-mind/proto\xaa\x02\x0eKubeMind.Protob\x06proto3"
+	"\x0eStreamIncident\x12\x19.kubemind.IncidentContext\x1a .kubemind.StreamIncidentResponse(\x01\x12D\n" +
+	"\tNegotiate\x12\x1a.kubemind.NegotiateRequest\x1a\x1b.kubemind.NegotiateResponse\x12S\n" +
+	"\x14StreamIncidentChunks\x12\x17.kubemind.IncidentChunk\x1a .kubemind.StreamIncidentResponse(\x01B+Z\x18kube-mind/observer/proto\xaa\x02\x0eKubeMind.Protob\x06proto3"
 
 var (
 	file_incident_proto_rawDescOnce sync.Once
@@ -200,21 +3166,122 @@ func file_incident_proto_rawDescGZIP() []byte {
 	return file_incident_proto_rawDescData
 }
 
-var file_incident_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_incident_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_incident_proto_goTypes = []any{
 	(*IncidentContext)(nil),        // 0: kubemind.IncidentContext
-	(*StreamIncidentResponse)(nil), // 1: kubemind.StreamIncidentResponse
-	(*timestamp.Timestamp)(nil),    // 2: google.protobuf.Timestamp
+	(*IncidentDetails)(nil),        // 1: kubemind.IncidentDetails
+	(*JobDetails)(nil),             // 2: kubemind.JobDetails
+	(*JobAttempt)(nil),             // 3: kubemind.JobAttempt
+	(*CronJobDetails)(nil),         // 4: kubemind.CronJobDetails
+	(*CronJobRun)(nil),             // 5: kubemind.CronJobRun
+	(*RolloutDetails)(nil),         // 6: kubemind.RolloutDetails
+	(*TemplateChange)(nil),         // 7: kubemind.TemplateChange
+	(*ProbeDetails)(nil),           // 8: kubemind.ProbeDetails
+	(*Probe)(nil),                  // 9: kubemind.Probe
+	(*ProbeFailure)(nil),           // 10: kubemind.ProbeFailure
+	(*EvictionDetails)(nil),        // 11: kubemind.EvictionDetails
+	(*SchedulingDetails)(nil),      // 12: kubemind.SchedulingDetails
+	(*Toleration)(nil),             // 13: kubemind.Toleration
+	(*NodeSummary)(nil),            // 14: kubemind.NodeSummary
+	(*NodeDetails)(nil),            // 15: kubemind.NodeDetails
+	(*NodeCondition)(nil),          // 16: kubemind.NodeCondition
+	(*Taint)(nil),                  // 17: kubemind.Taint
+	(*AffectedPod)(nil),            // 18: kubemind.AffectedPod
+	(*ObservationSettings)(nil),    // 19: kubemind.ObservationSettings
+	(*ContainerInfo)(nil),          // 20: kubemind.ContainerInfo
+	(*ContainerState)(nil),         // 21: kubemind.ContainerState
+	(*ResourceRequirements)(nil),   // 22: kubemind.ResourceRequirements
+	(*OwnerReference)(nil),         // 23: kubemind.OwnerReference
+	(*Event)(nil),                  // 24: kubemind.Event
+	(*LogSection)(nil),             // 25: kubemind.LogSection
+	(*IncidentChunk)(nil),          // 26: kubemind.IncidentChunk
+	(*StreamIncidentResponse)(nil), // 27: kubemind.StreamIncidentResponse
+	(*NegotiateRequest)(nil),       // 28: kubemind.NegotiateRequest
+	(*NegotiateResponse)(nil),      // 29: kubemind.NegotiateResponse
+	nil,                            // 30: kubemind.SchedulingDetails.RequestsEntry
+	nil,                            // 31: kubemind.SchedulingDetails.NodeSelectorEntry
+	nil,                            // 32: kubemind.NodeSummary.AllocatableEntry
+	nil,                            // 33: kubemind.NodeSummary.RequestedEntry
+	nil,                            // 34: kubemind.NodeDetails.CapacityEntry
+	nil,                            // 35: kubemind.NodeDetails.AllocatableEntry
+	nil,                            // 36: kubemind.NodeDetails.RequestedEntry
+	nil,                            // 37: kubemind.ResourceRequirements.RequestsEntry
+	nil,                            // 38: kubemind.ResourceRequirements.LimitsEntry
+	(*timestamppb.Timestamp)(nil),  // 39: google.protobuf.Timestamp
 }
 var file_incident_proto_depIdxs = []int32{
-	2, // 0: kubemind.IncidentContext.timestamp:type_name -> google.protobuf.Timestamp
-	0, // 1: kubemind.IncidentService.StreamIncident:input_type -> kubemind.IncidentContext
-	1, // 2: kubemind.IncidentService.StreamIncident:output_type -> kubemind.StreamIncidentResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	39, // 0: kubemind.IncidentContext.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 1: kubemind.IncidentContext.details:type_name -> kubemind.IncidentDetails
+	15, // 2: kubemind.IncidentContext.node:type_name -> kubemind.NodeDetails
+	20, // 3: kubemind.IncidentDetails.containers:type_name -> kubemind.ContainerInfo
+	23, // 4: kubemind.IncidentDetails.owner_chain:type_name -> kubemind.OwnerReference
+	24, // 5: kubemind.IncidentDetails.events:type_name -> kubemind.Event
+	25, // 6: kubemind.IncidentDetails.log_sections:type_name -> kubemind.LogSection
+	19, // 7: kubemind.IncidentDetails.settings:type_name -> kubemind.ObservationSettings
+	12, // 8: kubemind.IncidentDetails.scheduling:type_name -> kubemind.SchedulingDetails
+	11, // 9: kubemind.IncidentDetails.eviction:type_name -> kubemind.EvictionDetails
+	8,  // 10: kubemind.IncidentDetails.probes:type_name -> kubemind.ProbeDetails
+	6,  // 11: kubemind.IncidentDetails.rollout:type_name -> kubemind.RolloutDetails
+	2,  // 12: kubemind.IncidentDetails.job:type_name -> kubemind.JobDetails
+	39, // 13: kubemind.JobDetails.start_time:type_name -> google.protobuf.Timestamp
+	39, // 14: kubemind.JobDetails.failed_at:type_name -> google.protobuf.Timestamp
+	3,  // 15: kubemind.JobDetails.attempts:type_name -> kubemind.JobAttempt
+	4,  // 16: kubemind.JobDetails.cron_job:type_name -> kubemind.CronJobDetails
+	39, // 17: kubemind.JobAttempt.started_at:type_name -> google.protobuf.Timestamp
+	39, // 18: kubemind.JobAttempt.finished_at:type_name -> google.protobuf.Timestamp
+	39, // 19: kubemind.CronJobDetails.last_schedule_time:type_name -> google.protobuf.Timestamp
+	39, // 20: kubemind.CronJobDetails.last_successful_time:type_name -> google.protobuf.Timestamp
+	5,  // 21: kubemind.CronJobDetails.recent_runs:type_name -> kubemind.CronJobRun
+	39, // 22: kubemind.CronJobRun.start_time:type_name -> google.protobuf.Timestamp
+	39, // 23: kubemind.CronJobRun.finished_at:type_name -> google.protobuf.Timestamp
+	39, // 24: kubemind.RolloutDetails.last_update:type_name -> google.protobuf.Timestamp
+	7,  // 25: kubemind.RolloutDetails.template_changes:type_name -> kubemind.TemplateChange
+	18, // 26: kubemind.RolloutDetails.failing_pods:type_name -> kubemind.AffectedPod
+	9,  // 27: kubemind.ProbeDetails.readiness:type_name -> kubemind.Probe
+	9,  // 28: kubemind.ProbeDetails.liveness:type_name -> kubemind.Probe
+	9,  // 29: kubemind.ProbeDetails.startup:type_name -> kubemind.Probe
+	39, // 30: kubemind.ProbeDetails.not_ready_since:type_name -> google.protobuf.Timestamp
+	10, // 31: kubemind.ProbeDetails.failures:type_name -> kubemind.ProbeFailure
+	39, // 32: kubemind.ProbeFailure.last_seen:type_name -> google.protobuf.Timestamp
+	39, // 33: kubemind.EvictionDetails.evicted_at:type_name -> google.protobuf.Timestamp
+	16, // 34: kubemind.EvictionDetails.node_conditions:type_name -> kubemind.NodeCondition
+	18, // 35: kubemind.EvictionDetails.evicted_pods:type_name -> kubemind.AffectedPod
+	39, // 36: kubemind.SchedulingDetails.pending_since:type_name -> google.protobuf.Timestamp
+	30, // 37: kubemind.SchedulingDetails.requests:type_name -> kubemind.SchedulingDetails.RequestsEntry
+	31, // 38: kubemind.SchedulingDetails.node_selector:type_name -> kubemind.SchedulingDetails.NodeSelectorEntry
+	13, // 39: kubemind.SchedulingDetails.tolerations:type_name -> kubemind.Toleration
+	14, // 40: kubemind.SchedulingDetails.nodes:type_name -> kubemind.NodeSummary
+	32, // 41: kubemind.NodeSummary.allocatable:type_name -> kubemind.NodeSummary.AllocatableEntry
+	33, // 42: kubemind.NodeSummary.requested:type_name -> kubemind.NodeSummary.RequestedEntry
+	17, // 43: kubemind.NodeSummary.taints:type_name -> kubemind.Taint
+	16, // 44: kubemind.NodeDetails.conditions:type_name -> kubemind.NodeCondition
+	34, // 45: kubemind.NodeDetails.capacity:type_name -> kubemind.NodeDetails.CapacityEntry
+	35, // 46: kubemind.NodeDetails.allocatable:type_name -> kubemind.NodeDetails.AllocatableEntry
+	36, // 47: kubemind.NodeDetails.requested:type_name -> kubemind.NodeDetails.RequestedEntry
+	17, // 48: kubemind.NodeDetails.taints:type_name -> kubemind.Taint
+	18, // 49: kubemind.NodeDetails.affected_pods:type_name -> kubemind.AffectedPod
+	24, // 50: kubemind.NodeDetails.events:type_name -> kubemind.Event
+	39, // 51: kubemind.NodeCondition.last_transition_time:type_name -> google.protobuf.Timestamp
+	22, // 52: kubemind.ContainerInfo.resources:type_name -> kubemind.ResourceRequirements
+	21, // 53: kubemind.ContainerInfo.state:type_name -> kubemind.ContainerState
+	21, // 54: kubemind.ContainerInfo.last_termination:type_name -> kubemind.ContainerState
+	39, // 55: kubemind.ContainerState.started_at:type_name -> google.protobuf.Timestamp
+	39, // 56: kubemind.ContainerState.finished_at:type_name -> google.protobuf.Timestamp
+	37, // 57: kubemind.ResourceRequirements.requests:type_name -> kubemind.ResourceRequirements.RequestsEntry
+	38, // 58: kubemind.ResourceRequirements.limits:type_name -> kubemind.ResourceRequirements.LimitsEntry
+	39, // 59: kubemind.Event.first_seen:type_name -> google.protobuf.Timestamp
+	39, // 60: kubemind.Event.last_seen:type_name -> google.protobuf.Timestamp
+	0,  // 61: kubemind.IncidentService.StreamIncident:input_type -> kubemind.IncidentContext
+	28, // 62: kubemind.IncidentService.Negotiate:input_type -> kubemind.NegotiateRequest
+	26, // 63: kubemind.IncidentService.StreamIncidentChunks:input_type -> kubemind.IncidentChunk
+	27, // 64: kubemind.IncidentService.StreamIncident:output_type -> kubemind.StreamIncidentResponse
+	29, // 65: kubemind.IncidentService.Negotiate:output_type -> kubemind.NegotiateResponse
+	27, // 66: kubemind.IncidentService.StreamIncidentChunks:output_type -> kubemind.StreamIncidentResponse
+	64, // [64:67] is the sub-list for method output_type
+	61, // [61:64] is the sub-list for method input_type
+	61, // [61:61] is the sub-list for extension type_name
+	61, // [61:61] is the sub-list for extension extendee
+	0,  // [0:61] is the sub-list for field type_name
 }
 
 func init() { file_incident_proto_init() }
@@ -228,7 +3295,7 @@ func file_incident_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_incident_proto_rawDesc), len(file_incident_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,17 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	IncidentService_StreamIncident_FullMethodName = "/kubemind.IncidentService/StreamIncident"
+	IncidentService_StreamIncident_FullMethodName       = "/kubemind.IncidentService/StreamIncident"
+	IncidentService_Negotiate_FullMethodName            = "/kubemind.IncidentService/Negotiate"
+	IncidentService_StreamIncidentChunks_FullMethodName = "/kubemind.IncidentService/StreamIncidentChunks"
 )
 
 // IncidentServiceClient is the client API for IncidentService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// The gRPC service for sending incidents.
+// The gRPC service for receiving incidents from Observers.
 type IncidentServiceClient interface {
-	// A client-streaming RPC to send incident data.
+	// Client-streaming RPC: Observer streams one or more IncidentContext messages.
 	StreamIncident(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IncidentContext, StreamIncidentResponse], error)
+	// Negotiate exchanges supported schema versions at connect time.
+	// Brains that predate schema version 2 answer with UNIMPLEMENTED.
+	Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error)
+	// Client-streaming RPC for incidents above the message size limit; the Brain
+	// reassembles the chunks and verifies the checksum. Added in schema version 4.
+	StreamIncidentChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IncidentChunk, StreamIncidentResponse], error)
 }
 
 type incidentServiceClient struct {
@@ -53,14 +61,43 @@ func (c *incidentServiceClient) StreamIncident(ctx context.Context, opts ...grpc
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type IncidentService_StreamIncidentClient = grpc.ClientStreamingClient[IncidentContext, StreamIncidentResponse]
 
+func (c *incidentServiceClient) Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NegotiateResponse)
+	err := c.cc.Invoke(ctx, IncidentService_Negotiate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incidentServiceClient) StreamIncidentChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IncidentChunk, StreamIncidentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IncidentService_ServiceDesc.Streams[1], IncidentService_StreamIncidentChunks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IncidentChunk, StreamIncidentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncidentService_StreamIncidentChunksClient = grpc.ClientStreamingClient[IncidentChunk, StreamIncidentResponse]
+
 // IncidentServiceServer is the server API for IncidentService service.
 // All implementations must embed UnimplementedIncidentServiceServer
 // for forward compatibility.
 //
-// The gRPC service for sending incidents.
+// The gRPC service for receiving incidents from Observers.
 type IncidentServiceServer interface {
-	// A client-streaming RPC to send incident data.
+	// Client-streaming RPC: Observer streams one or more IncidentContext messages.
 	StreamIncident(grpc.ClientStreamingServer[IncidentContext, StreamIncidentResponse]) error
+	// Negotiate exchanges supported schema versions at connect time.
+	// Brains that predate schema version 2 answer with UNIMPLEMENTED.
+	Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error)
+	// Client-streaming RPC for incidents above the message size limit; the Brain
+	// reassembles the chunks and verifies the checksum. Added in schema version 4.
+	StreamIncidentChunks(grpc.ClientStreamingServer[IncidentChunk, StreamIncidentResponse]) error
 	mustEmbedUnimplementedIncidentServiceServer()
 }
 
@@ -74,6 +111,12 @@ type UnimplementedIncidentServiceServer struct{}
 func (UnimplementedIncidentServiceServer) StreamIncident(grpc.ClientStreamingServer[IncidentContext, StreamIncidentResponse]) error {
 	return status.Error(codes.Unimplemented, "method StreamIncident not implemented")
 }
+func (UnimplementedIncidentServiceServer) Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Negotiate not implemented")
+}
+func (UnimplementedIncidentServiceServer) StreamIncidentChunks(grpc.ClientStreamingServer[IncidentChunk, StreamIncidentResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamIncidentChunks not implemented")
+}
 func (UnimplementedIncidentServiceServer) mustEmbedUnimplementedIncidentServiceServer() {}
 func (UnimplementedIncidentServiceServer) testEmbeddedByValue()                         {}
 
@@ -102,19 +145,54 @@ func _IncidentService_StreamIncident_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type IncidentService_StreamIncidentServer = grpc.ClientStreamingServer[IncidentContext, StreamIncidentResponse]
 
+func _IncidentService_Negotiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NegotiateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncidentServiceServer).Negotiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncidentService_Negotiate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncidentServiceServer).Negotiate(ctx, req.(*NegotiateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncidentService_StreamIncidentChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IncidentServiceServer).StreamIncidentChunks(&grpc.GenericServerStream[IncidentChunk, StreamIncidentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncidentService_StreamIncidentChunksServer = grpc.ClientStreamingServer[IncidentChunk, StreamIncidentResponse]
+
 // IncidentService_ServiceDesc is the grpc.ServiceDesc for IncidentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var IncidentService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "kubemind.IncidentService",
 	HandlerType: (*IncidentServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Negotiate",
+			Handler:    _IncidentService_Negotiate_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamIncident",
 			Handler:       _IncidentService_StreamIncident_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamIncidentChunks",
+			Handler:       _IncidentService_StreamIncidentChunks_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "incident.proto",
 }
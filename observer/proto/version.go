@@ -0,0 +1,93 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// Schema versions of the contract defined in incident.proto:
+//
+//	1: IncidentContext fields 1-8 and the StreamIncident RPC.
+//	2: IncidentContext.cluster_id and the Negotiate RPC.
+//	3: IncidentContext.details with typed containers, owners, events and logs.
+//	4: Compression and chunked transfer negotiation, StreamIncidentChunks,
+//	   IncidentContext.trimmed_fields and LogSection.truncated.
+//	5: IncidentDetails.settings with the effective observation settings.
+//	6: IncidentContext.node for incidents of Nodes.
+//	7: IncidentDetails.scheduling for pods stuck in Pending.
+//	8: IncidentDetails.eviction for evicted and preempted pods.
+//	9: IncidentDetails.probes for containers failing their probes.
+//	10: IncidentDetails.rollout for failed and stalled Deployment rollouts, and
+//	    AffectedPod.reason.
+//	11: IncidentDetails.job for failed Jobs and CronJobs, and LogSection.pod.
+const (
+	// SchemaVersion is the newest contract version this Observer produces.
+	SchemaVersion uint32 = 11
+	// MinSchemaVersion is the oldest contract version this Observer can downgrade to.
+	MinSchemaVersion uint32 = 1
+	// SchemaVersionMetadataKey is the gRPC metadata key carrying the schema version of a call.
+	SchemaVersionMetadataKey = "x-kubemind-schema-version"
+)
+
+// ErrIncompatibleSchema is returned when the Observer and the Brain share no schema version.
+var ErrIncompatibleSchema = errors.New("incompatible incident schema version")
+
+// NegotiateVersion picks the newest schema version supported by both the Observer
+// and a Brain that accepts versions in [brainMin, brainMax].
+func NegotiateVersion(brainMin, brainMax uint32) (uint32, error) {
+	if brainMin > brainMax {
+		return 0, fmt.Errorf("%w: brain reported min %d above max %d", ErrIncompatibleSchema, brainMin, brainMax)
+	}
+	version := min(SchemaVersion, brainMax)
+	if version < MinSchemaVersion || version < brainMin {
+		return 0, fmt.Errorf("%w: observer supports [%d, %d], brain supports [%d, %d]",
+			ErrIncompatibleSchema, MinSchemaVersion, SchemaVersion, brainMin, brainMax)
+	}
+	return version, nil
+}
+
+// Downgrade returns a copy of the incident without the fields that the given
+// schema version does not define. The input is never modified.
+func Downgrade(incident *IncidentContext, version uint32) *IncidentContext {
+	downgraded := gproto.Clone(incident).(*IncidentContext)
+	if version < 11 && downgraded.Details != nil {
+		downgraded.Details.Job = nil
+		for _, section := range downgraded.Details.LogSections {
+			section.Pod = ""
+		}
+	}
+	// Only rollouts list failing pods with their reason.
+	if version < 10 && downgraded.Details != nil {
+		downgraded.Details.Rollout = nil
+	}
+	if version < 9 && downgraded.Details != nil {
+		downgraded.Details.Probes = nil
+	}
+	if version < 8 && downgraded.Details != nil {
+		downgraded.Details.Eviction = nil
+	}
+	if version < 7 && downgraded.Details != nil {
+		downgraded.Details.Scheduling = nil
+	}
+	if version < 6 {
+		downgraded.Node = nil
+	}
+	if version < 5 && downgraded.Details != nil {
+		downgraded.Details.Settings = nil
+	}
+	if version < 4 {
+		downgraded.TrimmedFields = nil
+		for _, section := range downgraded.GetDetails().GetLogSections() {
+			section.Truncated = false
+		}
+	}
+	if version < 3 {
+		downgraded.Details = nil
+	}
+	if version < 2 {
+		downgraded.ClusterId = ""
+	}
+	return downgraded
+}
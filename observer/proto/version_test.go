@@ -0,0 +1,147 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "kube-mind/observer/proto"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		brainMin        uint32
+		brainMax        uint32
+		expectedVersion uint32
+		expectErr       bool
+	}{
+		{
+			name:            "Brain on the same version",
+			brainMin:        pb.MinSchemaVersion,
+			brainMax:        pb.SchemaVersion,
+			expectedVersion: pb.SchemaVersion,
+		},
+		{
+			name:            "Brain newer than the Observer",
+			brainMin:        pb.MinSchemaVersion,
+			brainMax:        pb.SchemaVersion + 3,
+			expectedVersion: pb.SchemaVersion,
+		},
+		{
+			name:            "Brain older than the Observer",
+			brainMin:        pb.MinSchemaVersion,
+			brainMax:        pb.MinSchemaVersion,
+			expectedVersion: pb.MinSchemaVersion,
+		},
+		{
+			name:      "Brain dropped every version the Observer speaks",
+			brainMin:  pb.SchemaVersion + 1,
+			brainMax:  pb.SchemaVersion + 2,
+			expectErr: true,
+		},
+		{
+			name:      "Brain reported an inverted range",
+			brainMin:  pb.SchemaVersion,
+			brainMax:  pb.MinSchemaVersion,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := pb.NegotiateVersion(tc.brainMin, tc.brainMax)
+
+			if tc.expectErr {
+				require.ErrorIs(t, err, pb.ErrIncompatibleSchema)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	t.Parallel()
+
+	incident := &pb.IncidentContext{
+		IncidentId: "test-incident",
+		ClusterId:  "prod-eu",
+		Details: &pb.IncidentDetails{
+			ContainerName: "app",
+			Settings:      &pb.ObservationSettings{Team: "payments"},
+			Scheduling:    &pb.SchedulingDetails{ConditionReason: "Unschedulable"},
+			Eviction:      &pb.EvictionDetails{Reason: "Evicted"},
+			Probes:        &pb.ProbeDetails{Readiness: &pb.Probe{Handler: "tcpSocket :5432"}},
+			Rollout:       &pb.RolloutDetails{NewReplicaSet: "api-7d9f"},
+			Job:           &pb.JobDetails{Name: "report-28812345"},
+			LogSections:   []*pb.LogSection{{Container: "report", Pod: "report-28812345-x2", Content: "exit 1"}},
+		},
+		Node: &pb.NodeDetails{Name: "node-1"},
+	}
+
+	legacy := pb.Downgrade(incident, 1)
+	assert.Empty(t, legacy.ClusterId)
+	assert.Nil(t, legacy.Details)
+	assert.Equal(t, incident.IncidentId, legacy.IncidentId)
+	assert.Equal(t, "prod-eu", incident.ClusterId)
+	assert.NotNil(t, incident.Details)
+
+	untyped := pb.Downgrade(incident, 2)
+	assert.Equal(t, "prod-eu", untyped.ClusterId)
+	assert.Nil(t, untyped.Details)
+
+	jobless := pb.Downgrade(incident, 10)
+	assert.Nil(t, jobless.Details.Job)
+	assert.Empty(t, jobless.Details.LogSections[0].Pod)
+	assert.Equal(t, "api-7d9f", jobless.Details.Rollout.NewReplicaSet)
+	assert.Equal(t, "report-28812345-x2", incident.Details.LogSections[0].Pod)
+
+	unrolled := pb.Downgrade(incident, 9)
+	assert.Nil(t, unrolled.Details.Rollout)
+	assert.Equal(t, "tcpSocket :5432", unrolled.Details.Probes.Readiness.Handler)
+	assert.NotNil(t, incident.Details.Rollout)
+
+	unprobed := pb.Downgrade(incident, 8)
+	assert.Nil(t, unprobed.Details.Probes)
+	assert.Equal(t, "Evicted", unprobed.Details.Eviction.Reason)
+	assert.NotNil(t, incident.Details.Probes)
+
+	unevicted := pb.Downgrade(incident, 7)
+	assert.Nil(t, unevicted.Details.Eviction)
+	assert.Equal(t, "Unschedulable", unevicted.Details.Scheduling.ConditionReason)
+	assert.NotNil(t, incident.Details.Eviction)
+
+	unscheduled := pb.Downgrade(incident, 6)
+	assert.Nil(t, unscheduled.Details.Scheduling)
+	assert.Equal(t, "node-1", unscheduled.Node.Name)
+	assert.NotNil(t, incident.Details.Scheduling)
+
+	nodeless := pb.Downgrade(incident, 5)
+	assert.Nil(t, nodeless.Node)
+	assert.Equal(t, "payments", nodeless.Details.Settings.Team)
+	assert.NotNil(t, incident.Node)
+
+	unsettled := pb.Downgrade(incident, 4)
+	assert.Equal(t, "app", unsettled.Details.ContainerName)
+	assert.Nil(t, unsettled.Details.Settings)
+	assert.NotNil(t, incident.Details.Settings)
+
+	current := pb.Downgrade(incident, pb.SchemaVersion)
+	assert.Equal(t, "prod-eu", current.ClusterId)
+	assert.Equal(t, "app", current.Details.ContainerName)
+	assert.Equal(t, "payments", current.Details.Settings.Team)
+	assert.Equal(t, "node-1", current.Node.Name)
+	assert.Equal(t, "Unschedulable", current.Details.Scheduling.ConditionReason)
+	assert.Equal(t, "Evicted", current.Details.Eviction.Reason)
+	assert.Equal(t, "tcpSocket :5432", current.Details.Probes.Readiness.Handler)
+	assert.Equal(t, "api-7d9f", current.Details.Rollout.NewReplicaSet)
+	assert.Equal(t, "report-28812345", current.Details.Job.Name)
+}
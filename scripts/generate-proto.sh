@@ -1,6 +1,14 @@
 #!/bin/bash
 
-# This script generates the Go and C# code from the .proto definitions.
+# This script generates the Go code from the canonical incident contract and syncs
+# the copies consumed by the Brains.
+#
+# observer/proto/incident.proto is the single source of truth. The .NET and Python
+# copies are overwritten from it and must never be edited by hand.
+#
+# Usage:
+#   scripts/generate-proto.sh          # regenerate Go code and sync the Brain copies
+#   scripts/generate-proto.sh --check  # fail if any Brain copy has drifted (for CI)
 #
 # Prerequisites:
 # 1. protoc (the protobuf compiler) must be installed.
@@ -8,28 +16,41 @@
 #    - go install google.golang.org/protobuf/cmd/protoc-gen-go
 #    - go install google.golang.org/grpc/cmd/protoc-gen-go-grpc
 # 3. .NET gRPC plugins are typically managed via NuGet packages in the .csproj file.
-#    This script focuses on the Go generation.
+# 4. Python bindings are regenerated with `make proto` in brain-python/.
 
-# Set paths
-PROTO_DIR="./proto"
-OBSERVER_OUT_DIR="./observer/proto" # Assumes Go code will live in a 'proto' pkg
-BRAIN_OUT_DIR="./brain/src/KubeMind.Brain.Api/Protos" # Example, adjust as needed
+set -euo pipefail
 
-# Create output directories if they don't exist
-mkdir -p "$OBSERVER_OUT_DIR"
-mkdir -p "$BRAIN_OUT_DIR"
+# Set paths
+PROTO_DIR="./observer/proto"
+CANONICAL_PROTO="$PROTO_DIR/incident.proto"
+BRAIN_COPIES=(
+  "./brain/src/KubeMind.Brain.Shared/Protos/incident.proto"
+  "./brain-python/proto/incident.proto"
+)
+
+if [[ "${1:-}" == "--check" ]]; then
+  drifted=0
+  for copy in "${BRAIN_COPIES[@]}"; do
+    if ! diff -q "$CANONICAL_PROTO" "$copy" >/dev/null; then
+      echo "ERROR: $copy has drifted from $CANONICAL_PROTO; run scripts/generate-proto.sh" >&2
+      drifted=1
+    fi
+  done
+  exit "$drifted"
+fi
 
 echo "Generating Go code..."
 protoc --proto_path="$PROTO_DIR" \
-       --go_out="$OBSERVER_OUT_DIR" --go_opt=paths=source_relative \
-       --go-grpc_out="$OBSERVER_OUT_DIR" --go-grpc_opt=paths=source_relative \
-       "$PROTO_DIR"/incident.proto
+       --go_out="$PROTO_DIR" --go_opt=paths=source_relative \
+       --go-grpc_out="$PROTO_DIR" --go-grpc_opt=paths=source_relative \
+       "$CANONICAL_PROTO"
 
-echo "Generating C# code..."
-# For .NET, generation is typically handled by the Grpc.Tools NuGet package
-# within the .csproj file. Removing explicit protoc --grpc_out here.
-echo "C# protobuf generation delegated to .NET project tooling."
+echo "Syncing Brain copies..."
+for copy in "${BRAIN_COPIES[@]}"; do
+  cp "$CANONICAL_PROTO" "$copy"
+done
 
+echo "C# protobuf generation delegated to .NET project tooling."
+echo "Run 'make proto' in brain-python/ to refresh the Python bindings."
 
 echo "Protobuf generation complete."
-